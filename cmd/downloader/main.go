@@ -75,6 +75,8 @@ var (
 	disableIPV6                    bool
 	disableIPV4                    bool
 	seedbox                        bool
+	mirrorUrl                      string
+	preverifiedPath                string
 )
 
 func init() {
@@ -96,6 +98,8 @@ func init() {
 	rootCmd.Flags().BoolVar(&disableIPV6, "downloader.disable.ipv6", utils.DisableIPV6.Value, utils.DisableIPV6.Usage)
 	rootCmd.Flags().BoolVar(&disableIPV4, "downloader.disable.ipv4", utils.DisableIPV4.Value, utils.DisableIPV6.Usage)
 	rootCmd.Flags().BoolVar(&seedbox, "seedbox", false, "seedbox determines to either download .torrent from webseed or not")
+	rootCmd.Flags().StringVar(&mirrorUrl, utils.DownloaderMirrorFlag.Name, "", utils.DownloaderMirrorFlag.Usage)
+	rootCmd.Flags().StringVar(&preverifiedPath, utils.DownloaderPreverifiedFlag.Name, "", utils.DownloaderPreverifiedFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&forceVerify, "verify", false, "Verify files. All by default, or passed by --verify.files")
 	rootCmd.PersistentFlags().StringArrayVar(&forceVerifyFiles, "verify.files", nil, "Limit list of files to verify")
 
@@ -161,6 +165,13 @@ func Downloader(ctx context.Context, logger log.Logger) error {
 	if err := checkChainName(ctx, dirs, chain); err != nil {
 		return err
 	}
+	if preverifiedPath != "" {
+		preverified, err := snapcfg.LoadPreverified(preverifiedPath)
+		if err != nil {
+			return err
+		}
+		snapcfg.SetPreverified(chain, preverified)
+	}
 	torrentLogLevel, _, err := downloadercfg2.Int2LogLevel(torrentVerbosity)
 	if err != nil {
 		return err
@@ -199,12 +210,23 @@ func Downloader(ctx context.Context, logger log.Logger) error {
 	downloadernat.DoNat(natif, cfg.ClientConfig, logger)
 
 	cfg.DownloadTorrentFilesFromWebseed = true // enable it only for standalone mode now. feature is not fully ready yet
-	d, err := downloader.New(ctx, cfg, dirs, logger, log.LvlInfo, seedbox)
-	if err != nil {
-		return err
+	cfg.MirrorUrl = mirrorUrl
+	var d downloader.Backend
+	if cfg.MirrorUrl != "" {
+		d, err = downloader.NewMirror(ctx, cfg, logger, log.LvlInfo)
+		if err != nil {
+			return err
+		}
+		logger.Info("[snapshots] Start mirror downloader", "mirror", cfg.MirrorUrl)
+	} else {
+		bittorrent, err := downloader.New(ctx, cfg, dirs, logger, log.LvlInfo, seedbox)
+		if err != nil {
+			return err
+		}
+		logger.Info("[snapshots] Start bittorrent server", "my_peer_id", fmt.Sprintf("%x", bittorrent.TorrentClient().PeerID()))
+		d = bittorrent
 	}
 	defer d.Close()
-	logger.Info("[snapshots] Start bittorrent server", "my_peer_id", fmt.Sprintf("%x", d.TorrentClient().PeerID()))
 
 	if forceVerify { // remove and create .torrent files (will re-read all snapshots)
 		if err = d.VerifyData(ctx, forceVerifyFiles); err != nil {
@@ -433,7 +455,7 @@ func StartGrpc(snServer *downloader.GrpcServer, addr string, creds *credentials.
 }
 
 // Add pre-configured
-func addPreConfiguredHashes(ctx context.Context, d *downloader.Downloader) error {
+func addPreConfiguredHashes(ctx context.Context, d downloader.Backend) error {
 	for _, it := range snapcfg.KnownCfg(chain, nil, nil).Preverified {
 		if err := d.AddInfoHashAsMagnetLink(ctx, snaptype.Hex2InfoHash(it.Hash), it.Name); err != nil {
 			return err
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ledgerwatch/log/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutility"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces/grpcutil"
+	txpool_proto "github.com/tenderly/erigon/erigon-lib/gointerfaces/txpool"
+
+	coretypes "github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/turbo/debug"
+)
+
+var (
+	exportFile  string
+	importBatch int
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{exportCmd, importCmd} {
+		cmd.Flags().StringVar(&txpoolApiAddr, "txpool.api.addr", "localhost:9094", "txpool service <host>:<port>")
+		cmd.Flags().StringVar(&exportFile, "file", "txpool.jsonl", "path of the export file")
+	}
+	importCmd.Flags().IntVar(&importBatch, "batch", 1000, "number of transactions added to the pool by one request")
+	rootCmd.AddCommand(exportCmd, importCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:     "export",
+	Short:   "Write the transactions of a running txpool to a JSONL file, one transaction per line",
+	Example: "go run ./cmd/txpool export --txpool.api.addr=localhost:9094 --file=txpool.jsonl",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := debug.SetupCobra(cmd, "txpool")
+		client, err := txpoolClient()
+		if err != nil {
+			return err
+		}
+		f, err := os.Create(exportFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w := bufio.NewWriter(f)
+		count, err := exportTxs(cmd.Context(), client, exportPageSize, w)
+		if err != nil {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		logger.Info("[txpool] exported", "txs", count, "file", exportFile)
+		return f.Sync()
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:     "import",
+	Short:   "Add the transactions of an export file to a running txpool, they are validated again against its state",
+	Example: "go run ./cmd/txpool import --txpool.api.addr=localhost:9094 --file=txpool.jsonl",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := debug.SetupCobra(cmd, "txpool")
+		client, err := txpoolClient()
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(exportFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		results, err := importTxs(cmd.Context(), client, f, importBatch, logger)
+		if err != nil {
+			return err
+		}
+		logArgs := []interface{}{"file", exportFile}
+		for result, count := range results {
+			logArgs = append(logArgs, result.String(), count)
+		}
+		logger.Info("[txpool] imported", logArgs...)
+		return nil
+	},
+}
+
+func txpoolClient() (txpool_proto.TxpoolClient, error) {
+	creds, err := grpcutil.TLS(TLSCACert, TLSCertfile, TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to txpool: %w", err)
+	}
+	conn, err := grpcutil.Connect(creds, txpoolApiAddr)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to txpool: %w", err)
+	}
+	return txpool_proto.NewTxpoolClient(conn), nil
+}
+
+// exportedTx is a line of the export file. The import validates the transaction again, so it can land in another
+// sub-pool than the one it was exported from
+type exportedTx struct {
+	Hash           common.Hash      `json:"hash"`
+	Sender         common.Address   `json:"sender"`
+	SubPool        string           `json:"subPool"` // pending, baseFee or queued
+	Local          bool             `json:"local"`
+	Private        bool             `json:"private,omitempty"`        // added by AddPrivateTxs, never announced to peers
+	MaxBlockNumber uint64           `json:"maxBlockNumber,omitempty"` // last block a private transaction can be included in
+	Rlp            hexutility.Bytes `json:"rlp"`
+}
+
+// sameAddRequest tells whether the transactions can be added to the pool by one request
+func (tx *exportedTx) sameAddRequest(other *exportedTx) bool {
+	return tx.Local == other.Local && tx.Private == other.Private && tx.MaxBlockNumber == other.MaxBlockNumber
+}
+
+var subPoolNames = map[txpool_proto.AllReply_TxnType]string{
+	txpool_proto.AllReply_PENDING:  "pending",
+	txpool_proto.AllReply_BASE_FEE: "baseFee",
+	txpool_proto.AllReply_QUEUED:   "queued",
+}
+
+const exportPageSize = 1000
+
+// exportTxs writes the transactions of all sub-pools ordered by sender and nonce, so the import adds them in the nonce
+// order. Pages are keyed by sender and nonce: a transaction moving between sub-pools meanwhile is written once
+func exportTxs(ctx context.Context, client txpool_proto.TxpoolClient, pageSize uint64, w io.Writer) (count int, err error) {
+	enc := json.NewEncoder(w)
+	req := &txpool_proto.ExportRequest{Limit: pageSize}
+	for {
+		reply, err := client.Export(ctx, req)
+		if err != nil {
+			return count, err
+		}
+		for _, tx := range reply.Txs {
+			txn, err := coretypes.DecodeWrappedTransaction(tx.RlpTx)
+			if err != nil {
+				return count, fmt.Errorf("decoding transaction of %x: %w", gointerfaces.ConvertH160toAddress(tx.Sender), err)
+			}
+			if err := enc.Encode(&exportedTx{
+				Hash:           txn.Hash(),
+				Sender:         gointerfaces.ConvertH160toAddress(tx.Sender),
+				SubPool:        subPoolNames[tx.TxnType],
+				Local:          tx.IsLocal,
+				Private:        tx.IsPrivate,
+				MaxBlockNumber: tx.MaxBlockNumber,
+				Rlp:            tx.RlpTx,
+			}); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if !reply.More {
+			return count, nil
+		}
+		req.FromSenderId, req.FromNonce = reply.NextSenderId, reply.NextNonce
+	}
+}
+
+// importTxs adds the transactions in the file order, keeping them local, remote or private, and counts the results
+func importTxs(ctx context.Context, client txpool_proto.TxpoolClient, r io.Reader, batch int, logger log.Logger) (map[txpool_proto.ImportResult]int, error) {
+	if batch <= 0 {
+		batch = 1
+	}
+	results := map[txpool_proto.ImportResult]int{}
+	var pending []*exportedTx
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		req := &txpool_proto.AddRequest{Remote: !pending[0].Local, Private: pending[0].Private, MaxBlockNumber: pending[0].MaxBlockNumber}
+		for _, tx := range pending {
+			req.RlpTxs = append(req.RlpTxs, tx.Rlp)
+		}
+		reply, err := client.Add(ctx, req)
+		if err != nil {
+			return err
+		}
+		for i, result := range reply.Imported {
+			results[result]++
+			if result != txpool_proto.ImportResult_SUCCESS {
+				logger.Debug("[txpool] import: not added", "hash", pending[i].Hash, "result", result, "err", reply.Errors[i])
+			}
+		}
+		pending = pending[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024) // blob transactions are large
+	for line := 1; scanner.Scan(); line++ {
+		tx := &exportedTx{}
+		if err := json.Unmarshal(scanner.Bytes(), tx); err != nil {
+			return results, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(pending) > 0 && !pending[0].sameAddRequest(tx) {
+			if err := flush(); err != nil {
+				return results, err
+			}
+		}
+		pending = append(pending, tx)
+		if len(pending) >= batch {
+			if err := flush(); err != nil {
+				return results, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return results, err
+	}
+	return results, flush()
+}
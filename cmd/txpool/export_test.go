@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces"
+	txpool_proto "github.com/tenderly/erigon/erigon-lib/gointerfaces/txpool"
+
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/crypto"
+	"github.com/tenderly/erigon/params"
+)
+
+type fakeTxpoolClient struct {
+	txpool_proto.TxpoolClient
+	content  []*txpool_proto.AllReply_Tx // of one sender, ordered by nonce
+	onExport func()                      // called before every page
+	added    []*txpool_proto.AddRequest
+}
+
+func (c *fakeTxpoolClient) Export(_ context.Context, in *txpool_proto.ExportRequest, _ ...grpc.CallOption) (*txpool_proto.ExportReply, error) {
+	if c.onExport != nil {
+		c.onExport()
+	}
+	reply := &txpool_proto.ExportReply{}
+	for nonce := in.FromNonce; nonce < uint64(len(c.content)); nonce++ {
+		if uint64(len(reply.Txs)) == in.Limit {
+			reply.More, reply.NextSenderId, reply.NextNonce = true, 1, nonce
+			break
+		}
+		reply.Txs = append(reply.Txs, c.content[nonce])
+	}
+	return reply, nil
+}
+
+func (c *fakeTxpoolClient) Add(_ context.Context, in *txpool_proto.AddRequest, _ ...grpc.CallOption) (*txpool_proto.AddReply, error) {
+	c.added = append(c.added, in)
+	reply := &txpool_proto.AddReply{}
+	for range in.RlpTxs {
+		reply.Imported = append(reply.Imported, txpool_proto.ImportResult_SUCCESS)
+		reply.Errors = append(reply.Errors, "")
+	}
+	return reply, nil
+}
+
+func TestExportImport(t *testing.T) {
+	require := require.New(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.LatestSignerForChainID(params.MainnetChainConfig.ChainID)
+
+	source := &fakeTxpoolClient{}
+	var hashes []common.Hash
+	for nonce := uint64(0); nonce < 5; nonce++ {
+		txn, err := types.SignTx(types.NewTransaction(nonce, common.Address{1}, uint256.NewInt(1), params.TxGas, uint256.NewInt(params.GWei), nil), *signer, key)
+		require.NoError(err)
+		var buf bytes.Buffer
+		require.NoError(txn.MarshalBinary(&buf))
+		txnType := txpool_proto.AllReply_PENDING
+		if nonce >= 3 {
+			txnType = txpool_proto.AllReply_QUEUED
+		}
+		source.content = append(source.content, &txpool_proto.AllReply_Tx{
+			TxnType: txnType,
+			Sender:  gointerfaces.ConvertAddressToH160(sender),
+			RlpTx:   buf.Bytes(),
+			IsLocal: nonce < 2,
+		})
+		hashes = append(hashes, txn.Hash())
+	}
+
+	last := source.content[4]
+	last.IsLocal, last.IsPrivate, last.MaxBlockNumber = true, true, 100
+	// the queued transaction moves to pending while the pool is paged, it's exported once
+	source.onExport = func() { source.content[3].TxnType = txpool_proto.AllReply_PENDING }
+
+	var file bytes.Buffer
+	count, err := exportTxs(context.Background(), source, 2, &file)
+	require.NoError(err)
+	require.Equal(5, count)
+	lines := strings.Split(strings.TrimSpace(file.String()), "\n")
+	require.Len(lines, 5)
+	for i, line := range lines {
+		require.Contains(line, hashes[i].Hex())
+	}
+	require.Contains(lines[0], `"subPool":"pending"`)
+	require.NotContains(lines[0], `"private"`)
+	require.Contains(lines[3], `"subPool":"pending"`)
+	require.Contains(lines[4], `"subPool":"queued","local":true,"private":true,"maxBlockNumber":100`)
+
+	target := &fakeTxpoolClient{}
+	results, err := importTxs(context.Background(), target, &file, 2, log.New())
+	require.NoError(err)
+	require.Equal(map[txpool_proto.ImportResult]int{txpool_proto.ImportResult_SUCCESS: 5}, results)
+	// the batches are split where the origin changes, so the nonce order is kept
+	require.Len(target.added, 3)
+	for i, req := range target.added {
+		require.Equal(i == 1, req.Remote)
+		require.Equal(i == 2, req.Private)
+		require.Len(req.RlpTxs, []int{2, 2, 1}[i])
+	}
+	require.Equal(uint64(100), target.added[2].MaxBlockNumber)
+	require.Equal(source.content[0].RlpTx, target.added[0].RlpTxs[0])
+}
@@ -268,6 +268,20 @@ var (
 		Name:  "miner.noverify",
 		Usage: "Disable remote sealing verification",
 	}
+	MinerOrderingFlag = cli.StringFlag{
+		Name:  "miner.ordering",
+		Usage: "Order of the pool transactions in mined blocks: pool (txpool order), tip (highest effective tip first), profit (highest simulated value per gas first) or iterative (profit, improved by simulating swaps)",
+		Value: "pool",
+	}
+	MinerOrderingBudgetFlag = cli.DurationFlag{
+		Name:  "miner.ordering.budget",
+		Usage: "Time the iterative ordering spends improving each batch of transactions",
+		Value: ethconfig.Defaults.Miner.OrderingBudget,
+	}
+	MinerOrderingGainFlag = cli.BoolFlag{
+		Name:  "miner.ordering.gain",
+		Usage: "Report the gain of --miner.ordering over the pool order in the mining_ordering_gain_gwei metric, simulating every batch of transactions in both orders",
+	}
 	VMEnableDebugFlag = cli.BoolFlag{
 		Name:  "vmdebug",
 		Usage: "Record information useful for VM and contract debugging",
@@ -500,9 +514,14 @@ var (
 	// The current default has been chosen arbitrarily as 'useful' without likely being overly computationally intense.
 	RpcMaxGetProofRewindBlockCount = cli.IntFlag{
 		Name:  "rpc.maxgetproofrewindblockcount.limit",
-		Usage: "Max GetProof rewind block count",
+		Usage: "Max GetProof and debug_intermediateRoots rewind block count",
 		Value: 100_000,
 	}
+	RpcTraceDirFlag = cli.StringFlag{
+		Name:  "rpc.tracedir",
+		Usage: "Directory, relative to the datadir, where debug_standardTraceBlockToFile and debug_standardTraceBadBlockToFile write their trace files",
+		Value: "traces",
+	}
 	StateCacheFlag = cli.StringFlag{
 		Name:  "state.cache",
 		Value: "0MB",
@@ -547,6 +566,14 @@ var (
 		Name:  "downloader.api.addr",
 		Usage: "downloader address '<host>:<port>'",
 	}
+	DownloaderPreverifiedFlag = cli.PathFlag{
+		Name:  "downloader.preverified",
+		Usage: "Trust the preverified.toml manifest of this file, as made by `snapshots publish`, instead of the embedded one of the chain",
+	}
+	DownloaderMirrorFlag = cli.StringFlag{
+		Name:  "downloader.mirror",
+		Usage: "Download the snapshots from a directory or an http(s) file server instead of BitTorrent, verified against the preverified hashes",
+	}
 	BootnodesFlag = cli.StringFlag{
 		Name:  "bootnodes",
 		Usage: "Comma separated enode URLs for P2P discovery bootstrap",
@@ -1510,6 +1537,15 @@ func setMiner(ctx *cli.Context, cfg *params.MiningConfig) {
 	if ctx.IsSet(MinerNoVerfiyFlag.Name) {
 		cfg.Noverify = ctx.Bool(MinerNoVerfiyFlag.Name)
 	}
+	if ctx.IsSet(MinerOrderingFlag.Name) {
+		cfg.Ordering = ctx.String(MinerOrderingFlag.Name)
+	}
+	if ctx.IsSet(MinerOrderingBudgetFlag.Name) {
+		cfg.OrderingBudget = ctx.Duration(MinerOrderingBudgetFlag.Name)
+	}
+	if ctx.IsSet(MinerOrderingGainFlag.Name) {
+		cfg.OrderingGain = ctx.Bool(MinerOrderingGainFlag.Name)
+	}
 }
 
 func setWhitelist(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1615,6 +1651,9 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	cfg.Snapshot.Produce = !ctx.Bool(SnapStopFlag.Name)
 	cfg.Snapshot.NoDownloader = ctx.Bool(NoDownloaderFlag.Name)
 	cfg.Snapshot.Verify = ctx.Bool(DownloaderVerifyFlag.Name)
+	if err := SetPreverifiedFromFlag(ctx); err != nil {
+		panic(err)
+	}
 	cfg.Snapshot.DownloaderAddr = strings.TrimSpace(ctx.String(DownloaderAddrFlag.Name))
 	if cfg.Snapshot.DownloaderAddr == "" {
 		downloadRateStr := ctx.String(TorrentDownloadRateFlag.Name)
@@ -1641,6 +1680,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		if err != nil {
 			panic(err)
 		}
+		cfg.Downloader.MirrorUrl = strings.TrimSpace(ctx.String(DownloaderMirrorFlag.Name))
 		downloadernat.DoNat(nodeConfig.P2P.NAT, cfg.Downloader.ClientConfig, logger)
 	}
 
@@ -1797,3 +1837,17 @@ func CobraFlags(cmd *cobra.Command, urfaveCliFlagsLists ...[]cli.Flag) {
 		}
 	}
 }
+
+// SetPreverifiedFromFlag makes the chain trust the manifest of --downloader.preverified, if set
+func SetPreverifiedFromFlag(ctx *cli.Context) error {
+	path := ctx.String(DownloaderPreverifiedFlag.Name)
+	if path == "" {
+		return nil
+	}
+	preverified, err := snapcfg.LoadPreverified(path)
+	if err != nil {
+		return err
+	}
+	snapcfg.SetPreverified(ctx.String(ChainFlag.Name), preverified)
+	return nil
+}
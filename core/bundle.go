@@ -0,0 +1,60 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/tenderly/erigon/erigon-lib/chain"
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+
+	"github.com/tenderly/erigon/consensus"
+	"github.com/tenderly/erigon/core/state"
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/crypto"
+)
+
+// BundleTxResult is the outcome of a transaction applied by ApplyBundle
+type BundleTxResult struct {
+	Receipt *types.Receipt // nil with vm.Config.NoReceipts
+	Result  *ExecutionResult
+}
+
+// BundleHash is the hash of the concatenated hashes of the bundle transactions
+func BundleHash(txs types.Transactions) libcommon.Hash {
+	hashes := make([][]byte, len(txs))
+	for i, txn := range txs {
+		hash := txn.Hash()
+		hashes[i] = hash[:]
+	}
+	return crypto.Keccak256Hash(hashes...)
+}
+
+// ApplyBundle applies the transactions of a bundle in order, the first one at index txIndex of the block.
+// It stops with an error at the first transaction that can't be applied, or that fails when canRevert
+// doesn't allow it to; a nil canRevert allows all of them. After every transaction it stops with the error
+// of interrupted, if it's not nil and returns one, e.g. when the EVM is cancelled by a timeout. The state
+// changes of the applied transactions are kept: they can't be reverted across transactions, so a bundle
+// that must be included atomically is applied on a throwaway state first.
+func ApplyBundle(config *chain.Config, engine consensus.EngineReader, gp *GasPool, ibs *state.IntraBlockState,
+	stateWriter state.StateWriter, header *types.Header, txs types.Transactions, txIndex int, canRevert func(libcommon.Hash) bool,
+	interrupted func() error, usedGas, usedBlobGas *uint64, evm *vm.EVM, cfg vm.Config,
+) ([]*BundleTxResult, error) {
+	results := make([]*BundleTxResult, 0, len(txs))
+	for i, txn := range txs {
+		ibs.SetTxContext(txn.Hash(), libcommon.Hash{}, txIndex+i)
+		receipt, result, err := applyTransaction(config, engine, gp, ibs, stateWriter, header, txn, usedGas, usedBlobGas, evm, cfg)
+		if err != nil {
+			return results, fmt.Errorf("bundle transaction %x: %w", txn.Hash(), err)
+		}
+		if result.Failed() && canRevert != nil && !canRevert(txn.Hash()) {
+			return results, fmt.Errorf("bundle transaction %x reverted: %w", txn.Hash(), result.Err)
+		}
+		results = append(results, &BundleTxResult{Receipt: receipt, Result: result})
+		if interrupted != nil {
+			if err = interrupted(); err != nil {
+				return results, err
+			}
+		}
+	}
+	return results, nil
+}
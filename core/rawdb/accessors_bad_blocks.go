@@ -0,0 +1,91 @@
+package rawdb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/kv"
+	"github.com/tenderly/erigon/erigon-lib/kv/dbutils"
+
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/rlp"
+)
+
+// BadBlockLimit is the maximum number of invalid blocks kept in kv.BadBlocks
+const BadBlockLimit = 10
+
+// BadBlock is a block rejected by the block validation, stored together with the reason of rejection
+type BadBlock struct {
+	Block  *types.Block
+	Reason string
+}
+
+// WriteBadBlock stores an invalid block. Only the BadBlockLimit blocks with the highest numbers are kept.
+func WriteBadBlock(tx kv.RwTx, block *types.Block, reason string) error {
+	data, err := rlp.EncodeToBytes(&BadBlock{Block: block, Reason: reason})
+	if err != nil {
+		return fmt.Errorf("failed to RLP encode bad block: %w", err)
+	}
+	if err = tx.Put(kv.BadBlocks, dbutils.HeaderKey(block.NumberU64(), block.Hash()), data); err != nil {
+		return fmt.Errorf("failed to store bad block: %w", err)
+	}
+
+	c, err := tx.RwCursor(kv.BadBlocks)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	count, err := c.Count()
+	if err != nil {
+		return err
+	}
+	for ; count > BadBlockLimit; count-- {
+		if _, _, err = c.First(); err != nil {
+			return err
+		}
+		if err = c.DeleteCurrent(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadBadBlock retrieves an invalid block by its hash, nil is returned if the block is not stored
+func ReadBadBlock(tx kv.Tx, hash common.Hash) (*BadBlock, error) {
+	var res *BadBlock
+	if err := tx.ForEach(kv.BadBlocks, nil, func(k, v []byte) error {
+		if res != nil || !bytes.Equal(k[8:], hash[:]) {
+			return nil
+		}
+		res = new(BadBlock)
+		return rlp.DecodeBytes(v, res)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read bad block %x: %w", hash, err)
+	}
+	return res, nil
+}
+
+// ReadAllBadBlocks retrieves all the stored invalid blocks, the highest block number first
+func ReadAllBadBlocks(tx kv.Tx) ([]*BadBlock, error) {
+	var res []*BadBlock
+	if err := tx.ForEach(kv.BadBlocks, nil, func(k, v []byte) error {
+		badBlock := new(BadBlock)
+		if err := rlp.DecodeBytes(v, badBlock); err != nil {
+			return err
+		}
+		res = append(res, badBlock)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read bad blocks: %w", err)
+	}
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res, nil
+}
+
+// DeleteBadBlocks removes all the stored invalid blocks
+func DeleteBadBlocks(tx kv.RwTx) error {
+	return tx.ClearBucket(kv.BadBlocks)
+}
@@ -0,0 +1,124 @@
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/length"
+	"github.com/tenderly/erigon/erigon-lib/kv"
+	"github.com/tenderly/erigon/erigon-lib/kv/dbutils"
+
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/rlp"
+)
+
+// BlobSidecarsRetentionBlocks is the number of the most recent blocks for which the blob sidecars are kept.
+// It is MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS (4096) epochs of 32 slots, there is at most one block per slot,
+// so the window covers at least the period during which the consensus layer has to serve the blobs.
+const BlobSidecarsRetentionBlocks = 4096 * 32
+
+// BlobSidecar holds the blobs of a blob transaction together with their KZG commitments and proofs
+type BlobSidecar struct {
+	Blobs       types.Blobs
+	Commitments types.BlobKzgs
+	Proofs      types.KZGProofs
+}
+
+// WriteBlobSidecar stores the blobs of a mined transaction and indexes them by their versioned hashes
+func WriteBlobSidecar(tx kv.RwTx, blockNum uint64, txHash common.Hash, sidecar *BlobSidecar) error {
+	if len(sidecar.Blobs) != len(sidecar.Commitments) || len(sidecar.Blobs) != len(sidecar.Proofs) {
+		return fmt.Errorf("blob sidecar of %x: %d blobs, %d commitments, %d proofs", txHash, len(sidecar.Blobs), len(sidecar.Commitments), len(sidecar.Proofs))
+	}
+	data, err := rlp.EncodeToBytes(sidecar)
+	if err != nil {
+		return fmt.Errorf("failed to RLP encode blob sidecar: %w", err)
+	}
+	key := dbutils.HeaderKey(blockNum, txHash)
+	if err = tx.Put(kv.BlobSidecars, key, data); err != nil {
+		return fmt.Errorf("failed to store blob sidecar: %w", err)
+	}
+	for _, commitment := range sidecar.Commitments {
+		versionedHash := commitment.ComputeVersionedHash()
+		if err = tx.Put(kv.BlobVersionedHashes, versionedHash[:], key); err != nil {
+			return fmt.Errorf("failed to store blob versioned hash: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReadBlobSidecar retrieves the blobs of a mined transaction, nil is returned if they are not stored
+func ReadBlobSidecar(tx kv.Getter, blockNum uint64, txHash common.Hash) (*BlobSidecar, error) {
+	data, err := tx.GetOne(kv.BlobSidecars, dbutils.HeaderKey(blockNum, txHash))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	sidecar := new(BlobSidecar)
+	if err = rlp.DecodeBytes(data, sidecar); err != nil {
+		return nil, fmt.Errorf("invalid blob sidecar RLP of %x: %w", txHash, err)
+	}
+	return sidecar, nil
+}
+
+// ReadBlobSidecarLookup returns the block number and the hash of the mined transaction carrying the blob with the given versioned hash
+func ReadBlobSidecarLookup(tx kv.Getter, versionedHash common.Hash) (blockNum uint64, txHash common.Hash, ok bool, err error) {
+	v, err := tx.GetOne(kv.BlobVersionedHashes, versionedHash[:])
+	if err != nil {
+		return 0, common.Hash{}, false, err
+	}
+	if len(v) != 8+length.Hash {
+		return 0, common.Hash{}, false, nil
+	}
+	return binary.BigEndian.Uint64(v), common.BytesToHash(v[8:]), true, nil
+}
+
+// DeleteBlobSidecarsFrom removes the blobs of the blocks starting from blockFrom, used by unwind
+func DeleteBlobSidecarsFrom(tx kv.RwTx, blockFrom uint64) error {
+	return deleteBlobSidecars(tx, blockFrom, func(blockNum uint64) bool { return true })
+}
+
+// PruneBlobSidecars removes the blobs of the blocks before blockTo
+func PruneBlobSidecars(tx kv.RwTx, blockTo uint64) error {
+	return deleteBlobSidecars(tx, 0, func(blockNum uint64) bool { return blockNum < blockTo })
+}
+
+func deleteBlobSidecars(tx kv.RwTx, blockFrom uint64, inRange func(blockNum uint64) bool) error {
+	c, err := tx.RwCursor(kv.BlobSidecars)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	for k, v, err := c.Seek(dbutils.EncodeBlockNumber(blockFrom)); k != nil; k, v, err = c.Next() {
+		if err != nil {
+			return err
+		}
+		if !inRange(binary.BigEndian.Uint64(k)) {
+			break
+		}
+		sidecar := new(BlobSidecar)
+		if err = rlp.DecodeBytes(v, sidecar); err != nil {
+			return fmt.Errorf("invalid blob sidecar RLP: %w", err)
+		}
+		for _, commitment := range sidecar.Commitments {
+			versionedHash := commitment.ComputeVersionedHash()
+			// the transaction may have been mined again in another block
+			lookup, err := tx.GetOne(kv.BlobVersionedHashes, versionedHash[:])
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(lookup, k) {
+				if err = tx.Delete(kv.BlobVersionedHashes, versionedHash[:]); err != nil {
+					return err
+				}
+			}
+		}
+		if err = c.DeleteCurrent(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
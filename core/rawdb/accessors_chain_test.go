@@ -761,3 +761,95 @@ func checkReceiptsRLP(have, want types.Receipts) error {
 	}
 	return nil
 }
+
+// Tests that only the bad blocks with the highest numbers are kept.
+func TestBadBlockStorage(t *testing.T) {
+	t.Parallel()
+	m := mock.Mock(t)
+	tx, err := m.DB.BeginRw(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	var blocks []*types.Block
+	for i := 0; i < rawdb.BadBlockLimit+2; i++ {
+		header := &types.Header{Number: big.NewInt(int64(i + 1)), Extra: []byte("bad block")}
+		blocks = append(blocks, types.NewBlockWithHeader(header))
+		require.NoError(t, rawdb.WriteBadBlock(tx, blocks[i], fmt.Sprintf("reason %d", i)))
+	}
+
+	badBlock, err := rawdb.ReadBadBlock(tx, blocks[0].Hash())
+	require.NoError(t, err)
+	require.Nil(t, badBlock)
+	badBlock, err = rawdb.ReadBadBlock(tx, blocks[5].Hash())
+	require.NoError(t, err)
+	require.Equal(t, blocks[5].Hash(), badBlock.Block.Hash())
+	require.Equal(t, "reason 5", badBlock.Reason)
+
+	all, err := rawdb.ReadAllBadBlocks(tx)
+	require.NoError(t, err)
+	require.Len(t, all, rawdb.BadBlockLimit)
+	for i, badBlock := range all {
+		require.Equal(t, blocks[len(blocks)-1-i].Hash(), badBlock.Block.Hash())
+	}
+
+	require.NoError(t, rawdb.DeleteBadBlocks(tx))
+	all, err = rawdb.ReadAllBadBlocks(tx)
+	require.NoError(t, err)
+	require.Empty(t, all)
+}
+
+func TestBlobSidecarStorage(t *testing.T) {
+	t.Parallel()
+	m := mock.Mock(t)
+	tx, err := m.DB.BeginRw(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	sidecars := make([]*rawdb.BlobSidecar, 4)
+	txHashes := make([]libcommon.Hash, len(sidecars))
+	for i := range sidecars {
+		sidecar := &rawdb.BlobSidecar{Blobs: make(types.Blobs, 2), Commitments: make(types.BlobKzgs, 2), Proofs: make(types.KZGProofs, 2)}
+		for j := range sidecar.Blobs {
+			sidecar.Blobs[j][0], sidecar.Commitments[j][0], sidecar.Proofs[j][0] = byte(i), byte(i), byte(i)
+			sidecar.Blobs[j][1], sidecar.Commitments[j][1], sidecar.Proofs[j][1] = byte(j), byte(j), byte(j)
+		}
+		sidecars[i], txHashes[i] = sidecar, libcommon.Hash{byte(i + 1)}
+		require.NoError(t, rawdb.WriteBlobSidecar(tx, uint64(i+1), txHashes[i], sidecar))
+	}
+	invalid := &rawdb.BlobSidecar{Blobs: make(types.Blobs, 1)}
+	require.Error(t, rawdb.WriteBlobSidecar(tx, 5, libcommon.Hash{5}, invalid))
+
+	for i, sidecar := range sidecars {
+		stored, err := rawdb.ReadBlobSidecar(tx, uint64(i+1), txHashes[i])
+		require.NoError(t, err)
+		require.Equal(t, sidecar, stored)
+		for _, commitment := range sidecar.Commitments {
+			blockNum, txHash, ok, err := rawdb.ReadBlobSidecarLookup(tx, commitment.ComputeVersionedHash())
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, uint64(i+1), blockNum)
+			require.Equal(t, txHashes[i], txHash)
+		}
+	}
+	stored, err := rawdb.ReadBlobSidecar(tx, 2, txHashes[0])
+	require.NoError(t, err)
+	require.Nil(t, stored)
+
+	// the first transaction is mined again, pruning the original block must keep its lookup
+	require.NoError(t, rawdb.WriteBlobSidecar(tx, 5, txHashes[0], sidecars[0]))
+	require.NoError(t, rawdb.PruneBlobSidecars(tx, 2))
+	blockNum, _, ok, err := rawdb.ReadBlobSidecarLookup(tx, sidecars[0].Commitments[0].ComputeVersionedHash())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(5), blockNum)
+
+	require.NoError(t, rawdb.DeleteBlobSidecarsFrom(tx, 4))
+	for i, sidecar := range sidecars {
+		stored, err := rawdb.ReadBlobSidecar(tx, uint64(i+1), txHashes[i])
+		require.NoError(t, err)
+		_, _, ok, err := rawdb.ReadBlobSidecarLookup(tx, sidecar.Commitments[0].ComputeVersionedHash())
+		require.NoError(t, err)
+		require.Equal(t, i == 1 || i == 2, stored != nil, "block %d", i+1)
+		require.Equal(t, i == 1 || i == 2, ok, "block %d", i+1)
+	}
+}
@@ -2,6 +2,8 @@ package snapcfg
 
 import (
 	_ "embed"
+	"fmt"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -108,6 +110,24 @@ var KnownCfgs = map[string]*Cfg{
 	networkname.ChiadoChainName:     ChiadoChainSnapshotCfg,
 }
 
+// LoadPreverified reads a manifest of `'name' = 'infohash'` lines, in the format of the embedded ones
+func LoadPreverified(path string) (Preverified, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var outMap preverified
+	if err := toml.Unmarshal(data, &outMap); err != nil {
+		return nil, fmt.Errorf("preverified manifest %s: %w", path, err)
+	}
+	return doSort(outMap), nil
+}
+
+// SetPreverified makes the network trust the manifest instead of the embedded list of preverified hashes
+func SetPreverified(networkName string, preverified Preverified) {
+	KnownCfgs[networkName] = newCfg(preverified)
+}
+
 // KnownCfg return list of preverified hashes for given network, but apply whiteList filter if it's not empty
 func KnownCfg(networkName string, whiteList, whiteListHistory []string) *Cfg {
 	c, ok := KnownCfgs[networkName]
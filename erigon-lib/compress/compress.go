@@ -58,6 +58,7 @@ type Compressor struct {
 	outputFile       string // File where to output the dictionary and compressed data
 	tmpOutFilePath   string // File where to output the dictionary and compressed data
 	suffixCollectors []*etl.Collector
+	dict             *Dictionary // Shared dictionary, if set - the dictionary is not built from the words
 	// Buffer for "superstring" - transformation of superstrings where each byte of a word, say b,
 	// is turned into 2 bytes, 0x01 and b, and two zero bytes 0x00 0x00 are inserted after each word
 	// this is needed for using ordinary (one string) suffix sorting algorithm instead of a generalised (many superstrings) suffix
@@ -130,6 +131,10 @@ func (c *Compressor) SetTrace(trace bool) { c.trace = trace }
 
 func (c *Compressor) Count() int { return int(c.wordsCount) }
 
+// SetDictionary - compress words with the shared dictionary (see DictionaryTrainer) instead of building
+// own one. Must be called before adding words. Such file can be read only by NewDecompressorWithDictionary
+func (c *Compressor) SetDictionary(dict *Dictionary) { c.dict = dict }
+
 func (c *Compressor) AddWord(word []byte) error {
 	select {
 	case <-c.ctx.Done():
@@ -138,6 +143,9 @@ func (c *Compressor) AddWord(word []byte) error {
 	}
 
 	c.wordsCount++
+	if c.dict != nil {
+		return c.uncompressedFile.Append(word)
+	}
 	l := 2*len(word) + 2
 	if c.superstringLen+l > superstringLimit {
 		if c.superstringCount%samplingFactor == 0 {
@@ -184,10 +192,14 @@ func (c *Compressor) Compress() error {
 		c.logger.Log(c.lvl, fmt.Sprintf("[%s] BuildDict start", c.logPrefix), "workers", c.workers)
 	}
 	t := time.Now()
-	db, err := DictionaryBuilderFromCollectors(c.ctx, compressLogPrefix, c.tmpDir, c.suffixCollectors, c.lvl, c.logger)
-	if err != nil {
-
-		return err
+	var db *DictionaryBuilder
+	if c.dict != nil {
+		db = c.dict.builder()
+	} else {
+		var err error
+		if db, err = DictionaryBuilderFromCollectors(c.ctx, compressLogPrefix, c.tmpDir, c.suffixCollectors, c.lvl, c.logger); err != nil {
+			return err
+		}
 	}
 	if c.trace {
 		_, fileName := filepath.Split(c.outputFile)
@@ -206,7 +218,7 @@ func (c *Compressor) Compress() error {
 	}
 	defer cf.Close()
 	t = time.Now()
-	if err := reducedict(c.ctx, c.trace, c.logPrefix, c.tmpOutFilePath, cf, c.uncompressedFile, c.workers, db, c.lvl, c.logger); err != nil {
+	if err := reducedict(c.ctx, c.trace, c.logPrefix, c.tmpOutFilePath, cf, c.uncompressedFile, c.workers, db, c.dict, c.lvl, c.logger); err != nil {
 		return err
 	}
 	if err = c.fsync(cf); err != nil {
@@ -375,6 +387,7 @@ type Pattern struct {
 	code     uint64 // Allocated numerical code
 	codeBits int    // Number of bits in the code
 	depth    int    // Depth of the pattern in the huffman tree (for encoding in the file)
+	dictIdx  uint64 // Index of the pattern in the shared Dictionary (if it's used)
 }
 
 // PatternList is a sorted list of pattern for the purpose of
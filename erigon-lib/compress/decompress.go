@@ -145,6 +145,12 @@ func SetDecompressionTableCondensity(fromBitSize int) {
 }
 
 func NewDecompressor(compressedFilePath string) (d *Decompressor, err error) {
+	return NewDecompressorWithDictionary(compressedFilePath, nil)
+}
+
+// NewDecompressorWithDictionary - opens file compressed with the shared dictionary (see Compressor.SetDictionary).
+// Files which embed their own dictionary are opened as by NewDecompressor, ignoring `dict`
+func NewDecompressorWithDictionary(compressedFilePath string, dict *Dictionary) (d *Decompressor, err error) {
 	_, fName := filepath.Split(compressedFilePath)
 	d = &Decompressor{
 		filePath: compressedFilePath,
@@ -181,6 +187,8 @@ func NewDecompressor(compressedFilePath string) (d *Decompressor, err error) {
 	d.wordsCount = binary.BigEndian.Uint64(d.data[:8])
 	d.emptyWordsCount = binary.BigEndian.Uint64(d.data[8:16])
 	dictSize := binary.BigEndian.Uint64(d.data[16:24])
+	shared := dictSize&sharedDictionaryFlag != 0
+	dictSize &^= sharedDictionaryFlag
 	data := d.data[24 : 24+dictSize]
 
 	var depths []uint64
@@ -188,6 +196,16 @@ func NewDecompressor(compressedFilePath string) (d *Decompressor, err error) {
 	var i uint64
 	var patternMaxDepth uint64
 
+	if shared {
+		id := binary.BigEndian.Uint64(data[:8])
+		if dict == nil {
+			return nil, fmt.Errorf("compressed file requires shared dictionary %x", id)
+		}
+		if dict.id != id {
+			return nil, fmt.Errorf("%w: %x, given %x", ErrDictionaryMismatch, id, dict.id)
+		}
+		i = 8
+	}
 	for i < dictSize {
 		d, ns := binary.Uvarint(data[i:])
 		if d > 64 { // mainnet has maxDepth 31
@@ -198,6 +216,15 @@ func NewDecompressor(compressedFilePath string) (d *Decompressor, err error) {
 			patternMaxDepth = d
 		}
 		i += uint64(ns)
+		if shared {
+			idx, n := binary.Uvarint(data[i:])
+			if idx >= uint64(dict.Len()) {
+				return nil, fmt.Errorf("dictionary is invalid: pattern %d of %d", idx, dict.Len())
+			}
+			i += uint64(n)
+			patterns = append(patterns, dict.patterns[idx])
+			continue
+		}
 		l, n := binary.Uvarint(data[i:])
 		i += uint64(n)
 		patterns = append(patterns, data[i:i+l])
@@ -205,7 +232,7 @@ func NewDecompressor(compressedFilePath string) (d *Decompressor, err error) {
 		i += l
 	}
 
-	if dictSize > 0 {
+	if len(patterns) > 0 {
 		var bitLen int
 		if patternMaxDepth > 9 {
 			bitLen = 9
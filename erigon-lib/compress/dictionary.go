@@ -0,0 +1,236 @@
+/*
+   Copyright 2023 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compress
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ledgerwatch/log/v3"
+	dir2 "github.com/tenderly/erigon/erigon-lib/common/dir"
+	"github.com/tenderly/erigon/erigon-lib/etl"
+)
+
+// sharedDictionaryFlag is set in the (big endian) pattern dictionary size of the files compressed with
+// a shared Dictionary. Pattern section of such file starts with the ID of the dictionary, followed by
+// pairs of (huffman depth, index of the pattern in the dictionary) instead of the pattern words
+const sharedDictionaryFlag = uint64(1) << 63
+
+var ErrDictionaryMismatch = errors.New("compressed file references another dictionary")
+
+// Dictionary is a set of patterns trained once from a sample of words, which can be reused by
+// many Compressors (for example, by all segments of one type), instead of building the dictionary
+// of every file from its own words. Files compressed with it don't store the patterns - only
+// their huffman codes and the ID of the dictionary, so it has to be passed to the Decompressor
+type Dictionary struct {
+	id       uint64
+	scores   []uint64
+	patterns [][]byte // Ordered by descending score, index of pattern is its code in the compressed files
+}
+
+func (d *Dictionary) ID() uint64 { return d.id }
+func (d *Dictionary) Len() int   { return len(d.patterns) }
+
+// builder - returns DictionaryBuilder which iterates (ForEach) over patterns in dictionary order
+func (d *Dictionary) builder() *DictionaryBuilder {
+	db := &DictionaryBuilder{limit: maxDictPatterns, items: make([]*Pattern, len(d.patterns))}
+	for i, p := range d.patterns {
+		db.items[len(d.patterns)-1-i] = &Pattern{word: p, score: d.scores[i]}
+	}
+	return db
+}
+
+// encode - format is: amount of patterns (8 bytes), then score, length and word of each pattern (varints)
+func (d *Dictionary) encode() []byte {
+	var numBuf [binary.MaxVarintLen64]byte
+	buf := binary.BigEndian.AppendUint64(nil, uint64(len(d.patterns)))
+	for i, p := range d.patterns {
+		n := binary.PutUvarint(numBuf[:], d.scores[i])
+		buf = append(buf, numBuf[:n]...)
+		n = binary.PutUvarint(numBuf[:], uint64(len(p)))
+		buf = append(buf, numBuf[:n]...)
+		buf = append(buf, p...)
+	}
+	return buf
+}
+
+func dictionaryID(encoded []byte) uint64 {
+	h := sha256.Sum256(encoded)
+	return binary.BigEndian.Uint64(h[:8])
+}
+
+func newDictionary(db *DictionaryBuilder) *Dictionary {
+	d := &Dictionary{}
+	db.ForEach(func(score uint64, word []byte) {
+		d.scores = append(d.scores, score)
+		d.patterns = append(d.patterns, word)
+	})
+	d.id = dictionaryID(d.encode())
+	return d
+}
+
+// Save - writes dictionary atomically: to .tmp file, which is renamed when fully written
+func (d *Dictionary) Save(filePath string) error {
+	tmpFilePath := filePath + ".tmp"
+	defer os.Remove(tmpFilePath)
+	f, err := os.Create(tmpFilePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriterSize(f, etl.BufIOSize)
+	if _, err = w.Write(d.encode()); err != nil {
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilePath, filePath)
+}
+
+func LoadDictionary(filePath string) (*Dictionary, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 8 {
+		return nil, fmt.Errorf("dictionary file is too short: %s, %d", filePath, len(data))
+	}
+	count := binary.BigEndian.Uint64(data[:8])
+	if count > maxDictPatterns {
+		return nil, fmt.Errorf("dictionary is invalid: %s, patterns=%d", filePath, count)
+	}
+	d := &Dictionary{scores: make([]uint64, 0, count), patterns: make([][]byte, 0, count), id: dictionaryID(data)}
+	i := 8
+	for j := uint64(0); j < count; j++ {
+		score, n := binary.Uvarint(data[i:])
+		if n <= 0 {
+			return nil, fmt.Errorf("dictionary is invalid: %s, pattern %d", filePath, j)
+		}
+		i += n
+		l, n := binary.Uvarint(data[i:])
+		if n <= 0 || l > maxPatternLen || uint64(len(data)-i-n) < l {
+			return nil, fmt.Errorf("dictionary is invalid: %s, pattern %d", filePath, j)
+		}
+		i += n
+		d.scores = append(d.scores, score)
+		d.patterns = append(d.patterns, data[i:i+int(l)])
+		i += int(l)
+	}
+	if i != len(data) {
+		return nil, fmt.Errorf("dictionary is invalid: %s, %d trailing bytes", filePath, len(data)-i)
+	}
+	return d, nil
+}
+
+// DictionaryTrainer builds a Dictionary from a sample of words, the same way as Compressor does
+// for its own words (but without skipping superstrings - sampling is up to the caller)
+type DictionaryTrainer struct {
+	ctx              context.Context
+	wg               *sync.WaitGroup
+	superstrings     chan []byte
+	suffixCollectors []*etl.Collector
+	superstring      []byte
+	tmpDir           string
+	logPrefix        string
+	workers          int
+	lvl              log.Lvl
+	logger           log.Logger
+}
+
+func NewDictionaryTrainer(ctx context.Context, logPrefix, tmpDir string, minPatternScore uint64, workers int, lvl log.Lvl, logger log.Logger) *DictionaryTrainer {
+	dir2.MustExist(tmpDir)
+	superstrings := make(chan []byte, workers*2)
+	wg := &sync.WaitGroup{}
+	wg.Add(workers)
+	suffixCollectors := make([]*etl.Collector, workers)
+	for i := 0; i < workers; i++ {
+		collector := etl.NewCollector(logPrefix+"_dict", tmpDir, etl.NewSortableBuffer(etl.BufferOptimalSize/2), logger)
+		collector.LogLvl(lvl)
+
+		suffixCollectors[i] = collector
+		go processSuperstring(ctx, superstrings, collector, minPatternScore, wg, logger)
+	}
+	return &DictionaryTrainer{
+		ctx:              ctx,
+		wg:               wg,
+		superstrings:     superstrings,
+		suffixCollectors: suffixCollectors,
+		tmpDir:           tmpDir,
+		logPrefix:        logPrefix,
+		workers:          workers,
+		lvl:              lvl,
+		logger:           logger,
+	}
+}
+
+func (t *DictionaryTrainer) AddWord(word []byte) error {
+	select {
+	case <-t.ctx.Done():
+		return t.ctx.Err()
+	default:
+	}
+
+	if len(t.superstring)+2*len(word)+2 > superstringLimit {
+		t.superstrings <- t.superstring
+		t.superstring = make([]byte, 0, 1024*1024)
+	}
+	for _, a := range word {
+		t.superstring = append(t.superstring, 1, a)
+	}
+	t.superstring = append(t.superstring, 0, 0)
+	return nil
+}
+
+func (t *DictionaryTrainer) Train() (*Dictionary, error) {
+	if len(t.superstring) > 0 {
+		t.superstrings <- t.superstring
+		t.superstring = nil
+	}
+	close(t.superstrings)
+	t.wg.Wait()
+
+	db, err := DictionaryBuilderFromCollectors(t.ctx, t.logPrefix, t.tmpDir, t.suffixCollectors, t.lvl, t.logger)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	d := newDictionary(db)
+	if t.lvl < log.LvlTrace {
+		t.logger.Log(t.lvl, fmt.Sprintf("[%s] Dictionary trained", t.logPrefix), "patterns", d.Len(), "id", fmt.Sprintf("%x", d.id), "workers", t.workers)
+	}
+	return d, nil
+}
+
+func (t *DictionaryTrainer) Close() {
+	for _, collector := range t.suffixCollectors {
+		collector.Close()
+	}
+	t.suffixCollectors = nil
+}
@@ -0,0 +1,164 @@
+/*
+   Copyright 2023 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compress
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+)
+
+// loremWords - amount of words compressed by the tests, built from lorem (which alone is too short to have patterns)
+const loremWords = 2000
+
+func loremWord(k int) string {
+	return fmt.Sprintf("%s longlongword %s %d", loremStrings[k%len(loremStrings)], loremStrings[(k/2)%len(loremStrings)], k)
+}
+
+func trainLoremDict(tb testing.TB, tmpDir string, sampling int) *Dictionary {
+	tb.Helper()
+	trainer := NewDictionaryTrainer(context.Background(), tb.Name(), tmpDir, 1, 2, log.LvlDebug, log.New())
+	defer trainer.Close()
+	for k := 0; k < loremWords; k += sampling {
+		require.NoError(tb, trainer.AddWord([]byte(loremWord(k))))
+	}
+	dict, err := trainer.Train()
+	require.NoError(tb, err)
+	return dict
+}
+
+// compressLorem - compresses every `step`-th loremWord starting from `from`, with own or shared dictionary.
+// Returns size of the added words
+func compressLorem(tb testing.TB, file string, dict *Dictionary, from, step int) (size int64) {
+	tb.Helper()
+	c, err := NewCompressor(context.Background(), tb.Name(), file, filepath.Dir(file), 1, 2, log.LvlDebug, log.New())
+	require.NoError(tb, err)
+	defer c.Close()
+	c.DisableFsync()
+	if dict != nil {
+		c.SetDictionary(dict)
+	}
+	for k := from; k < loremWords; k += step {
+		if k%5 == 0 {
+			require.NoError(tb, c.AddUncompressedWord([]byte(loremWord(k))))
+			size += int64(len(loremWord(k)))
+			continue
+		}
+		w := loremWord(k)
+		require.NoError(tb, c.AddWord([]byte(w)))
+		size += int64(len(w))
+	}
+	require.NoError(tb, c.Compress())
+	return size
+}
+
+func TestDictionary(t *testing.T) {
+	tmpDir := t.TempDir()
+	dict := trainLoremDict(t, tmpDir, 2)
+	require.NotZero(t, dict.Len())
+
+	dictFile := filepath.Join(tmpDir, "lorem.dict")
+	require.NoError(t, dict.Save(dictFile))
+	loaded, err := LoadDictionary(dictFile)
+	require.NoError(t, err)
+	require.Equal(t, dict, loaded)
+
+	// the same dictionary is reused by files with different words
+	for from := 0; from < 3; from++ {
+		file := filepath.Join(tmpDir, fmt.Sprintf("compressed%d", from))
+		compressLorem(t, file, dict, from, 3)
+
+		_, err = NewDecompressor(file)
+		require.ErrorContains(t, err, fmt.Sprintf("%x", dict.ID()))
+		_, err = NewDecompressorWithDictionary(file, trainLoremDict(t, tmpDir, 3))
+		require.ErrorIs(t, err, ErrDictionaryMismatch)
+
+		d, err := NewDecompressorWithDictionary(file, loaded)
+		require.NoError(t, err)
+		g := d.MakeGetter()
+		for k := from; k < loremWords; k += 3 {
+			require.True(t, g.HasNext())
+			if k%5 == 0 {
+				word, _ := g.NextUncompressed()
+				require.Equal(t, loremWord(k), string(word))
+				continue
+			}
+			word, _ := g.Next(nil)
+			require.Equal(t, loremWord(k), string(word))
+		}
+		require.False(t, g.HasNext())
+		d.Close()
+	}
+
+	// files with own dictionary are not affected
+	file := filepath.Join(tmpDir, "own")
+	compressLorem(t, file, nil, 0, 1)
+	d, err := NewDecompressorWithDictionary(file, dict)
+	require.NoError(t, err)
+	defer d.Close()
+	require.Equal(t, loremWords, d.Count())
+}
+
+// BenchmarkDictionaryRatio - compares compression ratio of lorem words split into several files, when each file has
+// own dictionary and when all of them share one dictionary trained from a sample. `ratio_dict` accounts for
+// the size of the shared dictionary file too.
+// The shared dictionary is a trade-off, not a win on these few words: with 8 files of 250 words their own
+// dictionaries compress better (1.57 against 1.37), as the shared patterns are trained for all the files and fit
+// each one worse. It pays off for small files, too small to have patterns of their own - with 40 files of 50 words
+// it's 1.24 against 1.04 - and, as the dictionary file holds every trained pattern (`ratio_dict` is below 1 here),
+// only when it's shared by many more or larger files than this benchmark has, like the segments of one type
+func BenchmarkDictionaryRatio(b *testing.B) {
+	run := func(b *testing.B, files int, shared bool) {
+		b.Helper()
+		for i := 0; i < b.N; i++ {
+			tmpDir := b.TempDir()
+			var dict *Dictionary
+			var dictSize int64
+			if shared {
+				dict = trainLoremDict(b, tmpDir, 4)
+				dictFile := filepath.Join(tmpDir, "lorem.dict")
+				require.NoError(b, dict.Save(dictFile))
+				dictSize = fileSize(b, dictFile)
+			}
+			var uncompressed, compressed int64
+			for from := 0; from < files; from++ {
+				file := filepath.Join(tmpDir, fmt.Sprintf("compressed%d", from))
+				uncompressed += compressLorem(b, file, dict, from, files)
+				compressed += fileSize(b, file)
+			}
+			b.ReportMetric(float64(uncompressed)/float64(compressed), "ratio")
+			b.ReportMetric(float64(uncompressed)/float64(compressed+dictSize), "ratio_dict")
+		}
+	}
+	for _, files := range []int{8, 40} {
+		files := files
+		b.Run(fmt.Sprintf("files=%d/own", files), func(b *testing.B) { run(b, files, false) })
+		b.Run(fmt.Sprintf("files=%d/shared", files), func(b *testing.B) { run(b, files, true) })
+	}
+}
+
+func fileSize(tb testing.TB, file string) int64 {
+	tb.Helper()
+	st, err := os.Stat(file)
+	require.NoError(tb, err)
+	return st.Size()
+}
@@ -239,7 +239,7 @@ func (cq *CompressionQueue) Pop() interface{} {
 }
 
 // reduceDict reduces the dictionary by trying the substitutions and counting frequency for each word
-func reducedict(ctx context.Context, trace bool, logPrefix, segmentFilePath string, cf *os.File, datFile *DecompressedFile, workers int, dictBuilder *DictionaryBuilder, lvl log.Lvl, logger log.Logger) error {
+func reducedict(ctx context.Context, trace bool, logPrefix, segmentFilePath string, cf *os.File, datFile *DecompressedFile, workers int, dictBuilder *DictionaryBuilder, sharedDict *Dictionary, lvl log.Lvl, logger log.Logger) error {
 	logEvery := time.NewTicker(60 * time.Second)
 	defer logEvery.Stop()
 
@@ -253,6 +253,7 @@ func reducedict(ctx context.Context, trace bool, logPrefix, segmentFilePath stri
 			code:     uint64(len(code2pattern)),
 			codeBits: 0,
 			word:     word,
+			dictIdx:  uint64(len(code2pattern)),
 		}
 		pt.Insert(word, p)
 		code2pattern = append(code2pattern, p)
@@ -519,8 +520,16 @@ func reducedict(ctx context.Context, trace bool, logPrefix, segmentFilePath stri
 	}
 	// Calculate total size of the dictionary
 	var patternsSize uint64
+	if sharedDict != nil {
+		patternsSize = 8 // ID of the dictionary
+	}
 	for _, p := range patternList {
-		ns := binary.PutUvarint(numBuf[:], uint64(p.depth))    // Length of the word's depth
+		ns := binary.PutUvarint(numBuf[:], uint64(p.depth)) // Length of the word's depth
+		if sharedDict != nil {
+			n := binary.PutUvarint(numBuf[:], p.dictIdx) // Length of the word's index in the dictionary
+			patternsSize += uint64(ns + n)
+			continue
+		}
 		n := binary.PutUvarint(numBuf[:], uint64(len(p.word))) // Length of the word's length
 		patternsSize += uint64(ns + n + len(p.word))
 	}
@@ -546,9 +555,20 @@ func reducedict(ctx context.Context, trace bool, logPrefix, segmentFilePath stri
 		return err
 	}
 	// 2-nd, output dictionary size
-	binary.BigEndian.PutUint64(numBuf[:], patternsSize) // Dictionary size
-	if _, err = cw.Write(numBuf[:8]); err != nil {
-		return err
+	if sharedDict != nil {
+		binary.BigEndian.PutUint64(numBuf[:], patternsSize|sharedDictionaryFlag) // Dictionary size
+		if _, err = cw.Write(numBuf[:8]); err != nil {
+			return err
+		}
+		binary.BigEndian.PutUint64(numBuf[:], sharedDict.id)
+		if _, err = cw.Write(numBuf[:8]); err != nil {
+			return err
+		}
+	} else {
+		binary.BigEndian.PutUint64(numBuf[:], patternsSize) // Dictionary size
+		if _, err = cw.Write(numBuf[:8]); err != nil {
+			return err
+		}
 	}
 	//fmt.Printf("patternsSize = %d\n", patternsSize)
 	// Write all the pattens
@@ -558,6 +578,13 @@ func reducedict(ctx context.Context, trace bool, logPrefix, segmentFilePath stri
 		if _, err = cw.Write(numBuf[:ns]); err != nil {
 			return err
 		}
+		if sharedDict != nil {
+			n := binary.PutUvarint(numBuf[:], p.dictIdx)
+			if _, err = cw.Write(numBuf[:n]); err != nil {
+				return err
+			}
+			continue
+		}
 		n := binary.PutUvarint(numBuf[:], uint64(len(p.word)))
 		if _, err = cw.Write(numBuf[:n]); err != nil {
 			return err
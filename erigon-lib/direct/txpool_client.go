@@ -112,6 +112,62 @@ func (c *TxPoolOnAddC) Context() context.Context { return c.ctx }
 
 // -- end OnAdd
 
+func (s *TxPoolClient) Discards(ctx context.Context, in *txpool_proto.DiscardsRequest, opts ...grpc.CallOption) (*txpool_proto.DiscardsReply, error) {
+	return s.server.Discards(ctx, in)
+}
+
+// -- start OnDiscard
+
+func (s *TxPoolClient) OnDiscard(ctx context.Context, in *txpool_proto.OnDiscardRequest, opts ...grpc.CallOption) (txpool_proto.Txpool_OnDiscardClient, error) {
+	ch := make(chan *onDiscardReply, 16384)
+	streamServer := &TxPoolOnDiscardS{ch: ch, ctx: ctx}
+	go func() {
+		defer close(ch)
+		streamServer.Err(s.server.OnDiscard(in, streamServer))
+	}()
+	return &TxPoolOnDiscardC{ch: ch, ctx: ctx}, nil
+}
+
+type onDiscardReply struct {
+	r   *txpool_proto.DiscardsReply
+	err error
+}
+
+type TxPoolOnDiscardS struct {
+	ch  chan *onDiscardReply
+	ctx context.Context
+	grpc.ServerStream
+}
+
+func (s *TxPoolOnDiscardS) Send(m *txpool_proto.DiscardsReply) error {
+	s.ch <- &onDiscardReply{r: m}
+	return nil
+}
+func (s *TxPoolOnDiscardS) Context() context.Context { return s.ctx }
+func (s *TxPoolOnDiscardS) Err(err error) {
+	if err == nil {
+		return
+	}
+	s.ch <- &onDiscardReply{err: err}
+}
+
+type TxPoolOnDiscardC struct {
+	ch  chan *onDiscardReply
+	ctx context.Context
+	grpc.ClientStream
+}
+
+func (c *TxPoolOnDiscardC) Recv() (*txpool_proto.DiscardsReply, error) {
+	m, ok := <-c.ch
+	if !ok || m == nil {
+		return nil, io.EOF
+	}
+	return m.r, m.err
+}
+func (c *TxPoolOnDiscardC) Context() context.Context { return c.ctx }
+
+// -- end OnDiscard
+
 func (s *TxPoolClient) Status(ctx context.Context, in *txpool_proto.StatusRequest, opts ...grpc.CallOption) (*txpool_proto.StatusReply, error) {
 	return s.server.Status(ctx, in)
 }
@@ -119,3 +175,27 @@ func (s *TxPoolClient) Status(ctx context.Context, in *txpool_proto.StatusReques
 func (s *TxPoolClient) Nonce(ctx context.Context, in *txpool_proto.NonceRequest, opts ...grpc.CallOption) (*txpool_proto.NonceReply, error) {
 	return s.server.Nonce(ctx, in)
 }
+
+func (s *TxPoolClient) ContentFrom(ctx context.Context, in *txpool_proto.ContentFromRequest, opts ...grpc.CallOption) (*txpool_proto.ContentReply, error) {
+	return s.server.ContentFrom(ctx, in)
+}
+
+func (s *TxPoolClient) Content(ctx context.Context, in *txpool_proto.ContentRequest, opts ...grpc.CallOption) (*txpool_proto.ContentReply, error) {
+	return s.server.Content(ctx, in)
+}
+
+func (s *TxPoolClient) Export(ctx context.Context, in *txpool_proto.ExportRequest, opts ...grpc.CallOption) (*txpool_proto.ExportReply, error) {
+	return s.server.Export(ctx, in)
+}
+
+func (s *TxPoolClient) Inspect(ctx context.Context, in *txpool_proto.InspectRequest, opts ...grpc.CallOption) (*txpool_proto.InspectReply, error) {
+	return s.server.Inspect(ctx, in)
+}
+
+func (s *TxPoolClient) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequest, opts ...grpc.CallOption) (*txpool_proto.GetBlobsReply, error) {
+	return s.server.GetBlobs(ctx, in)
+}
+
+func (s *TxPoolClient) AddBundle(ctx context.Context, in *txpool_proto.AddBundleRequest, opts ...grpc.CallOption) (*txpool_proto.AddBundleReply, error) {
+	return s.server.AddBundle(ctx, in)
+}
@@ -555,6 +555,21 @@ func (d *Downloader) AddNewSeedableFile(ctx context.Context, name string) error
 	return nil
 }
 
+// Drop - stops downloading/seeding the file
+func (d *Downloader) Drop(name string) {
+	for _, t := range d.torrentClient.Torrents() {
+		select {
+		case <-t.GotInfo():
+			continue
+		default:
+		}
+		if t.Name() == name {
+			t.Drop()
+			break
+		}
+	}
+}
+
 func (d *Downloader) exists(name string) bool {
 	// Paranoic Mode on: if same file changed infoHash - skip it
 	// use-cases:
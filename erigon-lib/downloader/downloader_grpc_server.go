@@ -35,13 +35,31 @@ var (
 	_ proto_downloader.DownloaderServer = &GrpcServer{}
 )
 
-func NewGrpcServer(d *Downloader) (*GrpcServer, error) {
+// Backend - what serves the files of the Downloader API: BitTorrent (Downloader) or a plain mirror (Mirror)
+type Backend interface {
+	AddNewSeedableFile(ctx context.Context, name string) error
+	AddInfoHashAsMagnetLink(ctx context.Context, infoHash metainfo.Hash, name string) error
+	Drop(name string)
+	VerifyData(ctx context.Context, onlyFiles []string) error
+	ReCalcStats(interval time.Duration)
+	Stats() AggStats
+	SnapDir() string
+	MainLoopInBackground(silent bool)
+	Close()
+}
+
+var (
+	_ Backend = &Downloader{}
+	_ Backend = &Mirror{}
+)
+
+func NewGrpcServer(d Backend) (*GrpcServer, error) {
 	return &GrpcServer{d: d}, nil
 }
 
 type GrpcServer struct {
 	proto_downloader.UnimplementedDownloaderServer
-	d *Downloader
+	d Backend
 }
 
 // Download - create new .torrent ONLY if initialSync, everything else Erigon can generate by itself
@@ -79,22 +97,11 @@ func (s *GrpcServer) Download(ctx context.Context, request *proto_downloader.Dow
 // Delete - stop seeding, remove file, remove .torrent
 func (s *GrpcServer) Delete(ctx context.Context, request *proto_downloader.DeleteRequest) (*emptypb.Empty, error) {
 	defer s.d.ReCalcStats(10 * time.Second) // immediately call ReCalc to set stat.Complete flag
-	torrents := s.d.torrentClient.Torrents()
 	for _, name := range request.Paths {
 		if name == "" {
 			return nil, fmt.Errorf("field 'path' is required")
 		}
-		for _, t := range torrents {
-			select {
-			case <-t.GotInfo():
-				continue
-			default:
-			}
-			if t.Name() == name {
-				t.Drop()
-				break
-			}
-		}
+		s.d.Drop(name)
 
 		fPath := filepath.Join(s.d.SnapDir(), name)
 		_ = os.Remove(fPath)
@@ -2,12 +2,15 @@ package downloader
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"testing"
 
 	lg "github.com/anacrolix/log"
+	"github.com/anacrolix/torrent/metainfo"
 	"github.com/ledgerwatch/log/v3"
 	"github.com/stretchr/testify/require"
+	"github.com/tenderly/erigon/erigon-lib/chain/snapcfg"
 	"github.com/tenderly/erigon/erigon-lib/common/datadir"
 	downloadercfg2 "github.com/tenderly/erigon/erigon-lib/downloader/downloadercfg"
 	"github.com/tenderly/erigon/erigon-lib/downloader/snaptype"
@@ -64,3 +67,25 @@ func TestNoEscape(t *testing.T) {
 	_, err = BuildTorrentIfNeed(ctx, "./../a.seg", dirs.Snap)
 	require.Error(err)
 }
+
+func TestManifests(t *testing.T) {
+	require := require.New(t)
+	dirs := datadir.New(t.TempDir())
+	ctx := context.Background()
+	name := "v1-000000-000500-headers.seg"
+	require.NoError(os.WriteFile(filepath.Join(dirs.Snap, name), []byte("headers"), 0644))
+
+	preverified, webseeds, err := Manifests(ctx, dirs, "https://example.com/snapshots/")
+	require.NoError(err)
+	mi, err := metainfo.LoadFromFile(filepath.Join(dirs.Snap, name+".torrent"))
+	require.NoError(err)
+	require.Equal(snapcfg.Preverified{{Name: name, Hash: mi.HashInfoBytes().HexString()}}, preverified)
+	require.Equal(snaptype.WebSeedsFromProvider{
+		name:              "https://example.com/snapshots/" + name,
+		name + ".torrent": "https://example.com/snapshots/" + name + ".torrent",
+	}, webseeds)
+
+	_, webseeds, err = Manifests(ctx, dirs, "")
+	require.NoError(err)
+	require.Empty(webseeds)
+}
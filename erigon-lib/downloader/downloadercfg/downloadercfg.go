@@ -56,6 +56,9 @@ type Cfg struct {
 	DownloadTorrentFilesFromWebseed bool
 	ChainName                       string
 
+	// MirrorUrl - directory or http(s) url serving the snapshot files, downloaded from it instead of BitTorrent when set
+	MirrorUrl string
+
 	Dirs datadir.Dirs
 }
 
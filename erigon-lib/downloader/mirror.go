@@ -0,0 +1,493 @@
+/*
+   Copyright 2024 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/cmp"
+	dir2 "github.com/tenderly/erigon/erigon-lib/common/dir"
+	"github.com/tenderly/erigon/erigon-lib/downloader/downloadercfg"
+	"github.com/tenderly/erigon/erigon-lib/downloader/snaptype"
+)
+
+// Mirror - downloads the files from a plain directory or an HTTP file server, without BitTorrent.
+// For air-gapped and CI environments: the files are verified against the expected info hashes, as BitTorrent would,
+// and the progress is reported the same way.
+type Mirror struct {
+	cfg    *downloadercfg.Cfg
+	source mirrorSource
+
+	lock  sync.Mutex
+	files map[string]*mirrorFile
+
+	statsLock sync.RWMutex
+	stats     AggStats
+
+	slots        *semaphore.Weighted
+	ctx          context.Context
+	stopMainLoop context.CancelFunc
+	wg           sync.WaitGroup
+
+	logger    log.Logger
+	verbosity log.Lvl
+}
+
+type mirrorFile struct {
+	name     string
+	infoHash *metainfo.Hash // nil for the files produced locally, which are only seeded
+	cancel   context.CancelFunc
+
+	size      atomic.Int64 // -1 until known
+	completed atomic.Int64
+	done      atomic.Bool
+	err       atomic.Pointer[error] // the last download failure
+}
+
+// ErrInfoHashMismatch - the mirror serves another content than the expected one, downloading it again won't help
+var ErrInfoHashMismatch = errors.New("info hash mismatch")
+
+func NewMirror(ctx context.Context, cfg *downloadercfg.Cfg, logger log.Logger, verbosity log.Lvl) (*Mirror, error) {
+	source, err := newMirrorSource(cfg.MirrorUrl)
+	if err != nil {
+		return nil, err
+	}
+	m := &Mirror{
+		cfg:       cfg,
+		source:    source,
+		files:     map[string]*mirrorFile{},
+		slots:     semaphore.NewWeighted(int64(cmp.Max(cfg.DownloadSlots, 1))),
+		logger:    logger,
+		verbosity: verbosity,
+	}
+	m.ctx, m.stopMainLoop = context.WithCancel(ctx)
+	if err := BuildTorrentFilesIfNeed(m.ctx, cfg.Dirs); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Mirror) SnapDir() string { return m.cfg.Dirs.Snap }
+
+// MainLoopInBackground - logs the progress, the downloads are started as the files are added
+func (m *Mirror) MainLoopInBackground(silent bool) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		statInterval := 20 * time.Second
+		statEvery := time.NewTicker(statInterval)
+		defer statEvery.Stop()
+		for {
+			select {
+			case <-m.ctx.Done():
+				return
+			case <-statEvery.C:
+				m.ReCalcStats(statInterval)
+				if silent {
+					continue
+				}
+				stats := m.Stats()
+				if stats.Completed {
+					m.logger.Info("[snapshots] Seeding", "files", stats.FilesTotal)
+					continue
+				}
+				m.logger.Info("[snapshots] Downloading from mirror", "progress", fmt.Sprintf("%.2f%% %s/%s", stats.Progress, common.ByteCount(stats.BytesCompleted), common.ByteCount(stats.BytesTotal)),
+					"download", common.ByteCount(stats.DownloadRate)+"/s", "files", stats.FilesTotal)
+			}
+		}
+	}()
+}
+
+// AddInfoHashAsMagnetLink - downloads the file from the mirror unless it is already there, with the same info hash
+func (m *Mirror) AddInfoHashAsMagnetLink(ctx context.Context, infoHash metainfo.Hash, name string) error {
+	if _, err := ensureCantLeaveDir(name, m.SnapDir()); err != nil {
+		return err
+	}
+	for _, p := range m.cfg.ExpectedTorrentFilesHashes {
+		if p.Name == name && snaptype.Hex2InfoHash(p.Hash) != infoHash {
+			return fmt.Errorf("%w: %s requested with %x, preverified %s", ErrInfoHashMismatch, name, infoHash, p.Hash)
+		}
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.files[name]; ok {
+		return nil
+	}
+	f := &mirrorFile{name: name, infoHash: &infoHash}
+	f.size.Store(-1)
+	var fileCtx context.Context
+	fileCtx, f.cancel = context.WithCancel(m.ctx)
+	m.files[name] = f
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.download(fileCtx, f)
+	}()
+	return nil
+}
+
+// AddNewSeedableFile - the file was produced locally, there is nothing to download
+func (m *Mirror) AddNewSeedableFile(ctx context.Context, name string) error {
+	if _, err := BuildTorrentIfNeed(ctx, name, m.SnapDir()); err != nil {
+		return fmt.Errorf("AddNewSeedableFile: %w", err)
+	}
+	fi, err := os.Stat(filepath.Join(m.SnapDir(), name))
+	if err != nil {
+		return fmt.Errorf("AddNewSeedableFile: %w", err)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.files[name]; ok {
+		return nil
+	}
+	f := &mirrorFile{name: name, cancel: func() {}}
+	f.size.Store(fi.Size())
+	f.completed.Store(fi.Size())
+	f.done.Store(true)
+	m.files[name] = f
+	return nil
+}
+
+// Drop - stops the download of the file, if any
+func (m *Mirror) Drop(name string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if f, ok := m.files[name]; ok {
+		f.cancel()
+		delete(m.files, name)
+	}
+}
+
+func (m *Mirror) download(ctx context.Context, f *mirrorFile) {
+	fPath := filepath.Join(m.SnapDir(), f.name)
+	if size, ok := m.alreadyDownloaded(fPath, *f.infoHash); ok {
+		f.size.Store(size)
+		f.completed.Store(size)
+		f.done.Store(true)
+		return
+	}
+	if err := m.slots.Acquire(ctx, 1); err != nil {
+		return
+	}
+	defer m.slots.Release(1)
+
+	for attempt := 1; ; attempt++ {
+		err := m.downloadOnce(ctx, f, fPath)
+		if err == nil {
+			f.size.Store(f.completed.Load())
+			f.done.Store(true)
+			m.logger.Log(m.verbosity, "[snapshots] downloaded from mirror", "file", f.name)
+			return
+		}
+		f.err.Store(&err)
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, ErrInfoHashMismatch) {
+			m.logger.Error("[snapshots] mirror file rejected", "file", f.name, "err", err)
+			return
+		}
+		m.logger.Warn("[snapshots] mirror download failed, retrying", "file", f.name, "attempt", attempt, "err", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// alreadyDownloaded - the file has the .torrent file of the expected info hash, and its size.
+// Like the piece completion of BitTorrent, the data is trusted without hashing it again.
+func (m *Mirror) alreadyDownloaded(fPath string, infoHash metainfo.Hash) (int64, bool) {
+	if !dir2.FileExist(fPath) || !dir2.FileExist(fPath+".torrent") {
+		return 0, false
+	}
+	mi, err := metainfo.LoadFromFile(fPath + ".torrent")
+	if err != nil || mi.HashInfoBytes() != infoHash {
+		return 0, false
+	}
+	info, err := mi.UnmarshalInfo()
+	if err != nil {
+		return 0, false
+	}
+	fi, err := os.Stat(fPath)
+	if err != nil || fi.Size() != info.TotalLength() {
+		return 0, false
+	}
+	return fi.Size(), true
+}
+
+func (m *Mirror) downloadOnce(ctx context.Context, f *mirrorFile, fPath string) error {
+	f.completed.Store(0)
+	body, size, err := m.source.open(ctx, f.name)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	f.size.Store(size)
+
+	if err := os.MkdirAll(filepath.Dir(fPath), 0755); err != nil {
+		return err
+	}
+	tmpPath := fPath + ".tmp"
+	defer os.Remove(tmpPath)
+	out, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, &countingReader{r: body, n: &f.completed}); err != nil {
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	info, infoHash, err := infoOfFile(tmpPath, f.name)
+	if err != nil {
+		return err
+	}
+	if infoHash != *f.infoHash {
+		return fmt.Errorf("%w: %s has %x, expected %x", ErrInfoHashMismatch, f.name, infoHash, *f.infoHash)
+	}
+	if err := os.Rename(tmpPath, fPath); err != nil {
+		return err
+	}
+	_ = os.Remove(fPath + ".torrent")
+	return CreateTorrentFileFromInfo(m.SnapDir(), info, nil)
+}
+
+// infoOfFile - the torrent info of the file, as BuildTorrentIfNeed creates it, and its hash
+func infoOfFile(fPath, name string) (*metainfo.Info, metainfo.Hash, error) {
+	info := &metainfo.Info{PieceLength: downloadercfg.DefaultPieceSize, Name: name}
+	if err := info.BuildFromFilePath(fPath); err != nil {
+		return nil, metainfo.Hash{}, fmt.Errorf("hashing %s: %w", name, err)
+	}
+	info.Name = name
+	infoBytes, err := bencode.Marshal(info)
+	if err != nil {
+		return nil, metainfo.Hash{}, err
+	}
+	return info, metainfo.HashBytes(infoBytes), nil
+}
+
+// VerifyData - hashes again the downloaded files and the preverified ones on disk, a file with another info hash
+// than expected is removed
+func (m *Mirror) VerifyData(ctx context.Context, onlyFiles []string) error {
+	expected := map[string]metainfo.Hash{}
+	for _, p := range m.cfg.ExpectedTorrentFilesHashes {
+		if dir2.FileExist(filepath.Join(m.SnapDir(), p.Name)) {
+			expected[p.Name] = snaptype.Hex2InfoHash(p.Hash)
+		}
+	}
+	m.lock.Lock()
+	for _, f := range m.files {
+		if f.infoHash != nil && f.done.Load() {
+			expected[f.name] = *f.infoHash
+		}
+	}
+	m.lock.Unlock()
+
+	m.logger.Info("[snapshots] Verify start")
+	defer m.logger.Info("[snapshots] Verify done")
+	var bad []string
+	for name, expectedHash := range expected {
+		if len(onlyFiles) > 0 && !containsName(onlyFiles, name) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		fPath := filepath.Join(m.SnapDir(), name)
+		_, infoHash, err := infoOfFile(fPath, name)
+		if err != nil {
+			return err
+		}
+		if infoHash != expectedHash {
+			bad = append(bad, name)
+			_ = os.Remove(fPath)
+			_ = os.Remove(fPath + ".torrent")
+		}
+	}
+	if len(bad) > 0 {
+		return fmt.Errorf("%w: %s", ErrInfoHashMismatch, strings.Join(bad, ", "))
+	}
+	return nil
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Mirror) ReCalcStats(interval time.Duration) {
+	m.lock.Lock()
+	files := make([]*mirrorFile, 0, len(m.files))
+	for _, f := range m.files {
+		files = append(files, f)
+	}
+	m.lock.Unlock()
+
+	m.statsLock.Lock()
+	defer m.statsLock.Unlock()
+	prevStats, stats := m.stats, AggStats{Completed: true}
+	var failed []string
+	for _, f := range files {
+		completed := uint64(f.completed.Load())
+		stats.BytesCompleted += completed
+		stats.BytesDownload += completed
+		if size := f.size.Load(); size >= 0 {
+			stats.MetadataReady++
+			stats.BytesTotal += uint64(size)
+		}
+		if !f.done.Load() {
+			stats.Completed = false
+			if err := f.err.Load(); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %s", f.name, *err))
+			}
+		}
+	}
+	if len(failed) > 0 {
+		amount := len(failed)
+		if len(failed) > 5 {
+			failed = append(failed[:5], "...")
+		}
+		m.logger.Log(m.verbosity, "[snapshots] mirror downloads failing", "files", amount, "list", strings.Join(failed, ","))
+	}
+	if stats.BytesDownload > prevStats.BytesDownload {
+		stats.DownloadRate = (stats.BytesDownload - prevStats.BytesDownload) / uint64(interval.Seconds())
+	}
+	if stats.BytesTotal > 0 {
+		stats.Progress = float32(float64(100) * (float64(stats.BytesCompleted) / float64(stats.BytesTotal)))
+		if int(stats.Progress) == 100 && !stats.Completed {
+			stats.Progress = 99.99
+		}
+	}
+	stats.FilesTotal = int32(len(files))
+	m.stats = stats
+}
+
+func (m *Mirror) Stats() AggStats {
+	m.statsLock.RLock()
+	defer m.statsLock.RUnlock()
+	return m.stats
+}
+
+func (m *Mirror) Close() {
+	m.stopMainLoop()
+	m.wg.Wait()
+}
+
+type countingReader struct {
+	r io.Reader
+	n *atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// mirrorSource - where the Mirror reads the files from
+type mirrorSource interface {
+	// open - the content of the file at the path relative to the snapshots dir, and its size
+	open(ctx context.Context, name string) (io.ReadCloser, int64, error)
+}
+
+// newMirrorSource - http(s):// urls are read from a file server, file:// urls and paths from a directory
+func newMirrorSource(mirrorUrl string) (mirrorSource, error) {
+	if strings.HasPrefix(mirrorUrl, "http://") || strings.HasPrefix(mirrorUrl, "https://") {
+		u, err := url.Parse(mirrorUrl)
+		if err != nil {
+			return nil, fmt.Errorf("mirror url: %w", err)
+		}
+		return &httpMirror{base: u, client: &http.Client{}}, nil
+	}
+	root := strings.TrimPrefix(mirrorUrl, "file://")
+	if !dir2.Exist(root) {
+		return nil, fmt.Errorf("mirror dir %s does not exist", root)
+	}
+	return dirMirror(root), nil
+}
+
+type dirMirror string
+
+func (d dirMirror) open(_ context.Context, name string) (io.ReadCloser, int64, error) {
+	fPath, err := ensureCantLeaveDir(name, string(d))
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := os.Open(filepath.Join(string(d), fPath))
+	if err != nil {
+		return nil, 0, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, fi.Size(), nil
+}
+
+type httpMirror struct {
+	base   *url.URL
+	client *http.Client
+}
+
+func (h *httpMirror) open(ctx context.Context, name string) (io.ReadCloser, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.base.JoinPath(filepath.ToSlash(name)).String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("GET %s: %s", req.URL, resp.Status)
+	}
+	return resp.Body, resp.ContentLength, nil
+}
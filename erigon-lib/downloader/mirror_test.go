@@ -0,0 +1,80 @@
+package downloader
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	lg "github.com/anacrolix/log"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+	"github.com/tenderly/erigon/erigon-lib/common/datadir"
+	"github.com/tenderly/erigon/erigon-lib/common/dir"
+	downloadercfg2 "github.com/tenderly/erigon/erigon-lib/downloader/downloadercfg"
+)
+
+func TestMirror(t *testing.T) {
+	mirrorDir := t.TempDir()
+	data := make([]byte, 3*downloadercfg2.DefaultPieceSize/2)
+	_, _ = rand.Read(data)
+	require.NoError(t, os.WriteFile(filepath.Join(mirrorDir, "v1-000000-000500-headers.seg"), data, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(mirrorDir, "v1-000500-001000-headers.seg"), data[1:], 0644))
+	_, infoHash, err := infoOfFile(filepath.Join(mirrorDir, "v1-000000-000500-headers.seg"), "v1-000000-000500-headers.seg")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(mirrorDir)))
+	defer server.Close()
+
+	for name, mirrorUrl := range map[string]string{"dir": mirrorDir, "http": server.URL} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			dirs := datadir.New(t.TempDir())
+			cfg, err := downloadercfg2.New(dirs, "", lg.Info, 0, 0, 0, 0, 0, nil, nil, "testnet")
+			require.NoError(err)
+			cfg.MirrorUrl = mirrorUrl
+			m, err := NewMirror(context.Background(), cfg, log.New(), log.LvlInfo)
+			require.NoError(err)
+			defer m.Close()
+
+			require.NoError(m.AddInfoHashAsMagnetLink(m.ctx, infoHash, "v1-000000-000500-headers.seg"))
+			require.Eventually(func() bool {
+				m.ReCalcStats(time.Second)
+				return m.Stats().Completed
+			}, 10*time.Second, 10*time.Millisecond)
+			stats := m.Stats()
+			require.Equal(int32(1), stats.FilesTotal)
+			require.Equal(uint64(len(data)), stats.BytesTotal)
+			require.Equal(float32(100), stats.Progress)
+			require.True(dir.FileExist(filepath.Join(dirs.Snap, "v1-000000-000500-headers.seg.torrent")))
+			mi, err := metainfo.LoadFromFile(filepath.Join(dirs.Snap, "v1-000000-000500-headers.seg.torrent"))
+			require.NoError(err)
+			require.Equal(infoHash, mi.HashInfoBytes())
+
+			// served with another content than expected: rejected, never completed
+			require.NoError(m.AddInfoHashAsMagnetLink(m.ctx, infoHash, "v1-000500-001000-headers.seg"))
+			require.Eventually(func() bool {
+				m.ReCalcStats(time.Second)
+				m.lock.Lock()
+				defer m.lock.Unlock()
+				return m.files["v1-000500-001000-headers.seg"].err.Load() != nil
+			}, 10*time.Second, 10*time.Millisecond)
+			require.False(m.Stats().Completed)
+			require.False(dir.FileExist(filepath.Join(dirs.Snap, "v1-000500-001000-headers.seg")))
+			m.Drop("v1-000500-001000-headers.seg")
+			m.ReCalcStats(time.Second)
+			require.True(m.Stats().Completed)
+
+			// the downloaded file is kept on restart, without downloading it again
+			size, ok := m.alreadyDownloaded(filepath.Join(dirs.Snap, "v1-000000-000500-headers.seg"), infoHash)
+			require.True(ok)
+			require.Equal(int64(len(data)), size)
+			require.NoError(m.VerifyData(m.ctx, nil))
+		})
+	}
+}
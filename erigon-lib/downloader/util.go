@@ -19,11 +19,13 @@ package downloader
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -31,12 +33,14 @@ import (
 	"github.com/anacrolix/torrent/bencode"
 	"github.com/anacrolix/torrent/metainfo"
 	"github.com/ledgerwatch/log/v3"
+	"github.com/tenderly/erigon/erigon-lib/chain/snapcfg"
 	common2 "github.com/tenderly/erigon/erigon-lib/common"
 	"github.com/tenderly/erigon/erigon-lib/common/datadir"
 	dir2 "github.com/tenderly/erigon/erigon-lib/common/dir"
 	"github.com/tenderly/erigon/erigon-lib/downloader/downloadercfg"
 	"github.com/tenderly/erigon/erigon-lib/downloader/snaptype"
 	"github.com/tenderly/erigon/erigon-lib/kv"
+	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -275,6 +279,41 @@ func AllTorrentPaths(dirs datadir.Dirs) ([]string, error) {
 	return files, nil
 }
 
+// Manifests - the preverified info hashes of the seedable files of the datadir, creating their .torrent files if needed,
+// and the webseed urls of the files and of their .torrent files under webseedUrl
+func Manifests(ctx context.Context, dirs datadir.Dirs, webseedUrl string) (snapcfg.Preverified, snaptype.WebSeedsFromProvider, error) {
+	if err := BuildTorrentFilesIfNeed(ctx, dirs); err != nil {
+		return nil, nil, err
+	}
+	files, err := AllTorrentPaths(dirs)
+	if err != nil {
+		return nil, nil, err
+	}
+	preverified := make(snapcfg.Preverified, 0, len(files))
+	webseeds := snaptype.WebSeedsFromProvider{}
+	for _, fPath := range files {
+		mi, err := metainfo.LoadFromFile(fPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("LoadFromFile: %w, file=%s", err, fPath)
+		}
+		info, err := mi.UnmarshalInfo()
+		if err != nil {
+			return nil, nil, fmt.Errorf("UnmarshalInfo: %w, file=%s", err, fPath)
+		}
+		preverified = append(preverified, snapcfg.PreverifiedItem{Name: info.Name, Hash: mi.HashInfoBytes().HexString()})
+		if webseedUrl == "" {
+			continue
+		}
+		for _, name := range []string{info.Name, info.Name + ".torrent"} {
+			if webseeds[name], err = url.JoinPath(webseedUrl, filepath.ToSlash(name)); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	slices.SortFunc(preverified, func(i, j snapcfg.PreverifiedItem) int { return strings.Compare(i.Name, j.Name) })
+	return preverified, webseeds, nil
+}
+
 func AllTorrentSpecs(dirs datadir.Dirs) (res []*torrent.TorrentSpec, err error) {
 	files, err := AllTorrentPaths(dirs)
 	if err != nil {
@@ -48,9 +48,15 @@ type Collector struct {
 	bufType       int
 	allFlushed    bool
 	autoClean     bool
+	compression   Compression
 	logger        log.Logger
 }
 
+// CollectorCfg - optional settings of Collector, zero value is the default of NewCollector
+type CollectorCfg struct {
+	Compression Compression // Format of the files, to which sorted buffers are spilled
+}
+
 // NewCollectorFromFiles creates collector from existing files (left over from previous unsuccessful loading)
 func NewCollectorFromFiles(logPrefix, tmpdir string, logger log.Logger) (*Collector, error) {
 	if _, err := os.Stat(tmpdir); os.IsNotExist(err) {
@@ -87,7 +93,11 @@ func NewCriticalCollector(logPrefix, tmpdir string, sortableBuffer Buffer, logge
 }
 
 func NewCollector(logPrefix, tmpdir string, sortableBuffer Buffer, logger log.Logger) *Collector {
-	return &Collector{autoClean: true, bufType: getTypeByBuffer(sortableBuffer), buf: sortableBuffer, logPrefix: logPrefix, tmpdir: tmpdir, logLvl: log.LvlInfo, logger: logger}
+	return NewCollectorWithCfg(logPrefix, tmpdir, sortableBuffer, CollectorCfg{}, logger)
+}
+
+func NewCollectorWithCfg(logPrefix, tmpdir string, sortableBuffer Buffer, cfg CollectorCfg, logger log.Logger) *Collector {
+	return &Collector{autoClean: true, bufType: getTypeByBuffer(sortableBuffer), buf: sortableBuffer, logPrefix: logPrefix, tmpdir: tmpdir, compression: cfg.Compression, logLvl: log.LvlInfo, logger: logger}
 }
 
 func (c *Collector) extractNextFunc(originalK, k []byte, v []byte) error {
@@ -121,7 +131,7 @@ func (c *Collector) flushBuffer(canStoreInRam bool) error {
 
 		doFsync := !c.autoClean /* is critical collector */
 		var err error
-		provider, err = FlushToDisk(c.logPrefix, fullBuf, c.tmpdir, doFsync, c.logLvl)
+		provider, err = FlushToDisk(c.logPrefix, fullBuf, c.tmpdir, doFsync, c.compression, c.logLvl)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,127 @@
+/*
+   Copyright 2023 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package etl
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+
+	"github.com/tenderly/erigon/erigon-lib/metrics"
+)
+
+var (
+	spillWrittenBytes = metrics.GetOrCreateCounter(`etl_spill_written_bytes`)
+	spillReadBytes    = metrics.GetOrCreateCounter(`etl_spill_read_bytes`)
+)
+
+// Compression - on-disk format of the files to which Collector spills its sorted buffers.
+// Compressed files are smaller in tmpdir, at the cost of CPU during flush and load
+type Compression uint8
+
+const (
+	CompressionNone   Compression = iota
+	CompressionSnappy             // snappy framing format - fast, and self-describing (files are recognized on read)
+)
+
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionSnappy:
+		return "snappy"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
+
+func ParseCompression(s string) (Compression, error) {
+	switch s {
+	case "", "none":
+		return CompressionNone, nil
+	case "snappy":
+		return CompressionSnappy, nil
+	default:
+		return CompressionNone, fmt.Errorf("unknown etl compression: %s, supported: none, snappy", s)
+	}
+}
+
+// snappyStreamMagic - first chunk of snappy framing format. Raw files can't start with it:
+// they start with zig-zag varint of key length, and 0xff 0x06 is never written there
+var snappyStreamMagic = []byte("\xff\x06\x00\x00sNaPpY")
+
+type countingWriter struct {
+	w io.Writer
+	n uint64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += uint64(n)
+	return n, err
+}
+
+type countingReader struct{ r io.Reader }
+
+func (r countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	spillReadBytes.AddInt(n)
+	return n, err
+}
+
+// spillWriter - buffered writer of spill file, which compresses data if needed
+type spillWriter struct {
+	*bufio.Writer
+	file   *countingWriter
+	snappy *snappy.Writer
+}
+
+func newSpillWriter(f io.Writer, compression Compression) *spillWriter {
+	w := &spillWriter{file: &countingWriter{w: f}}
+	switch compression {
+	case CompressionSnappy:
+		w.snappy = snappy.NewBufferedWriter(w.file)
+		w.Writer = bufio.NewWriterSize(w.snappy, BufIOSize)
+	default:
+		w.Writer = bufio.NewWriterSize(w.file, BufIOSize)
+	}
+	return w
+}
+
+// Close - flushes everything to the file (but doesn't close it)
+func (w *spillWriter) Close() error {
+	defer func() { spillWrittenBytes.AddUint64(w.file.n) }()
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if w.snappy != nil {
+		return w.snappy.Close()
+	}
+	return nil
+}
+
+// newSpillReader - recognizes format of spill file, and returns reader of the raw key/values
+func newSpillReader(f io.Reader) *bufio.Reader {
+	r := bufio.NewReaderSize(countingReader{f}, BufIOSize)
+	if magic, err := r.Peek(len(snappyStreamMagic)); err == nil && bytes.Equal(magic, snappyStreamMagic) {
+		return bufio.NewReaderSize(snappy.NewReader(r), BufIOSize)
+	}
+	return r
+}
@@ -17,7 +17,6 @@
 package etl
 
 import (
-	"bufio"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -26,6 +25,8 @@ import (
 
 	"github.com/ledgerwatch/log/v3"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
 )
 
 type dataProvider interface {
@@ -42,7 +43,8 @@ type fileDataProvider struct {
 }
 
 // FlushToDisk - `doFsync` is true only for 'critical' collectors (which should not loose).
-func FlushToDisk(logPrefix string, b Buffer, tmpdir string, doFsync bool, lvl log.Lvl) (dataProvider, error) {
+// `compression` is format of the file, it's recognized on read - see newSpillReader.
+func FlushToDisk(logPrefix string, b Buffer, tmpdir string, doFsync bool, compression Compression, lvl log.Lvl) (dataProvider, error) {
 	if b.Len() == 0 {
 		return nil, nil
 	}
@@ -69,14 +71,15 @@ func FlushToDisk(logPrefix string, b Buffer, tmpdir string, doFsync bool, lvl lo
 			defer bufferFile.Sync() //nolint:errcheck
 		}
 
-		w := bufio.NewWriterSize(bufferFile, BufIOSize)
-		defer w.Flush() //nolint:errcheck
-
+		w := newSpillWriter(bufferFile, compression)
 		_, fName := filepath.Split(bufferFile.Name())
 		if err = b.Write(w); err != nil {
 			return fmt.Errorf("error writing entries to disk: %w", err)
 		}
-		log.Log(lvl, fmt.Sprintf("[%s] Flushed buffer file", logPrefix), "name", fName)
+		if err = w.Close(); err != nil {
+			return fmt.Errorf("error writing entries to disk: %w", err)
+		}
+		log.Log(lvl, fmt.Sprintf("[%s] Flushed buffer file", logPrefix), "name", fName, "compression", compression, "size", common.ByteCount(w.file.n))
 		return nil
 	})
 
@@ -89,7 +92,7 @@ func (p *fileDataProvider) Next(keyBuf, valBuf []byte) ([]byte, []byte, error) {
 		if err != nil {
 			return nil, nil, err
 		}
-		r := bufio.NewReaderSize(p.file, BufIOSize)
+		r := newSpillReader(p.file)
 		p.reader = r
 		p.byteReader = r
 
@@ -71,6 +71,7 @@ type TransformArgs struct {
 	ExtractEndKey   []byte
 	BufferType      int
 	BufferSize      int
+	Compression     Compression // Format of the files spilled to tmpdir
 }
 
 func Transform(
@@ -89,7 +90,7 @@ func Transform(
 		bufferSize = datasize.ByteSize(args.BufferSize)
 	}
 	buffer := getBufferByType(args.BufferType, bufferSize, nil)
-	collector := NewCollector(logPrefix, tmpdir, buffer, logger)
+	collector := NewCollectorWithCfg(logPrefix, tmpdir, buffer, CollectorCfg{Compression: args.Compression}, logger)
 	defer collector.Close()
 
 	t := time.Now()
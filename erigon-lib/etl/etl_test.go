@@ -330,6 +330,73 @@ func TestTransformThroughFiles(t *testing.T) {
 	compareBuckets(t, tx, sourceBucket, destBucket, nil)
 }
 
+func TestTransformThroughCompressedFiles(t *testing.T) {
+	logger := log.New()
+	_, tx := memdb.NewTestTx(t)
+	sourceBucket := kv.ChaindataTables[0]
+	destBucket := kv.ChaindataTables[1]
+	generateTestData(t, tx, sourceBucket, 10)
+	err := Transform("logPrefix", tx, sourceBucket, destBucket, "", testExtractToMapFunc, testLoadFromMapFunc,
+		TransformArgs{BufferSize: 1, Compression: CompressionSnappy}, logger)
+	assert.Nil(t, err)
+	compareBuckets(t, tx, sourceBucket, destBucket, nil)
+}
+
+func TestCompressedSpillFiles(t *testing.T) {
+	logger := log.New()
+	require := require.New(t)
+	written, read := spillWrittenBytes.GetValueUint64(), spillReadBytes.GetValueUint64()
+
+	// compressed and raw files are read transparently by one collector
+	compressed := NewCollectorWithCfg(t.Name(), t.TempDir(), NewSortableBuffer(1), CollectorCfg{Compression: CompressionSnappy}, logger)
+	defer compressed.Close()
+	raw := NewCollector(t.Name(), t.TempDir(), NewSortableBuffer(1), logger)
+	defer raw.Close()
+	for i := 9; i >= 0; i-- {
+		c := raw
+		if i%2 == 0 {
+			c = compressed
+		}
+		require.NoError(c.Collect([]byte(fmt.Sprintf("key-%d", i)), bytes.Repeat([]byte{byte(i)}, i)))
+	}
+	require.NoError(compressed.Collect([]byte("nil"), nil))
+	require.NoError(compressed.Flush())
+	require.NoError(raw.Flush())
+	for i, p := range compressed.dataProviders {
+		require.NoError(p.Wait())
+		magic := make([]byte, len(snappyStreamMagic))
+		_, err := p.(*fileDataProvider).file.ReadAt(magic, 0)
+		require.NoError(err)
+		require.Equal(snappyStreamMagic, magic, i)
+	}
+	require.Greater(spillWrittenBytes.GetValueUint64(), written)
+
+	compressed.dataProviders = append(compressed.dataProviders, raw.dataProviders...)
+	raw.dataProviders = nil
+	var keys []string
+	require.NoError(compressed.Load(nil, "", func(k, v []byte, table CurrentTableReader, next LoadNextFunc) error {
+		if string(k) == "nil" {
+			require.Nil(v)
+		} else {
+			require.Equal(bytes.Repeat([]byte{k[4] - '0'}, int(k[4]-'0')), v)
+		}
+		keys = append(keys, string(k))
+		return nil
+	}, TransformArgs{}))
+	require.Equal([]string{"key-0", "key-1", "key-2", "key-3", "key-4", "key-5", "key-6", "key-7", "key-8", "key-9", "nil"}, keys)
+	require.Greater(spillReadBytes.GetValueUint64(), read)
+}
+
+func TestParseCompression(t *testing.T) {
+	for _, c := range []Compression{CompressionNone, CompressionSnappy} {
+		parsed, err := ParseCompression(c.String())
+		require.NoError(t, err)
+		require.Equal(t, c, parsed)
+	}
+	_, err := ParseCompression("zstd")
+	require.Error(t, err)
+}
+
 func TestTransformDoubleOnExtract(t *testing.T) {
 	logger := log.New()
 	// test invariant when extractFunc multiplies the data 2x
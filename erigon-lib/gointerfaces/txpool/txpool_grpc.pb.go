@@ -30,6 +30,14 @@ const (
 	Txpool_OnAdd_FullMethodName        = "/txpool.Txpool/OnAdd"
 	Txpool_Status_FullMethodName       = "/txpool.Txpool/Status"
 	Txpool_Nonce_FullMethodName        = "/txpool.Txpool/Nonce"
+	Txpool_ContentFrom_FullMethodName  = "/txpool.Txpool/ContentFrom"
+	Txpool_Content_FullMethodName      = "/txpool.Txpool/Content"
+	Txpool_Export_FullMethodName       = "/txpool.Txpool/Export"
+	Txpool_Inspect_FullMethodName      = "/txpool.Txpool/Inspect"
+	Txpool_Discards_FullMethodName     = "/txpool.Txpool/Discards"
+	Txpool_OnDiscard_FullMethodName    = "/txpool.Txpool/OnDiscard"
+	Txpool_GetBlobs_FullMethodName     = "/txpool.Txpool/GetBlobs"
+	Txpool_AddBundle_FullMethodName    = "/txpool.Txpool/AddBundle"
 )
 
 // TxpoolClient is the client API for Txpool service.
@@ -55,6 +63,23 @@ type TxpoolClient interface {
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusReply, error)
 	// returns nonce for given account
 	Nonce(ctx context.Context, in *NonceRequest, opts ...grpc.CallOption) (*NonceReply, error)
+	// returns all transactions of the sender, ordered by nonce
+	ContentFrom(ctx context.Context, in *ContentFromRequest, opts ...grpc.CallOption) (*ContentReply, error)
+	// returns a page of transactions of a sub-pool, ordered by sender and nonce
+	Content(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*ContentReply, error)
+	// returns a page of transactions of all sub-pools, for moving them to another pool. Pages are keyed by sender and nonce,
+	// so a transaction moving between sub-pools while paging is neither skipped nor repeated
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportReply, error)
+	// returns the summary of all transactions, without their RLP
+	Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectReply, error)
+	// returns the journaled discards and replacements of the transaction or of the sender
+	Discards(ctx context.Context, in *DiscardsRequest, opts ...grpc.CallOption) (*DiscardsReply, error)
+	// streams the discards and replacements as they are journaled
+	OnDiscard(ctx context.Context, in *OnDiscardRequest, opts ...grpc.CallOption) (Txpool_OnDiscardClient, error)
+	// Blobs and proofs of the pooled and recently mined transactions by their versioned hashes
+	GetBlobs(ctx context.Context, in *GetBlobsRequest, opts ...grpc.CallOption) (*GetBlobsReply, error)
+	// Adds a bundle of transactions which the block builder includes atomically, ahead of the pool transactions
+	AddBundle(ctx context.Context, in *AddBundleRequest, opts ...grpc.CallOption) (*AddBundleReply, error)
 }
 
 type txpoolClient struct {
@@ -169,6 +194,101 @@ func (c *txpoolClient) Nonce(ctx context.Context, in *NonceRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *txpoolClient) ContentFrom(ctx context.Context, in *ContentFromRequest, opts ...grpc.CallOption) (*ContentReply, error) {
+	out := new(ContentReply)
+	err := c.cc.Invoke(ctx, Txpool_ContentFrom_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) Content(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*ContentReply, error) {
+	out := new(ContentReply)
+	err := c.cc.Invoke(ctx, Txpool_Content_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportReply, error) {
+	out := new(ExportReply)
+	err := c.cc.Invoke(ctx, Txpool_Export_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectReply, error) {
+	out := new(InspectReply)
+	err := c.cc.Invoke(ctx, Txpool_Inspect_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) Discards(ctx context.Context, in *DiscardsRequest, opts ...grpc.CallOption) (*DiscardsReply, error) {
+	out := new(DiscardsReply)
+	err := c.cc.Invoke(ctx, Txpool_Discards_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) OnDiscard(ctx context.Context, in *OnDiscardRequest, opts ...grpc.CallOption) (Txpool_OnDiscardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Txpool_ServiceDesc.Streams[1], Txpool_OnDiscard_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &txpoolOnDiscardClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Txpool_OnDiscardClient interface {
+	Recv() (*DiscardsReply, error)
+	grpc.ClientStream
+}
+
+type txpoolOnDiscardClient struct {
+	grpc.ClientStream
+}
+
+func (x *txpoolOnDiscardClient) Recv() (*DiscardsReply, error) {
+	m := new(DiscardsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *txpoolClient) GetBlobs(ctx context.Context, in *GetBlobsRequest, opts ...grpc.CallOption) (*GetBlobsReply, error) {
+	out := new(GetBlobsReply)
+	err := c.cc.Invoke(ctx, Txpool_GetBlobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) AddBundle(ctx context.Context, in *AddBundleRequest, opts ...grpc.CallOption) (*AddBundleReply, error) {
+	out := new(AddBundleReply)
+	err := c.cc.Invoke(ctx, Txpool_AddBundle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TxpoolServer is the server API for Txpool service.
 // All implementations must embed UnimplementedTxpoolServer
 // for forward compatibility
@@ -192,6 +312,23 @@ type TxpoolServer interface {
 	Status(context.Context, *StatusRequest) (*StatusReply, error)
 	// returns nonce for given account
 	Nonce(context.Context, *NonceRequest) (*NonceReply, error)
+	// returns all transactions of the sender, ordered by nonce
+	ContentFrom(context.Context, *ContentFromRequest) (*ContentReply, error)
+	// returns a page of transactions of a sub-pool, ordered by sender and nonce
+	Content(context.Context, *ContentRequest) (*ContentReply, error)
+	// returns a page of transactions of all sub-pools, for moving them to another pool. Pages are keyed by sender and nonce,
+	// so a transaction moving between sub-pools while paging is neither skipped nor repeated
+	Export(context.Context, *ExportRequest) (*ExportReply, error)
+	// returns the summary of all transactions, without their RLP
+	Inspect(context.Context, *InspectRequest) (*InspectReply, error)
+	// returns the journaled discards and replacements of the transaction or of the sender
+	Discards(context.Context, *DiscardsRequest) (*DiscardsReply, error)
+	// streams the discards and replacements as they are journaled
+	OnDiscard(*OnDiscardRequest, Txpool_OnDiscardServer) error
+	// Blobs and proofs of the pooled and recently mined transactions by their versioned hashes
+	GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error)
+	// Adds a bundle of transactions which the block builder includes atomically, ahead of the pool transactions
+	AddBundle(context.Context, *AddBundleRequest) (*AddBundleReply, error)
 	mustEmbedUnimplementedTxpoolServer()
 }
 
@@ -226,6 +363,30 @@ func (UnimplementedTxpoolServer) Status(context.Context, *StatusRequest) (*Statu
 func (UnimplementedTxpoolServer) Nonce(context.Context, *NonceRequest) (*NonceReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Nonce not implemented")
 }
+func (UnimplementedTxpoolServer) ContentFrom(context.Context, *ContentFromRequest) (*ContentReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ContentFrom not implemented")
+}
+func (UnimplementedTxpoolServer) Content(context.Context, *ContentRequest) (*ContentReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Content not implemented")
+}
+func (UnimplementedTxpoolServer) Export(context.Context, *ExportRequest) (*ExportReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedTxpoolServer) Inspect(context.Context, *InspectRequest) (*InspectReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Inspect not implemented")
+}
+func (UnimplementedTxpoolServer) Discards(context.Context, *DiscardsRequest) (*DiscardsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Discards not implemented")
+}
+func (UnimplementedTxpoolServer) OnDiscard(*OnDiscardRequest, Txpool_OnDiscardServer) error {
+	return status.Errorf(codes.Unimplemented, "method OnDiscard not implemented")
+}
+func (UnimplementedTxpoolServer) GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobs not implemented")
+}
+func (UnimplementedTxpoolServer) AddBundle(context.Context, *AddBundleRequest) (*AddBundleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBundle not implemented")
+}
 func (UnimplementedTxpoolServer) mustEmbedUnimplementedTxpoolServer() {}
 
 // UnsafeTxpoolServer may be embedded to opt out of forward compatibility for this service.
@@ -404,6 +565,153 @@ func _Txpool_Nonce_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Txpool_ContentFrom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentFromRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).ContentFrom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_ContentFrom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).ContentFrom(ctx, req.(*ContentFromRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_Content_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).Content(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_Content_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).Content(ctx, req.(*ContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).Export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_Export_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).Export(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_Inspect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).Inspect(ctx, req.(*InspectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_Discards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).Discards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_Discards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).Discards(ctx, req.(*DiscardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_OnDiscard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OnDiscardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TxpoolServer).OnDiscard(m, &txpoolOnDiscardServer{stream})
+}
+
+type Txpool_OnDiscardServer interface {
+	Send(*DiscardsReply) error
+	grpc.ServerStream
+}
+
+type txpoolOnDiscardServer struct {
+	grpc.ServerStream
+}
+
+func (x *txpoolOnDiscardServer) Send(m *DiscardsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Txpool_GetBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).GetBlobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_GetBlobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).GetBlobs(ctx, req.(*GetBlobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_AddBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).AddBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_AddBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).AddBundle(ctx, req.(*AddBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Txpool_ServiceDesc is the grpc.ServiceDesc for Txpool service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -443,6 +751,34 @@ var Txpool_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Nonce",
 			Handler:    _Txpool_Nonce_Handler,
 		},
+		{
+			MethodName: "ContentFrom",
+			Handler:    _Txpool_ContentFrom_Handler,
+		},
+		{
+			MethodName: "Content",
+			Handler:    _Txpool_Content_Handler,
+		},
+		{
+			MethodName: "Export",
+			Handler:    _Txpool_Export_Handler,
+		},
+		{
+			MethodName: "Inspect",
+			Handler:    _Txpool_Inspect_Handler,
+		},
+		{
+			MethodName: "Discards",
+			Handler:    _Txpool_Discards_Handler,
+		},
+		{
+			MethodName: "GetBlobs",
+			Handler:    _Txpool_GetBlobs_Handler,
+		},
+		{
+			MethodName: "AddBundle",
+			Handler:    _Txpool_AddBundle_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -450,6 +786,11 @@ var Txpool_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Txpool_OnAdd_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "OnDiscard",
+			Handler:       _Txpool_OnDiscard_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "txpool/txpool.proto",
 }
@@ -17,6 +17,7 @@
 package remotedbserver
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"errors"
@@ -64,7 +65,8 @@ const MaxTxTTL = 60 * time.Second
 // 6.0.0 - Blocks now have system-txs - in the begin/end of block
 // 6.1.0 - Add methods Range, IndexRange, HistoryGet, HistoryRange
 // 6.2.0 - Add HistoryFiles to reply of Snapshots() method
-var KvServiceAPIVersion = &types.VersionReply{Major: 6, Minor: 2, Patch: 0}
+// 6.3.0 - Implement HistoryRange, DomainRange. Pages of Range, IndexRange are limited by PageSize - client must follow NextPageToken
+var KvServiceAPIVersion = &types.VersionReply{Major: 6, Minor: 3, Patch: 0}
 
 type KvServer struct {
 	remote.UnimplementedKVServer // must be embedded to have forward compatible implementations.
@@ -88,8 +90,12 @@ type KvServer struct {
 type threadSafeTx struct {
 	kv.Tx
 	sync.Mutex
+	pages map[string]*resumedKV // iterators of unfinished HistoryRange pages, valid until tx renew/rollback
 }
 
+// MaxUnfinishedPages - of one tx. Client may not follow the page token - then its iterator stays until tx end
+const MaxUnfinishedPages = 16
+
 type Snapsthots interface {
 	Files() []string
 }
@@ -183,6 +189,11 @@ func (s *KvServer) rollback(id uint64) {
 //	client, portion of data it to client, then read next portion in another `with` call.
 //	It will allow cooperative access to `tx` object
 func (s *KvServer) with(id uint64, f func(kv.Tx) error) error {
+	return s.withPages(id, func(tx *threadSafeTx) error { return f(tx.Tx) })
+}
+
+// withPages - same as `with`, also gives access to the iterators of unfinished pages of `tx`
+func (s *KvServer) withPages(id uint64, f func(*threadSafeTx) error) error {
 	s.txsMapLock.RLock()
 	tx, ok := s.txs[id]
 	s.txsMapLock.RUnlock()
@@ -203,7 +214,7 @@ func (s *KvServer) with(id uint64, f func(kv.Tx) error) error {
 			s.logger.Info(fmt.Sprintf("[kv_server] with %d unlock %s\n", id, dbg.Stack()[:2]))
 		}
 	}()
-	return f(tx.Tx)
+	return f(tx)
 }
 
 func (s *KvServer) Tx(stream remote.KV_TxServer) error {
@@ -552,6 +563,77 @@ func (s *KvServer) HistoryGet(ctx context.Context, req *remote.HistoryGetReq) (r
 
 const PageSizeLimit = 4 * 4096
 
+// pageSize - amount of items to send in one page: requested by client, but not more than PageSizeLimit,
+// and not more than `limit` (<= 0 means no limit)
+func pageSize(requested int32, limit int) int {
+	size := int(requested)
+	if size <= 0 || size > PageSizeLimit {
+		size = PageSizeLimit
+	}
+	if limit > 0 && limit < size {
+		size = limit
+	}
+	return size
+}
+
+// remainingLimit - `limit` for the next page, `ok=false` if limit is exhausted
+func remainingLimit(limit, sent int) (remaining int, ok bool) {
+	if limit <= 0 {
+		return limit, true
+	}
+	return limit - sent, limit > sent
+}
+
+// kvPage - reads up to `size` pairs of `it`. If `it` has more - returns first pair of the next page (already read from `it`).
+// Pairs are copied: iterators may reuse buffers, and reply is serialized after tx is released by `with`
+func kvPage(it iter.KV, size int) (reply *remote.Pairs, nextK, nextV []byte, err error) {
+	reply = &remote.Pairs{}
+	for len(reply.Keys) < size && it.HasNext() {
+		k, v, err := it.Next()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		reply.Keys = append(reply.Keys, common.Copy(k))
+		reply.Values = append(reply.Values, common.Copy(v))
+	}
+	if !it.HasNext() {
+		return reply, nil, nil, nil
+	}
+	if nextK, nextV, err = it.Next(); err != nil {
+		return nil, nil, nil, err
+	}
+	return reply, common.Copy(nextK), common.Copy(nextV), nil
+}
+
+// resumedKV - rest of the iterator after a page: first pair of the next page (read by kvPage), then `it`
+type resumedKV struct {
+	it      iter.KV
+	k, v    []byte
+	pending bool
+}
+
+func (r *resumedKV) HasNext() bool { return r.pending || r.it.HasNext() }
+func (r *resumedKV) Next() ([]byte, []byte, error) {
+	if r.pending {
+		r.pending = false
+		return r.k, r.v, nil
+	}
+	return r.it.Next()
+}
+
+// setPairsPageToken - sets NextPageToken of `reply`, if there is next page and `limit` is not exhausted
+func setPairsPageToken(reply *remote.Pairs, nextK []byte, limit int) (err error) {
+	if nextK == nil {
+		return nil
+	}
+	limit, ok := remainingLimit(limit, len(reply.Keys))
+	if !ok {
+		return nil
+	}
+	reply.NextPageToken, err = marshalPagination(&remote.ParisPagination{NextKey: nextK, Limit: int64(limit)})
+	return err
+}
+
 func (s *KvServer) IndexRange(ctx context.Context, req *remote.IndexRangeReq) (*remote.IndexRangeReply, error) {
 	reply := &remote.IndexRangeReply{}
 	from, limit := int(req.FromTs), int(req.Limit)
@@ -562,9 +644,7 @@ func (s *KvServer) IndexRange(ctx context.Context, req *remote.IndexRangeReq) (*
 		}
 		from, limit = int(pagination.NextTimeStamp), int(pagination.Limit)
 	}
-	if req.PageSize <= 0 || req.PageSize > PageSizeLimit {
-		req.PageSize = PageSizeLimit
-	}
+	size := pageSize(req.PageSize, limit)
 
 	if err := s.with(req.TxId, func(tx kv.Tx) error {
 		ttx, ok := tx.(kv.TemporalTx)
@@ -575,24 +655,89 @@ func (s *KvServer) IndexRange(ctx context.Context, req *remote.IndexRangeReq) (*
 		if err != nil {
 			return err
 		}
-		for it.HasNext() {
+		for len(reply.Timestamps) < size && it.HasNext() {
 			v, err := it.Next()
 			if err != nil {
 				return err
 			}
 			reply.Timestamps = append(reply.Timestamps, v)
-			limit--
 		}
-		if len(reply.Timestamps) == PageSizeLimit && it.HasNext() {
-			next, err := it.Next()
-			if err != nil {
-				return err
+		if !it.HasNext() {
+			return nil
+		}
+		limit, ok := remainingLimit(limit, len(reply.Timestamps))
+		if !ok {
+			return nil
+		}
+		next, err := it.Next()
+		if err != nil {
+			return err
+		}
+		reply.NextPageToken, err = marshalPagination(&remote.IndexPagination{NextTimeStamp: int64(next), Limit: int64(limit)})
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// HistoryRange - keys changed in [FromTs, ToTs) with their values as of FromTs, ordered by key. OrderAscend must be
+// true - descending order is not supported.
+// Underlying iterator can't start from given key, so it's kept in the tx by the page token it's positioned at - and
+// next page continues it. Only if it's gone (tx renewed, too many unfinished pages) - next page re-opens the
+// iterator and skips keys before ParisPagination.NextKey
+func (s *KvServer) HistoryRange(ctx context.Context, req *remote.HistoryRangeReq) (reply *remote.Pairs, err error) {
+	if !req.OrderAscend {
+		return nil, fmt.Errorf("HistoryRange: descending order is not supported")
+	}
+	var from []byte
+	limit := int(req.Limit)
+	if req.PageToken != "" {
+		var pagination remote.ParisPagination
+		if err := unmarshalPagination(req.PageToken, &pagination); err != nil {
+			return nil, err
+		}
+		from, limit = pagination.NextKey, int(pagination.Limit)
+	}
+	pageKey := func(token string) string {
+		return fmt.Sprintf("%s/%d/%d/%s", req.Table, req.FromTs, req.ToTs, token)
+	}
+
+	if err = s.withPages(req.TxId, func(tx *threadSafeTx) error {
+		resumed, ok := tx.pages[pageKey(req.PageToken)]
+		if ok {
+			delete(tx.pages, pageKey(req.PageToken)) // same token may be given to the other client - it will re-open
+		} else {
+			ttx, ok := tx.Tx.(kv.TemporalTx)
+			if !ok {
+				return fmt.Errorf("server DB doesn't implement kv.Temporal interface")
 			}
-			reply.NextPageToken, err = marshalPagination(&remote.IndexPagination{NextTimeStamp: int64(next), Limit: int64(limit)})
+			it, err := ttx.HistoryRange(kv.History(req.Table), int(req.FromTs), int(req.ToTs), order.Asc, -1) // limit is applied by pagination
 			if err != nil {
 				return err
 			}
+			if from != nil {
+				it = iter.FilterKV(it, func(k, _ []byte) bool { return bytes.Compare(k, from) >= 0 })
+			}
+			resumed = &resumedKV{it: it}
+		}
+		if reply, resumed.k, resumed.v, err = kvPage(resumed, pageSize(req.PageSize, limit)); err != nil {
+			return err
+		}
+		if err = setPairsPageToken(reply, resumed.k, limit); err != nil || reply.NextPageToken == "" {
+			return err
+		}
+		if tx.pages == nil {
+			tx.pages = map[string]*resumedKV{}
+		}
+		for k := range tx.pages {
+			if len(tx.pages) < MaxUnfinishedPages {
+				break
+			}
+			delete(tx.pages, k)
 		}
+		resumed.pending = true
+		tx.pages[pageKey(reply.NextPageToken)] = resumed
 		return nil
 	}); err != nil {
 		return nil, err
@@ -600,8 +745,15 @@ func (s *KvServer) IndexRange(ctx context.Context, req *remote.IndexRangeReq) (*
 	return reply, nil
 }
 
-func (s *KvServer) Range(ctx context.Context, req *remote.RangeReq) (*remote.Pairs, error) {
-	from, limit := req.FromPrefix, int(req.Limit)
+// DomainRange - state of keys in [FromKey, ToKey) as of Ts. OrderAscend must be true - descending order is not supported
+func (s *KvServer) DomainRange(ctx context.Context, req *remote.DomainRangeReq) (reply *remote.Pairs, err error) {
+	if !req.OrderAscend {
+		return nil, fmt.Errorf("DomainRange: descending order is not supported")
+	}
+	if req.Latest {
+		return nil, fmt.Errorf("DomainRange: latest state is not supported, use Range")
+	}
+	from, limit := req.FromKey, int(req.Limit)
 	if req.PageToken != "" {
 		var pagination remote.ParisPagination
 		if err := unmarshalPagination(req.PageToken, &pagination); err != nil {
@@ -609,12 +761,37 @@ func (s *KvServer) Range(ctx context.Context, req *remote.RangeReq) (*remote.Pai
 		}
 		from, limit = pagination.NextKey, int(pagination.Limit)
 	}
-	if req.PageSize <= 0 || req.PageSize > PageSizeLimit {
-		req.PageSize = PageSizeLimit
+
+	if err = s.with(req.TxId, func(tx kv.Tx) error {
+		ttx, ok := tx.(kv.TemporalTx)
+		if !ok {
+			return fmt.Errorf("server DB doesn't implement kv.Temporal interface")
+		}
+		it, err := ttx.DomainRange(kv.Domain(req.Table), from, req.ToKey, req.Ts, order.Asc, limit)
+		if err != nil {
+			return err
+		}
+		var nextK []byte
+		if reply, nextK, _, err = kvPage(it, pageSize(req.PageSize, limit)); err != nil {
+			return err
+		}
+		return setPairsPageToken(reply, nextK, limit)
+	}); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *KvServer) Range(ctx context.Context, req *remote.RangeReq) (reply *remote.Pairs, err error) {
+	from, limit := req.FromPrefix, int(req.Limit)
+	if req.PageToken != "" {
+		var pagination remote.ParisPagination
+		if err := unmarshalPagination(req.PageToken, &pagination); err != nil {
+			return nil, err
+		}
+		from, limit = pagination.NextKey, int(pagination.Limit)
 	}
 
-	reply := &remote.Pairs{}
-	var err error
 	if err = s.with(req.TxId, func(tx kv.Tx) error {
 		var it iter.KV
 		if req.OrderAscend {
@@ -628,26 +805,11 @@ func (s *KvServer) Range(ctx context.Context, req *remote.RangeReq) (*remote.Pai
 				return err
 			}
 		}
-		for it.HasNext() {
-			k, v, err := it.Next()
-			if err != nil {
-				return err
-			}
-			reply.Keys = append(reply.Keys, k)
-			reply.Values = append(reply.Values, v)
-			limit--
-		}
-		if len(reply.Keys) == PageSizeLimit && it.HasNext() {
-			nextK, _, err := it.Next()
-			if err != nil {
-				return err
-			}
-			reply.NextPageToken, err = marshalPagination(&remote.ParisPagination{NextKey: nextK, Limit: int64(limit)})
-			if err != nil {
-				return err
-			}
+		var nextK []byte
+		if reply, nextK, _, err = kvPage(it, pageSize(req.PageSize, limit)); err != nil {
+			return err
 		}
-		return nil
+		return setPairsPageToken(reply, nextK, limit)
 	}); err != nil {
 		return nil, err
 	}
@@ -19,12 +19,16 @@ package remotedbserver
 import (
 	"context"
 	"runtime"
+	"sync/atomic"
 	"testing"
 
 	"github.com/ledgerwatch/log/v3"
 	"github.com/stretchr/testify/require"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces/remote"
 	"github.com/tenderly/erigon/erigon-lib/kv"
+	"github.com/tenderly/erigon/erigon-lib/kv/iter"
 	"github.com/tenderly/erigon/erigon-lib/kv/memdb"
+	"github.com/tenderly/erigon/erigon-lib/kv/order"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -95,3 +99,127 @@ func TestKvServer_renew(t *testing.T) {
 	}
 	require.NoError(g.Wait())
 }
+
+// temporalTestDB - serves PlainState as history and domain of any name, and [from, to) as inverted index of any key.
+// Counts opened history iterators
+type temporalTestDB struct {
+	kv.RoDB
+	historyRanges *atomic.Int32
+}
+
+func (db temporalTestDB) BeginRo(ctx context.Context) (kv.Tx, error) {
+	tx, err := db.RoDB.BeginRo(ctx)
+	return temporalTestTx{tx, db.historyRanges}, err
+}
+
+type temporalTestTx struct {
+	kv.Tx
+	historyRanges *atomic.Int32
+}
+
+func (tx temporalTestTx) DomainGet(name kv.Domain, k, k2 []byte) ([]byte, bool, error) {
+	v, err := tx.GetOne(kv.PlainState, k)
+	return v, v != nil, err
+}
+func (tx temporalTestTx) DomainGetAsOf(name kv.Domain, k, k2 []byte, ts uint64) ([]byte, bool, error) {
+	return tx.DomainGet(name, k, k2)
+}
+func (tx temporalTestTx) HistoryGet(name kv.History, k []byte, ts uint64) ([]byte, bool, error) {
+	return tx.DomainGet(kv.Domain(name), k, nil)
+}
+func (tx temporalTestTx) IndexRange(name kv.InvertedIdx, k []byte, fromTs, toTs int, asc order.By, limit int) (iter.U64, error) {
+	return iter.Range[uint64](uint64(fromTs), uint64(toTs)), nil
+}
+func (tx temporalTestTx) HistoryRange(name kv.History, fromTs, toTs int, asc order.By, limit int) (iter.KV, error) {
+	tx.historyRanges.Add(1)
+	return tx.RangeAscend(kv.PlainState, nil, nil, limit)
+}
+func (tx temporalTestTx) DomainRange(name kv.Domain, fromKey, toKey []byte, ts uint64, asc order.By, limit int) (iter.KV, error) {
+	return tx.RangeAscend(kv.PlainState, fromKey, toKey, limit)
+}
+
+func TestKvServer_pagination(t *testing.T) {
+	require, ctx, db := require.New(t), context.Background(), memdb.NewTestDB(t)
+	var keys [][]byte
+	require.NoError(db.Update(ctx, func(tx kv.RwTx) error {
+		for i := byte(0); i < 10; i++ {
+			keys = append(keys, []byte{i})
+			if err := tx.Put(kv.PlainState, []byte{i}, []byte{i, i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	historyRanges := &atomic.Int32{}
+	s := NewKvServer(ctx, temporalTestDB{db, historyRanges}, nil, nil, log.New())
+	id, err := s.begin(ctx)
+	require.NoError(err)
+	defer s.rollback(id)
+
+	const pageSize = 3
+	pages := func(page func(token string) (*remote.Pairs, error)) (keys [][]byte) {
+		for token := ""; ; {
+			reply, err := page(token)
+			require.NoError(err)
+			require.LessOrEqual(len(reply.Keys), pageSize)
+			require.Equal(len(reply.Keys), len(reply.Values))
+			keys = append(keys, reply.Keys...)
+			if token = reply.NextPageToken; token == "" {
+				return keys
+			}
+		}
+	}
+	rangeKeys := func(asc bool, limit int64) [][]byte {
+		return pages(func(token string) (*remote.Pairs, error) {
+			return s.Range(ctx, &remote.RangeReq{TxId: id, Table: kv.PlainState, OrderAscend: asc, Limit: limit, PageSize: pageSize, PageToken: token})
+		})
+	}
+	require.Equal(keys, rangeKeys(true, -1))
+	require.Equal(keys[:7], rangeKeys(true, 7))
+	require.Equal([][]byte{{9}, {8}, {7}, {6}}, rangeKeys(false, 4))
+
+	historyKeys := func(limit int64) [][]byte {
+		return pages(func(token string) (*remote.Pairs, error) {
+			return s.HistoryRange(ctx, &remote.HistoryRangeReq{TxId: id, Table: string(kv.AccountsHistory), FromTs: 2, ToTs: 5, OrderAscend: true, Limit: limit, PageSize: pageSize, PageToken: token})
+		})
+	}
+	historyPage := func(token string) *remote.Pairs {
+		reply, err := s.HistoryRange(ctx, &remote.HistoryRangeReq{TxId: id, Table: string(kv.AccountsHistory), FromTs: 2, ToTs: 5, OrderAscend: true, Limit: -1, PageSize: pageSize, PageToken: token})
+		require.NoError(err)
+		return reply
+	}
+	// next pages continue the iterator of the first one
+	require.Equal(keys, historyKeys(-1))
+	require.Equal(int32(1), historyRanges.Load())
+	require.Equal(keys[:5], historyKeys(5))
+	require.Equal(int32(2), historyRanges.Load())
+
+	// same token can be followed again, and after tx renew - then iterator is re-opened from the key of the token
+	first := historyPage("")
+	second := historyPage(first.NextPageToken)
+	require.Equal(keys[3:6], second.Keys)
+	require.Equal(keys[3:6], historyPage(first.NextPageToken).Keys)
+	require.Equal(int32(4), historyRanges.Load())
+	require.NoError(s.renew(ctx, id))
+	require.Equal(keys[6:9], historyPage(second.NextPageToken).Keys)
+	require.Equal(int32(5), historyRanges.Load())
+	_, err = s.HistoryRange(ctx, &remote.HistoryRangeReq{TxId: id, Table: string(kv.AccountsHistory), OrderAscend: false})
+	require.Error(err)
+
+	domainKeys := pages(func(token string) (*remote.Pairs, error) {
+		return s.DomainRange(ctx, &remote.DomainRangeReq{TxId: id, Table: string(kv.AccountsDomain), FromKey: []byte{2}, Ts: 5, OrderAscend: true, Limit: -1, PageSize: pageSize, PageToken: token})
+	})
+	require.Equal(keys[2:], domainKeys)
+
+	var timestamps []uint64
+	for token := ""; ; {
+		reply, err := s.IndexRange(ctx, &remote.IndexRangeReq{TxId: id, Table: string(kv.LogAddrIdx), FromTs: 1, ToTs: 9, OrderAscend: true, Limit: 7, PageSize: pageSize, PageToken: token})
+		require.NoError(err)
+		timestamps = append(timestamps, reply.Timestamps...)
+		if token = reply.NextPageToken; token == "" {
+			break
+		}
+	}
+	require.Equal([]uint64{1, 2, 3, 4, 5, 6, 7}, timestamps)
+}
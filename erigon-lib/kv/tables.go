@@ -255,6 +255,9 @@ const (
 
 	BlockBody = "BlockBody" // block_num_u64 + hash -> block body
 
+	// BadBlocks keeps the last few blocks rejected by block validation, together with the reason of rejection
+	BadBlocks = "BadBlocks" // block_num_u64 + hash -> RLP(block, reason)
+
 	// Naming:
 	//  TxNum - Ethereum canonical transaction number - same across all nodes.
 	//  TxnID - auto-increment ID - can be differrent across all nodes
@@ -303,6 +306,11 @@ const (
 
 	TxLookup = "BlockTransactionLookup" // hash -> transaction/receipt lookup metadata
 
+	// BlobSidecars keeps the blobs of the mined blob transactions for the data availability window,
+	// the blocks only carry their versioned hashes
+	BlobSidecars        = "BlobSidecar"       // block_num_u64 + tx_hash -> RLP(blobs, commitments, proofs)
+	BlobVersionedHashes = "BlobVersionedHash" // versioned_hash -> block_num_u64 + tx_hash
+
 	ConfigTable = "Config" // config prefix for the db
 
 	// Progress of sync stages: stageName -> stageData
@@ -537,8 +545,11 @@ var ChaindataTables = []string{
 	HeaderNumber,
 	BadHeaderNumber,
 	BlockBody,
+	BadBlocks,
 	Receipts,
 	TxLookup,
+	BlobSidecars,
+	BlobVersionedHashes,
 	ConfigTable,
 	CurrentExecutionPayload,
 	DatabaseInfo,
@@ -683,12 +694,16 @@ const (
 	RecentLocalTransaction = "RecentLocalTransaction" // sequence_u64 -> tx_hash
 	PoolTransaction        = "PoolTransaction"        // txHash -> sender_id_u64+tx_rlp
 	PoolInfo               = "PoolInfo"               // option_key -> option_value
+	PoolDiscard            = "PoolDiscard"            // sequence_u64 -> tx_hash+sender+nonce_u64+reason_u8+replaced_by+timestamp_u64
+	PoolPrivateTransaction = "PoolPrivateTransaction" // tx_hash -> max_block_number_u64
 )
 
 var TxPoolTables = []string{
 	RecentLocalTransaction,
 	PoolTransaction,
 	PoolInfo,
+	PoolDiscard,
+	PoolPrivateTransaction,
 }
 var SentryTables = []string{}
 var DownloaderTables = []string{
@@ -0,0 +1,106 @@
+/*
+   Copyright 2024 The Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpool
+
+import (
+	"fmt"
+
+	"github.com/tenderly/erigon/erigon-lib/types"
+)
+
+const (
+	maxBundles     = 1_000 // bundles kept by the pool, for all the target blocks
+	maxBundleTxs   = 64
+	maxBundleAhead = 256 // how far after the last seen block a bundle may target
+)
+
+// bundlePool keeps the bundles in the order of submission, they are not persisted.
+// Must be used under the pool lock.
+type bundlePool struct {
+	bundles []*types.TxsBundle
+}
+
+// add keeps the bundle unless the same one is already known
+func (b *bundlePool) add(bundle *types.TxsBundle) error {
+	for _, known := range b.bundles {
+		if known.Hash == bundle.Hash && known.BlockNumber == bundle.BlockNumber {
+			return nil
+		}
+	}
+	if len(b.bundles) >= maxBundles {
+		return fmt.Errorf("too many bundles, limit %d", maxBundles)
+	}
+	b.bundles = append(b.bundles, bundle)
+	return nil
+}
+
+// forBlock returns the bundles which can be included in the block
+func (b *bundlePool) forBlock(blockNum, timestamp uint64) (res []*types.TxsBundle) {
+	for _, bundle := range b.bundles {
+		if bundle.BlockNumber != blockNum {
+			continue
+		}
+		if bundle.MinTimestamp != 0 && timestamp < bundle.MinTimestamp {
+			continue
+		}
+		if bundle.MaxTimestamp != 0 && timestamp > bundle.MaxTimestamp {
+			continue
+		}
+		res = append(res, bundle)
+	}
+	return res
+}
+
+// dropUpTo forgets the bundles of the block and the earlier ones
+func (b *bundlePool) dropUpTo(blockNum uint64) {
+	kept := b.bundles[:0]
+	for _, bundle := range b.bundles {
+		if bundle.BlockNumber > blockNum {
+			kept = append(kept, bundle)
+		}
+	}
+	for i := len(kept); i < len(b.bundles); i++ {
+		b.bundles[i] = nil
+	}
+	b.bundles = kept
+}
+
+// AddBundle keeps a bundle for the block builder, which includes all its transactions in order, ahead of the
+// pool transactions, or none of them. The bundle transactions don't enter the pool and are not announced.
+func (p *TxPool) AddBundle(bundle *types.TxsBundle) error {
+	if len(bundle.Txs) == 0 || len(bundle.Txs) > maxBundleTxs {
+		return fmt.Errorf("bundle of %d transactions, expected from 1 to %d", len(bundle.Txs), maxBundleTxs)
+	}
+	if bundle.MaxTimestamp != 0 && bundle.MaxTimestamp < bundle.MinTimestamp {
+		return fmt.Errorf("bundle max timestamp %d is before min timestamp %d", bundle.MaxTimestamp, bundle.MinTimestamp)
+	}
+	lastSeenBlock := p.lastSeenBlock.Load()
+	if bundle.BlockNumber <= lastSeenBlock || bundle.BlockNumber > lastSeenBlock+maxBundleAhead {
+		return fmt.Errorf("bundle block number %d is not in the next %d blocks after %d", bundle.BlockNumber, maxBundleAhead, lastSeenBlock)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.bundles.add(bundle)
+}
+
+// BundlesFor returns the bundles which can be included in the block, in the order of submission
+func (p *TxPool) BundlesFor(blockNum, timestamp uint64) []*types.TxsBundle {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.bundles.forBlock(blockNum, timestamp)
+}
@@ -0,0 +1,78 @@
+/*
+   Copyright 2024 The Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tenderly/erigon/erigon-lib/common/fixedgas"
+	"github.com/tenderly/erigon/erigon-lib/common/u256"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces/remote"
+	"github.com/tenderly/erigon/erigon-lib/kv/kvcache"
+	"github.com/tenderly/erigon/erigon-lib/kv/memdb"
+	"github.com/tenderly/erigon/erigon-lib/txpool/txpoolcfg"
+	"github.com/tenderly/erigon/erigon-lib/types"
+)
+
+func TestBundles(t *testing.T) {
+	require := require.New(t)
+	ch := make(chan types.Announcements, 100)
+	db, coreDB := memdb.NewTestPoolDB(t), memdb.NewTestDB(t)
+
+	pool, err := New(ch, coreDB, txpoolcfg.DefaultConfig, kvcache.New(kvcache.DefaultCoherentConfig), *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	require.NoError(err)
+	ctx := context.Background()
+	newBlock := func(height uint64) *remote.StateChangeBatch {
+		return &remote.StateChangeBatch{
+			StateVersionId:      height,
+			PendingBlockBaseFee: 200000,
+			BlockGasLimit:       1000000,
+			ChangeBatch: []*remote.StateChange{{
+				BlockHeight: height,
+				BlockHash:   gointerfaces.ConvertHashToH256([32]byte{byte(height)}),
+			}},
+		}
+	}
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	require.NoError(pool.OnNewBlock(ctx, newBlock(5), types.TxSlots{}, types.TxSlots{}, tx))
+
+	require.Error(pool.AddBundle(&types.TxsBundle{BlockNumber: 6}))                                                        // no transactions
+	require.Error(pool.AddBundle(&types.TxsBundle{Txs: [][]byte{{1}}, BlockNumber: 5}))                                    // already seen
+	require.Error(pool.AddBundle(&types.TxsBundle{Txs: [][]byte{{1}}, BlockNumber: 6, MinTimestamp: 10, MaxTimestamp: 9})) // empty time range
+
+	first := &types.TxsBundle{Hash: [32]byte{1}, Txs: [][]byte{{1}}, BlockNumber: 6}
+	bounded := &types.TxsBundle{Hash: [32]byte{2}, Txs: [][]byte{{2}}, BlockNumber: 6, MinTimestamp: 100, MaxTimestamp: 200}
+	later := &types.TxsBundle{Hash: [32]byte{3}, Txs: [][]byte{{3}}, BlockNumber: 7}
+	for _, bundle := range []*types.TxsBundle{first, bounded, later, first} {
+		require.NoError(pool.AddBundle(bundle))
+	}
+	require.Equal([]*types.TxsBundle{first}, pool.BundlesFor(6, 50))
+	require.Equal([]*types.TxsBundle{first, bounded}, pool.BundlesFor(6, 150))
+	require.Equal([]*types.TxsBundle{first}, pool.BundlesFor(6, 250))
+	require.Equal([]*types.TxsBundle{later}, pool.BundlesFor(7, 0))
+
+	require.NoError(pool.OnNewBlock(ctx, newBlock(6), types.TxSlots{}, types.TxSlots{}, tx))
+	require.Empty(pool.BundlesFor(6, 150))
+	require.Equal([]*types.TxsBundle{later}, pool.BundlesFor(7, 0))
+}
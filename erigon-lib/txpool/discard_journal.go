@@ -0,0 +1,219 @@
+/*
+   Copyright 2024 The Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpool
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/kv"
+	"github.com/tenderly/erigon/erigon-lib/txpool/txpoolcfg"
+)
+
+// DiscardEntry is a record of the discard journal - a transaction which left the pool for another reason than being mined
+type DiscardEntry struct {
+	Hash       common.Hash
+	Sender     common.Address
+	Nonce      uint64
+	Reason     txpoolcfg.DiscardReason
+	ReplacedBy common.Hash // hash of the replacing transaction, zero unless the reason is ReplacedByHigherTip
+	Time       uint64      // unix time of the discard, in seconds
+}
+
+const discardEntryLen = 32 + 20 + 8 + 1 + 32 + 8
+
+func (e *DiscardEntry) encode(buf []byte) []byte {
+	buf = common.EnsureEnoughSize(buf, discardEntryLen)
+	copy(buf, e.Hash[:])
+	copy(buf[32:], e.Sender[:])
+	binary.BigEndian.PutUint64(buf[52:], e.Nonce)
+	buf[60] = byte(e.Reason)
+	copy(buf[61:], e.ReplacedBy[:])
+	binary.BigEndian.PutUint64(buf[93:], e.Time)
+	return buf
+}
+
+func (e *DiscardEntry) decode(v []byte) error {
+	if len(v) != discardEntryLen {
+		return fmt.Errorf("discard entry: unexpected length %d", len(v))
+	}
+	copy(e.Hash[:], v)
+	copy(e.Sender[:], v[32:])
+	e.Nonce = binary.BigEndian.Uint64(v[52:])
+	e.Reason = txpoolcfg.DiscardReason(v[60])
+	copy(e.ReplacedBy[:], v[61:])
+	e.Time = binary.BigEndian.Uint64(v[93:])
+	return nil
+}
+
+// discardJournal keeps the latest discards in a ring, they are persisted to kv.PoolDiscard on flush.
+// Must be used under the pool lock, except of subscribe.
+type discardJournal struct {
+	entries []DiscardEntry // entry with sequence number s is at s % len(entries)
+	first   uint64         // sequence number of the oldest valid entry
+	seq     uint64         // sequence number of the next entry
+	flushed uint64         // entries below it are already persisted
+
+	subsLock sync.Mutex
+	subs     map[uint]chan DiscardEntry
+	subID    uint
+}
+
+func newDiscardJournal(limit int) *discardJournal {
+	if limit < 0 {
+		limit = 0
+	}
+	return &discardJournal{entries: make([]DiscardEntry, limit), subs: map[uint]chan DiscardEntry{}}
+}
+
+func (j *discardJournal) add(e DiscardEntry) {
+	if len(j.entries) == 0 {
+		return
+	}
+	j.entries[j.seq%uint64(len(j.entries))] = e
+	j.seq++
+
+	j.subsLock.Lock()
+	defer j.subsLock.Unlock()
+	for _, ch := range j.subs {
+		select {
+		case ch <- e:
+		default: // slow subscriber, it loses the entry
+		}
+	}
+}
+
+// oldest returns the sequence number of the oldest entry still in the ring
+func (j *discardJournal) oldest() uint64 {
+	if limit := uint64(len(j.entries)); j.seq > limit && j.seq-limit > j.first {
+		return j.seq - limit
+	}
+	return j.first
+}
+
+// forEach calls f for the entries, oldest first
+func (j *discardJournal) forEach(f func(e *DiscardEntry)) {
+	for s := j.oldest(); s < j.seq; s++ {
+		f(&j.entries[s%uint64(len(j.entries))])
+	}
+}
+
+func (j *discardJournal) byHash(hash common.Hash) (res []DiscardEntry) {
+	j.forEach(func(e *DiscardEntry) {
+		if e.Hash == hash {
+			res = append(res, *e)
+		}
+	})
+	return res
+}
+
+func (j *discardJournal) bySender(sender common.Address) (res []DiscardEntry) {
+	j.forEach(func(e *DiscardEntry) {
+		if e.Sender == sender {
+			res = append(res, *e)
+		}
+	})
+	return res
+}
+
+// subscribe returns a channel receiving the new entries, a slow subscriber misses the entries which don't fit in its buffer
+func (j *discardJournal) subscribe() (<-chan DiscardEntry, func()) {
+	j.subsLock.Lock()
+	defer j.subsLock.Unlock()
+	j.subID++
+	id := j.subID
+	ch := make(chan DiscardEntry, 1024)
+	j.subs[id] = ch
+	return ch, func() {
+		j.subsLock.Lock()
+		defer j.subsLock.Unlock()
+		delete(j.subs, id)
+	}
+}
+
+// flush persists the new entries and deletes the ones which fell out of the ring
+func (j *discardJournal) flush(tx kv.RwTx) error {
+	from := j.oldest()
+	if j.flushed > from {
+		from = j.flushed
+	}
+	k := make([]byte, 8)
+	var v []byte
+	for s := from; s < j.seq; s++ {
+		binary.BigEndian.PutUint64(k, s)
+		v = j.entries[s%uint64(len(j.entries))].encode(v)
+		if err := tx.Put(kv.PoolDiscard, k, v); err != nil {
+			return err
+		}
+	}
+	j.flushed = j.seq
+
+	c, err := tx.RwCursor(kv.PoolDiscard)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	oldest := j.oldest()
+	for k, _, err := c.First(); k != nil; k, _, err = c.Next() {
+		if err != nil {
+			return err
+		}
+		if binary.BigEndian.Uint64(k) >= oldest {
+			break
+		}
+		if err := c.DeleteCurrent(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *discardJournal) fromDB(tx kv.Tx) error {
+	if len(j.entries) == 0 {
+		return nil
+	}
+	c, err := tx.Cursor(kv.PoolDiscard)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	last, _, err := c.Last()
+	if err != nil || last == nil {
+		return err
+	}
+	j.seq = binary.BigEndian.Uint64(last) + 1
+	j.first, j.flushed = j.seq, j.seq
+	var from uint64
+	if limit := uint64(len(j.entries)); j.seq > limit {
+		from = j.seq - limit
+	}
+	for k, v, err := c.Seek(binary.BigEndian.AppendUint64(nil, from)); k != nil; k, v, err = c.Next() {
+		if err != nil {
+			return err
+		}
+		s := binary.BigEndian.Uint64(k)
+		if err := j.entries[s%uint64(len(j.entries))].decode(v); err != nil {
+			return err
+		}
+		if s < j.first {
+			j.first = s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+   Copyright 2024 The Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/fixedgas"
+	"github.com/tenderly/erigon/erigon-lib/common/u256"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces/remote"
+	"github.com/tenderly/erigon/erigon-lib/kv"
+	"github.com/tenderly/erigon/erigon-lib/kv/kvcache"
+	"github.com/tenderly/erigon/erigon-lib/kv/memdb"
+	"github.com/tenderly/erigon/erigon-lib/txpool/txpoolcfg"
+	"github.com/tenderly/erigon/erigon-lib/types"
+)
+
+func TestDiscardJournal(t *testing.T) {
+	require := require.New(t)
+	db := memdb.NewTestPoolDB(t)
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(err)
+	defer tx.Rollback()
+
+	j := newDiscardJournal(3)
+	entries, unsubscribe := j.subscribe()
+	defer unsubscribe()
+	for i := 0; i < 5; i++ {
+		j.add(DiscardEntry{Hash: common.Hash{byte(i)}, Sender: common.Address{byte(i % 2)}, Nonce: uint64(i), Reason: txpoolcfg.Spammer, Time: 1})
+		require.Equal(uint64(i), (<-entries).Nonce)
+		if i == 1 {
+			require.NoError(j.flush(tx))
+		}
+	}
+	require.Len(j.byHash(common.Hash{0}), 0) // fell out of the ring
+	require.Len(j.byHash(common.Hash{4}), 1)
+	require.Equal([]uint64{2, 4}, nonces(j.bySender(common.Address{0})))
+
+	require.NoError(j.flush(tx))
+	var count int
+	require.NoError(tx.ForEach(kv.PoolDiscard, nil, func(k, v []byte) error {
+		count++
+		return nil
+	}))
+	require.Equal(3, count)
+
+	restored := newDiscardJournal(3)
+	require.NoError(restored.fromDB(tx))
+	require.Equal(nonces(j.bySender(common.Address{1})), nonces(restored.bySender(common.Address{1})))
+	require.Equal(j.byHash(common.Hash{4}), restored.byHash(common.Hash{4}))
+	restored.add(DiscardEntry{Hash: common.Hash{5}, Nonce: 5})
+	require.Equal([]uint64{4, 5}, nonces(restored.bySender(common.Address{0})))
+
+	// a larger journal doesn't make up the entries which were never persisted
+	larger := newDiscardJournal(10)
+	require.NoError(larger.fromDB(tx))
+	require.Equal([]uint64{3}, nonces(larger.bySender(common.Address{1})))
+}
+
+func nonces(entries []DiscardEntry) (res []uint64) {
+	for _, e := range entries {
+		res = append(res, e.Nonce)
+	}
+	return res
+}
+
+func TestDiscardJournalReplacement(t *testing.T) {
+	require := require.New(t)
+	ch := make(chan types.Announcements, 100)
+	db, coreDB := memdb.NewTestPoolDB(t), memdb.NewTestDB(t)
+
+	pool, err := New(ch, coreDB, txpoolcfg.DefaultConfig, kvcache.New(kvcache.DefaultCoherentConfig), *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	require.NoError(err)
+	ctx := context.Background()
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})},
+		},
+	}
+	addr := common.Address{1}
+	v := make([]byte, types.EncodeSenderLengthForStorage(2, *uint256.NewInt(1 * common.Ether)))
+	types.EncodeSender(2, *uint256.NewInt(1 * common.Ether), v)
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    v,
+	})
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, tx))
+
+	add := func(id byte, fee uint64) {
+		var txSlots types.TxSlots
+		txSlot := &types.TxSlot{Tip: *uint256.NewInt(fee), FeeCap: *uint256.NewInt(fee), Gas: 100000, Nonce: 3}
+		txSlot.IDHash[0] = id
+		txSlots.Append(txSlot, addr[:], true)
+		reasons, err := pool.AddLocalTxs(ctx, txSlots, tx)
+		require.NoError(err)
+		require.Equal(txpoolcfg.Success, reasons[0], reasons[0].String())
+	}
+	add(1, 300000)
+	add(2, 3000000)
+
+	discards, _, inPool := pool.TxDiscards(common.Hash{1})
+	require.False(inPool)
+	require.Len(discards, 1)
+	require.Equal(txpoolcfg.ReplacedByHigherTip, discards[0].Reason)
+	require.Equal(common.Hash{2}, discards[0].ReplacedBy)
+	require.Equal(addr, discards[0].Sender)
+	require.Equal(uint64(3), discards[0].Nonce)
+
+	discards, subPool, inPool := pool.TxDiscards(common.Hash{2})
+	require.True(inPool)
+	require.Equal(QueuedSubPool, subPool) // the sender nonce is 2
+	require.Empty(discards)
+	require.Len(pool.SenderDiscards(addr), 1)
+
+	// the journal survives the restart
+	require.NoError(pool.flushLocked(tx))
+	restarted, err := New(ch, coreDB, txpoolcfg.DefaultConfig, kvcache.New(kvcache.DefaultCoherentConfig), *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	require.NoError(err)
+	require.NoError(restarted.discards.fromDB(tx))
+	require.Equal(pool.SenderDiscards(addr), restarted.SenderDiscards(addr))
+}
@@ -0,0 +1,54 @@
+/*
+   Copyright 2024 The Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpool
+
+import (
+	"github.com/holiman/uint256"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/txpool/txpoolcfg"
+	"github.com/tenderly/erigon/erigon-lib/types"
+)
+
+// DefaultPolicy admits all the valid transactions and orders them by the sub-pool marker, effective tip, nonce and
+// balance distances. Custom policies can embed it to override only a part of the behaviour.
+type DefaultPolicy struct{}
+
+var _ txpoolcfg.Policy = DefaultPolicy{}
+
+func (DefaultPolicy) Admit(*types.TxSlot, common.Address, bool) txpoolcfg.DiscardReason {
+	return txpoolcfg.Success
+}
+
+func (DefaultPolicy) Better(mt, than txpoolcfg.PoolTx, pendingBaseFee uint64) bool {
+	return mt.(*metaTx).better(than.(*metaTx), *uint256.NewInt(pendingBaseFee))
+}
+
+func (DefaultPolicy) Worse(mt, than txpoolcfg.PoolTx, pendingBaseFee uint64) bool {
+	return mt.(*metaTx).worse(than.(*metaTx), *uint256.NewInt(pendingBaseFee))
+}
+
+var _ txpoolcfg.PoolTx = (*metaTx)(nil)
+
+func (mt *metaTx) TxSlot() *types.TxSlot             { return mt.Tx }
+func (mt *metaTx) SubPoolMarker() uint8              { return uint8(mt.subPool) }
+func (mt *metaTx) CurrentSubPool() uint8             { return uint8(mt.currentSubPool) }
+func (mt *metaTx) NonceDistance() uint64             { return mt.nonceDistance }
+func (mt *metaTx) CumulativeBalanceDistance() uint64 { return mt.cumulativeBalanceDistance }
+func (mt *metaTx) MinFeeCap() *uint256.Int           { return &mt.minFeeCap }
+func (mt *metaTx) MinTip() uint64                    { return mt.minTip }
+func (mt *metaTx) Timestamp() uint64                 { return mt.timestamp }
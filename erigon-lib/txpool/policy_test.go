@@ -0,0 +1,106 @@
+/*
+   Copyright 2024 The Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/fixedgas"
+	"github.com/tenderly/erigon/erigon-lib/common/u256"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces/remote"
+	"github.com/tenderly/erigon/erigon-lib/kv/kvcache"
+	"github.com/tenderly/erigon/erigon-lib/kv/memdb"
+	"github.com/tenderly/erigon/erigon-lib/txpool/txpoolcfg"
+	"github.com/tenderly/erigon/erigon-lib/types"
+)
+
+// fifoAllowlistPolicy admits only the allowed senders and mines in the arrival order
+type fifoAllowlistPolicy struct {
+	DefaultPolicy
+	allowed map[common.Address]struct{}
+}
+
+func (p fifoAllowlistPolicy) Admit(_ *types.TxSlot, sender common.Address, _ bool) txpoolcfg.DiscardReason {
+	if _, ok := p.allowed[sender]; !ok {
+		return txpoolcfg.PolicyRejected
+	}
+	return txpoolcfg.Success
+}
+
+func (fifoAllowlistPolicy) Better(mt, than txpoolcfg.PoolTx, _ uint64) bool {
+	return mt.Timestamp() < than.Timestamp()
+}
+
+func TestPolicy(t *testing.T) {
+	senders := []common.Address{{1}, {2}, {3}}
+	for _, fifo := range []bool{false, true} {
+		require := require.New(t)
+		cfg := txpoolcfg.DefaultConfig
+		if fifo {
+			cfg.Policy = fifoAllowlistPolicy{allowed: map[common.Address]struct{}{senders[0]: {}, senders[1]: {}}}
+		}
+		db, coreDB := memdb.NewTestPoolDB(t), memdb.NewTestDB(t)
+		pool, err := New(make(chan types.Announcements, 100), coreDB, cfg, kvcache.New(kvcache.DefaultCoherentConfig), *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+		require.NoError(err)
+		ctx := context.Background()
+		change := &remote.StateChangeBatch{
+			PendingBlockBaseFee: 200000,
+			BlockGasLimit:       1000000,
+			ChangeBatch: []*remote.StateChange{
+				{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})},
+			},
+		}
+		v := make([]byte, types.EncodeSenderLengthForStorage(0, *uint256.NewInt(1 * common.Ether)))
+		types.EncodeSender(0, *uint256.NewInt(1 * common.Ether), v)
+		for _, sender := range senders {
+			change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+				Action:  remote.Action_UPSERT,
+				Address: gointerfaces.ConvertAddressToH160(sender),
+				Data:    v,
+			})
+		}
+		tx, err := db.BeginRw(ctx)
+		require.NoError(err)
+		defer tx.Rollback()
+		require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, tx))
+
+		var txSlots types.TxSlots
+		for i, sender := range senders {
+			txSlot := &types.TxSlot{Tip: *uint256.NewInt(uint64(i+1) * 10_000), FeeCap: *uint256.NewInt(300000), Gas: 100000}
+			txSlot.IDHash[0] = byte(i + 1)
+			txSlots.Append(txSlot, sender[:], true)
+		}
+		reasons, err := pool.AddLocalTxs(ctx, txSlots, tx)
+		require.NoError(err)
+		require.Equal(txpoolcfg.Success, reasons[0])
+		require.Equal(txpoolcfg.Success, reasons[1])
+		if fifo {
+			require.Equal(txpoolcfg.PolicyRejected, reasons[2])
+			require.Equal(uint8(1), pool.pending.Best().Tx.IDHash[0]) // the first one, despite of the lower tip
+		} else {
+			require.Equal(txpoolcfg.Success, reasons[2])
+			require.Equal(uint8(3), pool.pending.Best().Tx.IDHash[0])
+		}
+	}
+}
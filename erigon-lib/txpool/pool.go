@@ -198,12 +198,16 @@ type TxPool struct {
 	unprocessedRemoteByHash map[string]int                                  // to reject duplicates
 	byHash                  map[string]*metaTx                              // tx_hash => tx : only those records not committed to db yet
 	discardReasonsLRU       *simplelru.LRU[string, txpoolcfg.DiscardReason] // tx_hash => discard_reason : non-persisted
+	discards                *discardJournal                                 // latest discards and replacements : persisted
 	pending                 *PendingPool
 	baseFee                 *SubPool
 	queued                  *SubPool
 	minedBlobTxsByBlock     map[uint64][]*metaTx             // (blockNum => slice): cache of recently mined blobs
 	minedBlobTxsByHash      map[string]*metaTx               // (hash => mt): map of recently mined blobs
+	blobTxsByVersionedHash  map[common.Hash][]*metaTx        // (versioned_hash => txs): pooled and recently mined txs carrying the blob
 	isLocalLRU              *simplelru.LRU[string, struct{}] // tx_hash => is_local : to restore isLocal flag of unwinded transactions
+	privateTxs              map[string]uint64                // tx_hash => max_block_number : local transactions which are never announced
+	bundles                 bundlePool                       // bundles for the block builder : non-persisted
 	newPendingTxs           chan types.Announcements         // notifications about new txs in Pending sub-pool
 	all                     *BySenderAndNonce                // senderID => (sorted map of tx nonce => *metaTx)
 	deletedTxs              []*metaTx                        // list of discarded txs since last db commit
@@ -229,6 +233,9 @@ type TxPool struct {
 func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config, cache kvcache.Cache,
 	chainID uint256.Int, shanghaiTime, agraBlock, cancunTime *big.Int, maxBlobsPerBlock uint64, logger log.Logger,
 ) (*TxPool, error) {
+	if cfg.Policy == nil {
+		cfg.Policy = DefaultPolicy{}
+	}
 	localsHistory, err := simplelru.NewLRU[string, struct{}](10_000, nil)
 	if err != nil {
 		return nil, err
@@ -253,12 +260,14 @@ func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config,
 		lock:                    &sync.Mutex{},
 		byHash:                  map[string]*metaTx{},
 		isLocalLRU:              localsHistory,
+		privateTxs:              map[string]uint64{},
 		discardReasonsLRU:       discardHistory,
+		discards:                newDiscardJournal(cfg.DiscardJournalSize),
 		all:                     byNonce,
 		recentlyConnectedPeers:  &recentlyConnectedPeers{},
-		pending:                 NewPendingSubPool(PendingSubPool, cfg.PendingSubPoolLimit),
-		baseFee:                 NewSubPool(BaseFeeSubPool, cfg.BaseFeeSubPoolLimit),
-		queued:                  NewSubPool(QueuedSubPool, cfg.QueuedSubPoolLimit),
+		pending:                 NewPendingSubPool(PendingSubPool, cfg.PendingSubPoolLimit, cfg.Policy),
+		baseFee:                 NewSubPool(BaseFeeSubPool, cfg.BaseFeeSubPoolLimit, cfg.Policy),
+		queued:                  NewSubPool(QueuedSubPool, cfg.QueuedSubPoolLimit, cfg.Policy),
 		newPendingTxs:           newTxs,
 		_stateCache:             cache,
 		senders:                 newSendersCache(tracedSenders),
@@ -269,6 +278,7 @@ func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config,
 		unprocessedRemoteByHash: map[string]int{},
 		minedBlobTxsByBlock:     map[uint64][]*metaTx{},
 		minedBlobTxsByHash:      map[string]*metaTx{},
+		blobTxsByVersionedHash:  map[common.Hash][]*metaTx{},
 		maxBlobsPerBlock:        maxBlobsPerBlock,
 		logger:                  logger,
 	}
@@ -386,6 +396,8 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 	if err != nil {
 		return err
 	}
+	p.dropExpiredPrivateLocked(p.lastSeenBlock.Load())
+	p.bundles.dropUpTo(p.lastSeenBlock.Load())
 	p.pending.EnforceWorstInvariants()
 	p.baseFee.EnforceInvariants()
 	p.queued.EnforceInvariants()
@@ -482,9 +494,15 @@ func (p *TxPool) getRlpLocked(tx kv.Tx, hash []byte) (rlpTxn []byte, sender comm
 	}
 	return v[20:], *(*[20]byte)(v[:20]), txn != nil && txn.subPool&IsLocal > 0, nil
 }
+
+// GetRlp - rlp of the transaction for peers and RPC. Private transactions (see AddPrivateTxs) are not returned:
+// they are only for our own block builder
 func (p *TxPool) GetRlp(tx kv.Tx, hash []byte) ([]byte, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
+	if _, ok := p.privateTxs[string(hash)]; ok {
+		return nil, nil
+	}
 	rlpTx, _, _, err := p.getRlpLocked(tx, hash)
 	return common.Copy(rlpTx), err
 }
@@ -495,6 +513,9 @@ func (p *TxPool) AppendLocalAnnouncements(types []byte, sizes []uint32, hashes [
 		if txn.subPool&IsLocal == 0 {
 			continue
 		}
+		if _, ok := p.privateTxs[hash]; ok {
+			continue
+		}
 		types = append(types, txn.Tx.Type)
 		sizes = append(sizes, txn.Tx.Size)
 		hashes = append(hashes, hash...)
@@ -569,6 +590,79 @@ func (p *TxPool) getUnprocessedTxn(hashS string) (*types.TxSlot, bool) {
 	return nil, false
 }
 
+// GetBlobs returns the blobs, KZG commitments and proofs of a pooled or recently mined blob transaction,
+// nothing is returned if the transaction is unknown or was received without the blobs
+func (p *TxPool) GetBlobs(hash []byte) (blobs [][]byte, commitments []gokzg4844.KZGCommitment, proofs []gokzg4844.KZGProof) {
+	hashS := string(hash)
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var txn *types.TxSlot
+	if mt, ok := p.minedBlobTxsByHash[hashS]; ok && len(mt.Tx.Blobs) > 0 {
+		txn = mt.Tx
+	} else if mt, ok := p.byHash[hashS]; ok {
+		txn = mt.Tx
+	} else if unprocessed, ok := p.getUnprocessedTxn(hashS); ok {
+		txn = unprocessed
+	}
+	if txn == nil || txn.Type != types.BlobTxType {
+		return nil, nil, nil
+	}
+	return txn.Blobs, txn.Commitments, txn.Proofs
+}
+
+// GetBlobsByVersionedHash returns the blobs and proofs of the pooled and recently mined transactions by the versioned
+// hashes of the blobs, the slices are in the order of hashes with nil for the unknown blobs
+func (p *TxPool) GetBlobsByVersionedHash(hashes []common.Hash) (blobs [][]byte, proofs []gokzg4844.KZGProof) {
+	blobs, proofs = make([][]byte, len(hashes)), make([]gokzg4844.KZGProof, len(hashes))
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i, hash := range hashes {
+		txs := p.blobTxsByVersionedHash[hash]
+		if len(txs) == 0 {
+			continue
+		}
+		for j, blobHash := range txs[0].Tx.BlobHashes {
+			if blobHash == hash {
+				blobs[i], proofs[i] = txs[0].Tx.Blobs[j], txs[0].Tx.Proofs[j]
+				break
+			}
+		}
+	}
+	return blobs, proofs
+}
+
+// indexBlobsLocked makes the blobs of the transaction available by their versioned hashes,
+// transactions without the blobs are skipped
+func (p *TxPool) indexBlobsLocked(mt *metaTx) {
+	if mt.Tx.Type != types.BlobTxType || len(mt.Tx.Blobs) != len(mt.Tx.BlobHashes) || len(mt.Tx.Proofs) != len(mt.Tx.BlobHashes) {
+		return
+	}
+	for _, hash := range mt.Tx.BlobHashes {
+		p.blobTxsByVersionedHash[hash] = append(p.blobTxsByVersionedHash[hash], mt)
+	}
+}
+
+// unindexBlobsLocked is the reverse of indexBlobsLocked
+func (p *TxPool) unindexBlobsLocked(mt *metaTx) {
+	if mt.Tx.Type != types.BlobTxType {
+		return
+	}
+	for _, hash := range mt.Tx.BlobHashes {
+		txs := p.blobTxsByVersionedHash[hash]
+		for i := range txs {
+			if txs[i] == mt {
+				txs = append(txs[:i], txs[i+1:]...)
+				break
+			}
+		}
+		if len(txs) == 0 {
+			delete(p.blobTxsByVersionedHash, hash)
+		} else {
+			p.blobTxsByVersionedHash[hash] = txs
+		}
+	}
+}
+
 func (p *TxPool) GetKnownBlobTxn(tx kv.Tx, hash []byte) (*metaTx, error) {
 	hashS := string(hash)
 	p.lock.Lock()
@@ -607,6 +701,14 @@ func (p *TxPool) IsLocal(idHash []byte) bool {
 	defer p.lock.Unlock()
 	return p.isLocalLRU.Contains(hashS)
 }
+
+// IsPrivate tells whether the transaction was added by AddPrivateTxs, such transactions must not be announced
+func (p *TxPool) IsPrivate(idHash []byte) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, ok := p.privateTxs[string(idHash)]
+	return ok
+}
 func (p *TxPool) AddNewGoodPeer(peerID types.PeerID) { p.recentlyConnectedPeers.AddPeer(peerID) }
 func (p *TxPool) Started() bool                      { return p.started.Load() }
 
@@ -1003,6 +1105,9 @@ func (p *TxPool) validateTxs(txs *types.TxSlots, stateCache kvcache.CacheView) (
 	goodCount := 0
 	for i, txn := range txs.Txs {
 		reason := p.validateTx(txn, txs.IsLocal[i], stateCache)
+		if reason == txpoolcfg.Success {
+			reason = p.cfg.Policy.Admit(txn, txs.Senders.AddressAt(i), txs.IsLocal[i])
+		}
 		if reason == txpoolcfg.Success {
 			goodCount++
 			// Success here means no DiscardReason yet, so leave it NotSet
@@ -1060,6 +1165,16 @@ func fillDiscardReasons(reasons []txpoolcfg.DiscardReason, newTxs types.TxSlots,
 }
 
 func (p *TxPool) AddLocalTxs(ctx context.Context, newTransactions types.TxSlots, tx kv.Tx) ([]txpoolcfg.DiscardReason, error) {
+	return p.addLocalTxs(ctx, newTransactions, false /* private */, 0 /* maxBlockNumber */, tx)
+}
+
+// AddPrivateTxs adds local transactions which are never announced to peers, so only the own block builder includes them.
+// They are dropped from the pool once the block maxBlockNumber is seen.
+func (p *TxPool) AddPrivateTxs(ctx context.Context, newTransactions types.TxSlots, maxBlockNumber uint64, tx kv.Tx) ([]txpoolcfg.DiscardReason, error) {
+	return p.addLocalTxs(ctx, newTransactions, true /* private */, maxBlockNumber, tx)
+}
+
+func (p *TxPool) addLocalTxs(ctx context.Context, newTransactions types.TxSlots, private bool, maxBlockNumber uint64, tx kv.Tx) ([]txpoolcfg.DiscardReason, error) {
 	coreDb, cache := p.coreDBWithCache()
 	coreTx, err := coreDb.BeginRo(ctx)
 	if err != nil {
@@ -1084,6 +1199,10 @@ func (p *TxPool) AddLocalTxs(ctx context.Context, newTransactions types.TxSlots,
 		}
 	}
 
+	if private && maxBlockNumber <= p.lastSeenBlock.Load() {
+		return nil, fmt.Errorf("max block number %d of private transactions is not after the last seen block %d", maxBlockNumber, p.lastSeenBlock.Load())
+	}
+
 	if err = p.senders.registerNewSenders(&newTransactions, p.logger); err != nil {
 		return nil, err
 	}
@@ -1114,6 +1233,10 @@ func (p *TxPool) AddLocalTxs(ctx context.Context, newTransactions types.TxSlots,
 			if txn.Traced {
 				p.logger.Info(fmt.Sprintf("TX TRACING: AddLocalTxs promotes idHash=%x, senderId=%d", txn.IDHash, txn.SenderID))
 			}
+			if private {
+				// marked before the lock is released, so the announcement below is never propagated
+				p.privateTxs[string(txn.IDHash[:])] = maxBlockNumber
+			}
 			p.promoted.Append(txn.Type, txn.Size, txn.IDHash[:])
 		}
 	}
@@ -1325,7 +1448,7 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) txpoo
 			//already removed
 		}
 
-		p.discardLocked(found, txpoolcfg.ReplacedByHigherTip)
+		p.discardByLocked(found, txpoolcfg.ReplacedByHigherTip, mt)
 	}
 
 	// Don't add blob tx to queued if it's less than current pending blob base fee
@@ -1335,6 +1458,7 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) txpoo
 
 	hashStr := string(mt.Tx.IDHash[:])
 	p.byHash[hashStr] = mt
+	p.indexBlobsLocked(mt)
 
 	if replaced := p.all.replaceOrInsert(mt); replaced != nil {
 		if assert.Enable {
@@ -1352,14 +1476,52 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) txpoo
 	return txpoolcfg.NotSet
 }
 
+// dropExpiredPrivateLocked discards the private transactions which can't be included after the block anymore
+func (p *TxPool) dropExpiredPrivateLocked(blockNum uint64) {
+	for hashStr, maxBlockNumber := range p.privateTxs {
+		if maxBlockNumber > blockNum {
+			continue
+		}
+		mt, ok := p.byHash[hashStr]
+		if !ok { // mined
+			delete(p.privateTxs, hashStr)
+			continue
+		}
+		switch mt.currentSubPool {
+		case PendingSubPool:
+			p.pending.Remove(mt)
+		case BaseFeeSubPool:
+			p.baseFee.Remove(mt)
+		case QueuedSubPool:
+			p.queued.Remove(mt)
+		}
+		p.discardLocked(mt, txpoolcfg.PrivateTxExpired)
+	}
+}
+
 // dropping transaction from all sub-structures and from db
 // Important: don't call it while iterating by all
 func (p *TxPool) discardLocked(mt *metaTx, reason txpoolcfg.DiscardReason) {
+	p.discardByLocked(mt, reason, nil)
+}
+
+// discardByLocked is discardLocked which also records the replacing transaction, if any, in the discard journal
+func (p *TxPool) discardByLocked(mt *metaTx, reason txpoolcfg.DiscardReason, replacedBy *metaTx) {
 	hashStr := string(mt.Tx.IDHash[:])
 	delete(p.byHash, hashStr)
+	p.unindexBlobsLocked(mt)
 	p.deletedTxs = append(p.deletedTxs, mt)
 	p.all.delete(mt)
 	p.discardReasonsLRU.Add(hashStr, reason)
+	if reason == txpoolcfg.Mined { // receipts tell where the mined ones went
+		return // private ones stay private until their max block number, in case they are unwound
+	}
+	delete(p.privateTxs, hashStr)
+	entry := DiscardEntry{Hash: mt.Tx.IDHash, Sender: p.senders.senderID2Addr[mt.Tx.SenderID], Nonce: mt.Tx.Nonce, Reason: reason, Time: uint64(time.Now().Unix())}
+	if replacedBy != nil {
+		entry.ReplacedBy = replacedBy.Tx.IDHash
+	}
+	p.discards.add(entry)
 }
 
 // Cache recently mined blobs in anticipation of reorg, delete finalized ones
@@ -1370,6 +1532,7 @@ func (p *TxPool) processMinedFinalizedBlobs(coreTx kv.Tx, minedTxs []*types.TxSl
 		// delete individual hashes
 		for _, mt := range p.minedBlobTxsByBlock[finalizedBlock] {
 			delete(p.minedBlobTxsByHash, string(mt.Tx.IDHash[:]))
+			p.unindexBlobsLocked(mt)
 		}
 		// delete the map entry for this block num
 		delete(p.minedBlobTxsByBlock, finalizedBlock)
@@ -1379,13 +1542,23 @@ func (p *TxPool) processMinedFinalizedBlobs(coreTx kv.Tx, minedTxs []*types.TxSl
 
 	// Add mined blobs
 	minedBlock := p.lastSeenBlock.Load()
+	for _, mt := range p.minedBlobTxsByBlock[minedBlock] { // the block is replaced
+		delete(p.minedBlobTxsByHash, string(mt.Tx.IDHash[:]))
+		p.unindexBlobsLocked(mt)
+	}
 	p.minedBlobTxsByBlock[minedBlock] = make([]*metaTx, 0)
 	for _, txn := range minedTxs {
 		if txn.Type == types.BlobTxType {
+			p.deleteMinedBlobTxn(string(txn.IDHash[:]))
+			// blocks don't carry the blobs, take them from the pooled version of the transaction
+			if pooled, ok := p.byHash[string(txn.IDHash[:])]; ok && len(txn.Blobs) == 0 {
+				txn.Blobs, txn.Commitments, txn.Proofs = pooled.Tx.Blobs, pooled.Tx.Commitments, pooled.Tx.Proofs
+			}
 			mt := &metaTx{Tx: txn, minedBlockNum: minedBlock}
 			p.minedBlobTxsByBlock[minedBlock] = append(p.minedBlobTxsByBlock[minedBlock], mt)
 			mt.bestIndex = len(p.minedBlobTxsByBlock[minedBlock]) - 1
 			p.minedBlobTxsByHash[string(txn.IDHash[:])] = mt
+			p.indexBlobsLocked(mt)
 		}
 	}
 	return nil
@@ -1400,9 +1573,11 @@ func (p *TxPool) deleteMinedBlobTxn(hash string) {
 	l := len(p.minedBlobTxsByBlock[mt.minedBlockNum])
 	if l > 1 {
 		p.minedBlobTxsByBlock[mt.minedBlockNum][mt.bestIndex] = p.minedBlobTxsByBlock[mt.minedBlockNum][l-1]
+		p.minedBlobTxsByBlock[mt.minedBlockNum][mt.bestIndex].bestIndex = mt.bestIndex
 	}
 	p.minedBlobTxsByBlock[mt.minedBlockNum] = p.minedBlobTxsByBlock[mt.minedBlockNum][:l-1]
 	delete(p.minedBlobTxsByHash, hash)
+	p.unindexBlobsLocked(mt)
 }
 
 func (p *TxPool) NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool) {
@@ -1738,7 +1913,8 @@ func MainLoop(ctx context.Context, db kv.RwDB, coreDB kv.RoDB, p *TxPool, newTxs
 							continue
 						}
 
-						// Empty rlp can happen if a transaction we want to broadcast has just been mined, for example
+						// Empty rlp can happen if a transaction we want to broadcast has just been mined, for example,
+						// or if it's private - such transactions are never announced
 						slotsRlp = append(slotsRlp, slotRlp)
 						if p.IsLocal(hash) {
 							localTxTypes = append(localTxTypes, t)
@@ -1867,6 +2043,9 @@ func (p *TxPool) flushLocked(tx kv.RwTx) (err error) {
 		}
 		p.deletedTxs[i] = nil // for gc
 	}
+	if err := p.discards.flush(tx); err != nil {
+		return err
+	}
 
 	txHashes := p.isLocalLRU.Keys()
 	encID := make([]byte, 8)
@@ -1880,6 +2059,16 @@ func (p *TxPool) flushLocked(tx kv.RwTx) (err error) {
 		}
 	}
 
+	if err := tx.ClearBucket(kv.PoolPrivateTransaction); err != nil {
+		return err
+	}
+	for txHash, maxBlockNumber := range p.privateTxs {
+		binary.BigEndian.PutUint64(encID, maxBlockNumber)
+		if err := tx.Put(kv.PoolPrivateTransaction, []byte(txHash), encID); err != nil {
+			return err
+		}
+	}
+
 	v := make([]byte, 0, 1024)
 	for txHash, metaTx := range p.byHash {
 		if metaTx.Tx.Rlp == nil {
@@ -1956,6 +2145,15 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.Tx) error {
 		}
 		p.isLocalLRU.Add(string(v), struct{}{})
 	}
+	if err := p.discards.fromDB(tx); err != nil {
+		return err
+	}
+	if err := tx.ForEach(kv.PoolPrivateTransaction, nil, func(k, v []byte) error {
+		p.privateTxs[string(k)] = binary.BigEndian.Uint64(v)
+		return nil
+	}); err != nil {
+		return err
+	}
 
 	txs := types.TxSlots{}
 	parseCtx := types.NewTxParseContext(p.chainID)
@@ -2120,24 +2318,19 @@ func (p *TxPool) logStats() {
 	queuedSubCounter.SetInt(p.queued.Len())
 }
 
-// Deprecated need switch to streaming-like
+// Deprecated need switch to streaming-like. Private transactions (see AddPrivateTxs) are skipped
 func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	p.all.ascendAll(func(mt *metaTx) bool {
 		slot := mt.Tx
-		slotRlp := slot.Rlp
-		if slot.Rlp == nil {
-			v, err := tx.GetOne(kv.PoolTransaction, slot.IDHash[:])
-			if err != nil {
-				p.logger.Warn("[txpool] foreach: get tx from db", "err", err)
-				return true
-			}
-			if v == nil {
-				p.logger.Warn("[txpool] foreach: tx not found in db")
-				return true
-			}
-			slotRlp = v[20:]
+		if _, ok := p.privateTxs[string(slot.IDHash[:])]; ok {
+			return true
+		}
+		slotRlp, err := p.slotRlp(slot, tx)
+		if err != nil {
+			p.logger.Warn("[txpool] foreach", "err", err)
+			return true
 		}
 		if sender, found := p.senders.senderID2Addr[slot.SenderID]; found {
 			f(slotRlp, sender, mt.currentSubPool)
@@ -2146,6 +2339,143 @@ func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender
 	})
 }
 
+// slotRlp returns the RLP of the transaction, reading it from the db if it was already flushed
+func (p *TxPool) slotRlp(slot *types.TxSlot, tx kv.Tx) ([]byte, error) {
+	if slot.Rlp != nil {
+		return slot.Rlp, nil
+	}
+	v, err := tx.GetOne(kv.PoolTransaction, slot.IDHash[:])
+	if err != nil {
+		return nil, fmt.Errorf("get tx from db: %w", err)
+	}
+	if v == nil {
+		return nil, fmt.Errorf("tx %x not found in db", slot.IDHash)
+	}
+	return v[20:], nil
+}
+
+// ContentFrom calls f for every transaction of the sender, ordered by nonce, except the private ones
+func (p *TxPool) ContentFrom(sender common.Address, f func(rlp []byte, t SubPoolType), tx kv.Tx) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	senderID, ok := p.senders.getID(sender)
+	if !ok {
+		return nil
+	}
+	var err error
+	p.all.ascend(senderID, func(mt *metaTx) bool {
+		if _, ok := p.privateTxs[string(mt.Tx.IDHash[:])]; ok {
+			return true
+		}
+		var slotRlp []byte
+		if slotRlp, err = p.slotRlp(mt.Tx, tx); err != nil {
+			return false
+		}
+		f(slotRlp, mt.currentSubPool)
+		return true
+	})
+	return err
+}
+
+// SubPoolContent calls f for the transactions of the sub-pool ordered by sender and nonce, skipping the first offset
+// of them and stopping after limit of them, unless limit is 0. Senders are ordered by the time they were first seen,
+// so the pages stay consistent while the pool changes, apart from the transactions added or removed in between.
+// Private transactions (see AddPrivateTxs) are neither returned nor counted. Returns the number of the transactions in the sub-pool.
+func (p *TxPool) SubPoolContent(t SubPoolType, offset, limit int, f func(rlp []byte, sender common.Address, isLocal bool), tx kv.Tx) (total int, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.all.ascendAll(func(mt *metaTx) bool {
+		if mt.currentSubPool != t {
+			return true
+		}
+		if _, ok := p.privateTxs[string(mt.Tx.IDHash[:])]; ok {
+			return true
+		}
+		total++
+		if total <= offset || (limit > 0 && total > offset+limit) {
+			return true
+		}
+		sender, found := p.senders.senderID2Addr[mt.Tx.SenderID]
+		if !found {
+			return true
+		}
+		var slotRlp []byte
+		if slotRlp, err = p.slotRlp(mt.Tx, tx); err != nil {
+			return false
+		}
+		f(slotRlp, sender, mt.subPool&IsLocal != 0)
+		return true
+	})
+	return total, err
+}
+
+// ExportContent calls f for the transactions of all sub-pools, private ones too, ordered by sender id and nonce starting
+// at (fromSenderID, fromNonce), and stops after limit of them, unless limit is 0. Returns where the next page starts:
+// keyed pages don't skip or repeat the transactions moving between sub-pools in between.
+// maxBlockNumber is set only for private transactions (see AddPrivateTxs).
+func (p *TxPool) ExportContent(fromSenderID, fromNonce uint64, limit int, f func(rlp []byte, sender common.Address, t SubPoolType, isLocal, isPrivate bool, maxBlockNumber uint64), tx kv.Tx) (nextSenderID, nextNonce uint64, more bool, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	count := 0
+	p.all.ascendFrom(fromSenderID, fromNonce, func(mt *metaTx) bool {
+		if limit > 0 && count == limit {
+			nextSenderID, nextNonce, more = mt.Tx.SenderID, mt.Tx.Nonce, true
+			return false
+		}
+		sender, found := p.senders.senderID2Addr[mt.Tx.SenderID]
+		if !found {
+			return true
+		}
+		var slotRlp []byte
+		if slotRlp, err = p.slotRlp(mt.Tx, tx); err != nil {
+			return false
+		}
+		maxBlockNumber, isPrivate := p.privateTxs[string(mt.Tx.IDHash[:])]
+		f(slotRlp, sender, mt.currentSubPool, mt.subPool&IsLocal != 0, isPrivate, maxBlockNumber)
+		count++
+		return true
+	})
+	return nextSenderID, nextNonce, more, err
+}
+
+// Inspect calls f for every transaction of the pool except the private ones, ordered by sender and nonce, without
+// loading their RLP
+func (p *TxPool) Inspect(f func(slot *types.TxSlot, sender common.Address, t SubPoolType)) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.all.ascendAll(func(mt *metaTx) bool {
+		if _, ok := p.privateTxs[string(mt.Tx.IDHash[:])]; ok {
+			return true
+		}
+		if sender, found := p.senders.senderID2Addr[mt.Tx.SenderID]; found {
+			f(mt.Tx, sender, mt.currentSubPool)
+		}
+		return true
+	})
+}
+
+// TxDiscards returns the journaled discards of the transaction, oldest first, and its sub-pool if it's in the pool
+func (p *TxPool) TxDiscards(hash common.Hash) (discards []DiscardEntry, t SubPoolType, inPool bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if mt, ok := p.byHash[string(hash[:])]; ok {
+		t, inPool = mt.currentSubPool, true
+	}
+	return p.discards.byHash(hash), t, inPool
+}
+
+// SenderDiscards returns the journaled discards of the transactions of the sender, oldest first
+func (p *TxPool) SenderDiscards(sender common.Address) []DiscardEntry {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.discards.bySender(sender)
+}
+
+// SubscribeDiscards returns a channel receiving the discards as they are journaled and the function to unsubscribe
+func (p *TxPool) SubscribeDiscards() (<-chan DiscardEntry, func()) {
+	return p.discards.subscribe()
+}
+
 var PoolChainConfigKey = []byte("chain_config")
 var PoolLastSeenBlockKey = []byte("last_seen_block")
 var PoolPendingBaseFeeKey = []byte("pending_base_fee")
@@ -2301,6 +2631,12 @@ func (b *BySenderAndNonce) ascend(senderID uint64, f func(*metaTx) bool) {
 		return f(mt)
 	})
 }
+func (b *BySenderAndNonce) ascendFrom(senderID, nonce uint64, f func(*metaTx) bool) {
+	s := b.search
+	s.Tx.SenderID = senderID
+	s.Tx.Nonce = nonce
+	b.tree.AscendGreaterOrEqual(s, f)
+}
 func (b *BySenderAndNonce) descend(senderID uint64, f func(*metaTx) bool) {
 	s := b.search
 	s.Tx.SenderID = senderID
@@ -2383,8 +2719,8 @@ type PendingPool struct {
 	t     SubPoolType
 }
 
-func NewPendingSubPool(t SubPoolType, limit int) *PendingPool {
-	return &PendingPool{limit: limit, t: t, best: &bestSlice{ms: []*metaTx{}}, worst: &WorstQueue{ms: []*metaTx{}}}
+func NewPendingSubPool(t SubPoolType, limit int, policy txpoolcfg.Policy) *PendingPool {
+	return &PendingPool{limit: limit, t: t, best: &bestSlice{ms: []*metaTx{}, policy: policy}, worst: &WorstQueue{ms: []*metaTx{}, policy: policy}}
 }
 
 // bestSlice - is similar to best queue, but uses a linear structure with O(n log n) sort complexity and
@@ -2392,6 +2728,7 @@ func NewPendingSubPool(t SubPoolType, limit int) *PendingPool {
 type bestSlice struct {
 	ms             []*metaTx
 	pendingBaseFee uint64
+	policy         txpoolcfg.Policy
 }
 
 func (s *bestSlice) Len() int { return len(s.ms) }
@@ -2400,7 +2737,7 @@ func (s *bestSlice) Swap(i, j int) {
 	s.ms[i].bestIndex, s.ms[j].bestIndex = i, j
 }
 func (s *bestSlice) Less(i, j int) bool {
-	return s.ms[i].better(s.ms[j], *uint256.NewInt(s.pendingBaseFee))
+	return s.policy.Better(s.ms[i], s.ms[j], s.pendingBaseFee)
 }
 func (s *bestSlice) UnsafeRemove(i *metaTx) {
 	s.Swap(i.bestIndex, len(s.ms)-1)
@@ -2478,8 +2815,8 @@ type SubPool struct {
 	t     SubPoolType
 }
 
-func NewSubPool(t SubPoolType, limit int) *SubPool {
-	return &SubPool{limit: limit, t: t, best: &BestQueue{}, worst: &WorstQueue{}}
+func NewSubPool(t SubPoolType, limit int, policy txpoolcfg.Policy) *SubPool {
+	return &SubPool{limit: limit, t: t, best: &BestQueue{policy: policy}, worst: &WorstQueue{policy: policy}}
 }
 
 func (p *SubPool) EnforceInvariants() {
@@ -2541,6 +2878,7 @@ func (p *SubPool) DebugPrint(prefix string) {
 type BestQueue struct {
 	ms             []*metaTx
 	pendingBastFee uint64
+	policy         txpoolcfg.Policy
 }
 
 // Returns true if the txn "mt" is better than the parameter txn "than"
@@ -2648,7 +2986,7 @@ func (mt *metaTx) worse(than *metaTx, pendingBaseFee uint256.Int) bool {
 
 func (p BestQueue) Len() int { return len(p.ms) }
 func (p BestQueue) Less(i, j int) bool {
-	return p.ms[i].better(p.ms[j], *uint256.NewInt(p.pendingBastFee))
+	return p.policy.Better(p.ms[i], p.ms[j], p.pendingBastFee)
 }
 func (p BestQueue) Swap(i, j int) {
 	p.ms[i], p.ms[j] = p.ms[j], p.ms[i]
@@ -2676,11 +3014,12 @@ func (p *BestQueue) Pop() interface{} {
 type WorstQueue struct {
 	ms             []*metaTx
 	pendingBaseFee uint64
+	policy         txpoolcfg.Policy
 }
 
 func (p WorstQueue) Len() int { return len(p.ms) }
 func (p WorstQueue) Less(i, j int) bool {
-	return p.ms[i].worse(p.ms[j], *uint256.NewInt(p.pendingBaseFee))
+	return p.policy.Worse(p.ms[i], p.ms[j], p.pendingBaseFee)
 }
 func (p WorstQueue) Swap(i, j int) {
 	p.ms[i], p.ms[j] = p.ms[j], p.ms[i]
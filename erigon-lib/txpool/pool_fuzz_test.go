@@ -47,7 +47,7 @@ func init() {
 			t.Parallel()
 			assert := assert.New(t)
 			{
-				sub := NewPendingSubPool(PendingSubPool, 1024)
+				sub := NewPendingSubPool(PendingSubPool, 1024, DefaultPolicy{})
 				for _, i := range in {
 					sub.Add(&metaTx{subPool: SubPoolMarker(i & 0b1111), Tx: &TxSlot{nonce: 1, value: *uint256.NewInt(1)}})
 				}
@@ -68,7 +68,7 @@ func init() {
 				}
 			}
 			{
-				sub := NewSubPool(BaseFeeSubPool, 1024)
+				sub := NewSubPool(BaseFeeSubPool, 1024, DefaultPolicy{})
 				for _, i := range in {
 					sub.Add(&metaTx{subPool: SubPoolMarker(i & 0b1111), Tx: &TxSlot{nonce: 1, value: *uint256.NewInt(1)}})
 				}
@@ -101,7 +101,7 @@ func init() {
 			}
 
 			{
-				sub := NewSubPool(QueuedSubPool, 1024)
+				sub := NewSubPool(QueuedSubPool, 1024, DefaultPolicy{})
 				for _, i := range in {
 					sub.Add(&metaTx{subPool: SubPoolMarker(i & 0b1111), Tx: &TxSlot{nonce: 1, value: *uint256.NewInt(1)}})
 				}
@@ -873,6 +873,85 @@ func TestBlobTxReplacement(t *testing.T) {
 	}
 }
 
+func TestGetBlobsOfMinedTx(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan types.Announcements, 5)
+	db, coreDB := memdb.NewTestPoolDB(t), memdb.NewTestDB(t)
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, txpoolcfg.DefaultConfig, sendersCache, *u256.N1, common.Big0, nil, common.Big0, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	assert.NoError(err)
+	require.True(pool != nil)
+	ctx := context.Background()
+
+	var addr [20]byte
+	addr[0] = 1
+	v := make([]byte, types.EncodeSenderLengthForStorage(2, *uint256.NewInt(1 * common.Ether)))
+	types.EncodeSender(2, *uint256.NewInt(1 * common.Ether), v)
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee:  200_000,
+		BlockGasLimit:        1000000,
+		PendingBlobFeePerGas: 100_000,
+		ChangeBatch: []*remote.StateChange{{
+			BlockHeight: 0,
+			BlockHash:   gointerfaces.ConvertHashToH256([32]byte{}),
+			Changes: []*remote.AccountChange{{
+				Action:  remote.Action_UPSERT,
+				Address: gointerfaces.ConvertAddressToH160(addr),
+				Data:    v,
+			}},
+		}},
+	}
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, tx))
+
+	blobTxn := makeBlobTx()
+	blobTxn.Nonce = 0x2
+	txSlots := types.TxSlots{}
+	txSlots.Append(&blobTxn, addr[:], true)
+	reasons, err := pool.AddLocalTxs(ctx, txSlots, tx)
+	require.NoError(err)
+	require.Equal(txpoolcfg.Success, reasons[0], reasons[0].String())
+
+	blobs, commitments, proofs := pool.GetBlobs(blobTxn.IDHash[:])
+	require.Len(blobs, 2)
+	require.Equal(blobTxn.Commitments, commitments)
+	require.Equal(blobTxn.Proofs, proofs)
+	blobs, _ = pool.GetBlobsByVersionedHash([]common.Hash{blobTxn.BlobHashes[0]})
+	require.Equal([][]byte{blobTxn.Blobs[0]}, blobs)
+
+	// the mined version of the transaction comes from the block, without the blobs
+	minedTxn := blobTxn
+	minedTxn.Blobs, minedTxn.Commitments, minedTxn.Proofs = nil, nil, nil
+	minedTxs := types.TxSlots{}
+	minedTxs.Append(&minedTxn, addr[:], true)
+	change.ChangeBatch[0].BlockHeight = 1
+	change.ChangeBatch[0].Changes = nil
+	require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, minedTxs, tx))
+
+	blobs, commitments, proofs = pool.GetBlobs(blobTxn.IDHash[:])
+	require.Equal(blobTxn.Blobs, blobs)
+	require.Equal(blobTxn.Commitments, commitments)
+	require.Equal(blobTxn.Proofs, proofs)
+
+	blobs, _, _ = pool.GetBlobs(make([]byte, 32))
+	require.Empty(blobs)
+
+	blobs, proofs = pool.GetBlobsByVersionedHash([]common.Hash{blobTxn.BlobHashes[1], {}, blobTxn.BlobHashes[0]})
+	require.Equal([][]byte{blobTxn.Blobs[1], nil, blobTxn.Blobs[0]}, blobs)
+	require.Equal(blobTxn.Proofs[1], proofs[0])
+	require.Equal(blobTxn.Proofs[0], proofs[2])
+
+	// the blobs of finalized transactions are dropped
+	change.ChangeBatch[0].BlockHeight = 2
+	change.FinalizedBlock = 1
+	require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, tx))
+	blobs, _ = pool.GetBlobsByVersionedHash([]common.Hash{blobTxn.BlobHashes[0]})
+	require.Equal([][]byte{nil}, blobs)
+	require.Empty(pool.blobTxsByVersionedHash)
+}
+
 // Todo, make the tx more realistic with good values
 func makeBlobTx() types.TxSlot {
 	// Some arbitrary hardcoded example
@@ -1043,3 +1122,163 @@ func TestDropRemoteAtNoGossip(t *testing.T) {
 	// no announcement because unprocessedRemoteTxs is already empty
 	assert.True(checkAnnouncementEmpty())
 }
+
+func TestPrivateTxs(t *testing.T) {
+	require := require.New(t)
+	ch := make(chan types.Announcements, 100)
+	db, coreDB := memdb.NewTestPoolDB(t), memdb.NewTestDB(t)
+
+	pool, err := New(ch, coreDB, txpoolcfg.DefaultConfig, kvcache.New(kvcache.DefaultCoherentConfig), *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	require.NoError(err)
+	ctx := context.Background()
+	var addr [20]byte
+	addr[0] = 1
+	v := make([]byte, types.EncodeSenderLengthForStorage(3, *uint256.NewInt(1 * common.Ether)))
+	types.EncodeSender(3, *uint256.NewInt(1 * common.Ether), v)
+	newBlock := func(height uint64) *remote.StateChangeBatch {
+		return &remote.StateChangeBatch{
+			StateVersionId:      height,
+			PendingBlockBaseFee: 200000,
+			BlockGasLimit:       1000000,
+			ChangeBatch: []*remote.StateChange{{
+				BlockHeight: height,
+				BlockHash:   gointerfaces.ConvertHashToH256([32]byte{byte(height)}),
+				Changes: []*remote.AccountChange{{
+					Action:  remote.Action_UPSERT,
+					Address: gointerfaces.ConvertAddressToH160(addr),
+					Data:    v,
+				}},
+			}},
+		}
+	}
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	require.NoError(pool.OnNewBlock(ctx, newBlock(0), types.TxSlots{}, types.TxSlots{}, tx))
+
+	var txSlots types.TxSlots
+	txSlot := &types.TxSlot{Tip: *uint256.NewInt(300000), FeeCap: *uint256.NewInt(300000), Gas: 100000, Nonce: 3}
+	txSlot.IDHash[0] = 1
+	txSlot.Rlp = []byte{1}
+	txSlots.Append(txSlot, addr[:], true)
+
+	_, err = pool.AddPrivateTxs(ctx, txSlots, 0, tx)
+	require.Error(err) // the deadline already passed
+	reasons, err := pool.AddPrivateTxs(ctx, txSlots, 2, tx)
+	require.NoError(err)
+	require.Equal(txpoolcfg.Success, reasons[0], reasons[0].String())
+	require.True(pool.IsPrivate(txSlot.IDHash[:]))
+	_, _, hashes := pool.AppendAllAnnouncements(nil, nil, nil)
+	require.Empty(hashes)
+	rlp, err := pool.GetRlp(tx, txSlot.IDHash[:]) // not served to peers
+	require.NoError(err)
+	require.Nil(rlp)
+	// nor listed by txpool_content, txpool_contentFrom and txpool_inspect
+	listed := 0
+	pool.deprecatedForEach(ctx, func([]byte, common.Address, SubPoolType) { listed++ }, tx)
+	require.NoError(pool.ContentFrom(addr, func([]byte, SubPoolType) { listed++ }, tx))
+	pool.Inspect(func(*types.TxSlot, common.Address, SubPoolType) { listed++ })
+	for _, t := range []SubPoolType{PendingSubPool, BaseFeeSubPool, QueuedSubPool} {
+		total, err := pool.SubPoolContent(t, 0, 0, func([]byte, common.Address, bool) { listed++ }, tx)
+		require.NoError(err)
+		require.Zero(total)
+	}
+	require.Zero(listed)
+	exported := 0
+	_, _, _, err = pool.ExportContent(0, 0, 0, func(_ []byte, _ common.Address, _ SubPoolType, _, isPrivate bool, maxBlockNumber uint64) {
+		require.True(isPrivate)
+		require.Equal(uint64(2), maxBlockNumber)
+		exported++
+	}, tx)
+	require.NoError(err)
+	require.Equal(1, exported)
+
+	// it survives the restart
+	require.NoError(pool.flushLocked(tx))
+	restarted, err := New(ch, coreDB, txpoolcfg.DefaultConfig, kvcache.New(kvcache.DefaultCoherentConfig), *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	require.NoError(err)
+	coreTx, err := coreDB.BeginRo(ctx)
+	require.NoError(err)
+	defer coreTx.Rollback()
+	require.NoError(restarted.fromDB(ctx, tx, coreTx))
+	require.True(restarted.IsPrivate(txSlot.IDHash[:]))
+
+	require.NoError(pool.OnNewBlock(ctx, newBlock(1), types.TxSlots{}, types.TxSlots{}, tx))
+	_, _, inPool := pool.TxDiscards(common.Hash(txSlot.IDHash))
+	require.True(inPool)
+
+	require.NoError(pool.OnNewBlock(ctx, newBlock(2), types.TxSlots{}, types.TxSlots{}, tx))
+	discards, _, inPool := pool.TxDiscards(common.Hash(txSlot.IDHash))
+	require.False(inPool)
+	require.Len(discards, 1)
+	require.Equal(txpoolcfg.PrivateTxExpired, discards[0].Reason)
+	require.False(pool.IsPrivate(txSlot.IDHash[:]))
+}
+
+func TestExportContent(t *testing.T) {
+	require := require.New(t)
+	ch := make(chan types.Announcements, 100)
+	db, coreDB := memdb.NewTestPoolDB(t), memdb.NewTestDB(t)
+
+	pool, err := New(ch, coreDB, txpoolcfg.DefaultConfig, kvcache.New(kvcache.DefaultCoherentConfig), *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	require.NoError(err)
+	ctx := context.Background()
+	var addr [20]byte
+	addr[0] = 1
+	v := make([]byte, types.EncodeSenderLengthForStorage(3, *uint256.NewInt(1 * common.Ether)))
+	types.EncodeSender(3, *uint256.NewInt(1 * common.Ether), v)
+	change := &remote.StateChangeBatch{
+		StateVersionId:      0,
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{{
+			BlockHeight: 0,
+			BlockHash:   gointerfaces.ConvertHashToH256([32]byte{}),
+			Changes: []*remote.AccountChange{{
+				Action:  remote.Action_UPSERT,
+				Address: gointerfaces.ConvertAddressToH160(addr),
+				Data:    v,
+			}},
+		}},
+	}
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, tx))
+
+	newTxSlots := func(nonce uint64) (txSlots types.TxSlots) {
+		txSlot := &types.TxSlot{Tip: *uint256.NewInt(300000), FeeCap: *uint256.NewInt(300000), Gas: 100000, Nonce: nonce}
+		txSlot.IDHash[0] = byte(nonce)
+		txSlot.Rlp = []byte{byte(nonce)}
+		txSlots.Append(txSlot, addr[:], true)
+		return txSlots
+	}
+	_, err = pool.AddLocalTxs(ctx, newTxSlots(3), tx)
+	require.NoError(err)
+	_, err = pool.AddPrivateTxs(ctx, newTxSlots(4), 10, tx)
+	require.NoError(err)
+
+	type exported struct {
+		rlp            []byte
+		t              SubPoolType
+		isLocal        bool
+		isPrivate      bool
+		maxBlockNumber uint64
+	}
+	var txs []exported
+	export := func(rlp []byte, sender common.Address, t SubPoolType, isLocal, isPrivate bool, maxBlockNumber uint64) {
+		require.Equal(common.Address(addr), sender)
+		txs = append(txs, exported{common.Copy(rlp), t, isLocal, isPrivate, maxBlockNumber})
+	}
+	senderID, nonce, more, err := pool.ExportContent(0, 0, 1, export, tx)
+	require.NoError(err)
+	require.True(more)
+	require.Equal(uint64(4), nonce)
+	_, _, more, err = pool.ExportContent(senderID, nonce, 1, export, tx)
+	require.NoError(err)
+	require.False(more)
+	require.Equal([]exported{
+		{rlp: []byte{3}, t: PendingSubPool, isLocal: true},
+		{rlp: []byte{4}, t: PendingSubPool, isLocal: true, isPrivate: true, maxBlockNumber: 10},
+	}, txs)
+}
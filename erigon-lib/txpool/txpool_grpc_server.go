@@ -25,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/holiman/uint256"
@@ -47,7 +48,7 @@ import (
 )
 
 // TxPoolAPIVersion
-var TxPoolAPIVersion = &types2.VersionReply{Major: 1, Minor: 0, Patch: 0}
+var TxPoolAPIVersion = &types2.VersionReply{Major: 1, Minor: 4, Patch: 0}
 
 type txPool interface {
 	ValidateSerializedTxn(serializedTxn []byte) error
@@ -55,7 +56,17 @@ type txPool interface {
 	PeekBest(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas, availableBlobGas uint64) (bool, error)
 	GetRlp(tx kv.Tx, hash []byte) ([]byte, error)
 	AddLocalTxs(ctx context.Context, newTxs types.TxSlots, tx kv.Tx) ([]txpoolcfg.DiscardReason, error)
+	AddPrivateTxs(ctx context.Context, newTxs types.TxSlots, maxBlockNumber uint64, tx kv.Tx) ([]txpoolcfg.DiscardReason, error)
+	AddBundle(bundle *types.TxsBundle) error
 	deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx)
+	ContentFrom(sender common.Address, f func(rlp []byte, t SubPoolType), tx kv.Tx) error
+	SubPoolContent(t SubPoolType, offset, limit int, f func(rlp []byte, sender common.Address, isLocal bool), tx kv.Tx) (int, error)
+	ExportContent(fromSenderID, fromNonce uint64, limit int, f func(rlp []byte, sender common.Address, t SubPoolType, isLocal, isPrivate bool, maxBlockNumber uint64), tx kv.Tx) (uint64, uint64, bool, error)
+	Inspect(f func(slot *types.TxSlot, sender common.Address, t SubPoolType))
+	TxDiscards(hash common.Hash) ([]DiscardEntry, SubPoolType, bool)
+	SenderDiscards(sender common.Address) []DiscardEntry
+	SubscribeDiscards() (<-chan DiscardEntry, func())
+	GetBlobsByVersionedHash(hashes []common.Hash) ([][]byte, []gokzg4844.KZGProof)
 	CountContent() (int, int, int)
 	IdHashKnown(tx kv.Tx, hash []byte) (bool, error)
 	NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool)
@@ -97,6 +108,30 @@ func (*GrpcDisabled) Status(ctx context.Context, request *txpool_proto.StatusReq
 func (*GrpcDisabled) Nonce(ctx context.Context, request *txpool_proto.NonceRequest) (*txpool_proto.NonceReply, error) {
 	return nil, ErrPoolDisabled
 }
+func (*GrpcDisabled) ContentFrom(ctx context.Context, request *txpool_proto.ContentFromRequest) (*txpool_proto.ContentReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) Content(ctx context.Context, request *txpool_proto.ContentRequest) (*txpool_proto.ContentReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) Export(ctx context.Context, request *txpool_proto.ExportRequest) (*txpool_proto.ExportReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) Inspect(ctx context.Context, request *txpool_proto.InspectRequest) (*txpool_proto.InspectReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) Discards(ctx context.Context, request *txpool_proto.DiscardsRequest) (*txpool_proto.DiscardsReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) OnDiscard(request *txpool_proto.OnDiscardRequest, server txpool_proto.Txpool_OnDiscardServer) error {
+	return ErrPoolDisabled
+}
+func (*GrpcDisabled) GetBlobs(ctx context.Context, request *txpool_proto.GetBlobsRequest) (*txpool_proto.GetBlobsReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) AddBundle(ctx context.Context, request *txpool_proto.AddBundleRequest) (*txpool_proto.AddBundleReply, error) {
+	return nil, ErrPoolDisabled
+}
 
 type GrpcServer struct {
 	txpool_proto.UnimplementedTxpoolServer
@@ -128,6 +163,20 @@ func convertSubPoolType(t SubPoolType) txpool_proto.AllReply_TxnType {
 		panic("unknown")
 	}
 }
+
+func convertTxnType(t txpool_proto.AllReply_TxnType) (SubPoolType, error) {
+	switch t {
+	case txpool_proto.AllReply_PENDING:
+		return PendingSubPool, nil
+	case txpool_proto.AllReply_BASE_FEE:
+		return BaseFeeSubPool, nil
+	case txpool_proto.AllReply_QUEUED:
+		return QueuedSubPool, nil
+	default:
+		return 0, fmt.Errorf("unknown txn type: %d", t)
+	}
+}
+
 func (s *GrpcServer) All(ctx context.Context, _ *txpool_proto.AllRequest) (*txpool_proto.AllReply, error) {
 	tx, err := s.db.BeginRo(ctx)
 	if err != nil {
@@ -146,6 +195,197 @@ func (s *GrpcServer) All(ctx context.Context, _ *txpool_proto.AllRequest) (*txpo
 	return reply, nil
 }
 
+func (s *GrpcServer) ContentFrom(ctx context.Context, in *txpool_proto.ContentFromRequest) (*txpool_proto.ContentReply, error) {
+	tx, err := s.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	sender := gointerfaces.ConvertH160toAddress(in.Sender)
+	reply := &txpool_proto.ContentReply{}
+	if err := s.txPool.ContentFrom(sender, func(rlp []byte, t SubPoolType) {
+		reply.Txs = append(reply.Txs, &txpool_proto.AllReply_Tx{
+			Sender:  gointerfaces.ConvertAddressToH160(sender),
+			TxnType: convertSubPoolType(t),
+			RlpTx:   common.Copy(rlp),
+		})
+	}, tx); err != nil {
+		return nil, err
+	}
+	reply.Total = uint64(len(reply.Txs))
+	return reply, nil
+}
+
+func (s *GrpcServer) Content(ctx context.Context, in *txpool_proto.ContentRequest) (*txpool_proto.ContentReply, error) {
+	subPool, err := convertTxnType(in.TxnType)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	reply := &txpool_proto.ContentReply{}
+	total, err := s.txPool.SubPoolContent(subPool, int(in.Offset), int(in.Limit), func(rlp []byte, sender common.Address, isLocal bool) {
+		reply.Txs = append(reply.Txs, &txpool_proto.AllReply_Tx{
+			Sender:  gointerfaces.ConvertAddressToH160(sender),
+			TxnType: in.TxnType,
+			RlpTx:   common.Copy(rlp),
+			IsLocal: isLocal,
+		})
+	}, tx)
+	if err != nil {
+		return nil, err
+	}
+	reply.Total = uint64(total)
+	return reply, nil
+}
+
+func (s *GrpcServer) Export(ctx context.Context, in *txpool_proto.ExportRequest) (*txpool_proto.ExportReply, error) {
+	tx, err := s.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	reply := &txpool_proto.ExportReply{}
+	reply.NextSenderId, reply.NextNonce, reply.More, err = s.txPool.ExportContent(in.FromSenderId, in.FromNonce, int(in.Limit), func(rlp []byte, sender common.Address, t SubPoolType, isLocal, isPrivate bool, maxBlockNumber uint64) {
+		reply.Txs = append(reply.Txs, &txpool_proto.AllReply_Tx{
+			Sender:         gointerfaces.ConvertAddressToH160(sender),
+			TxnType:        convertSubPoolType(t),
+			RlpTx:          common.Copy(rlp),
+			IsLocal:        isLocal,
+			IsPrivate:      isPrivate,
+			MaxBlockNumber: maxBlockNumber,
+		})
+	}, tx)
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *GrpcServer) Inspect(ctx context.Context, _ *txpool_proto.InspectRequest) (*txpool_proto.InspectReply, error) {
+	reply := &txpool_proto.InspectReply{}
+	s.txPool.Inspect(func(slot *types.TxSlot, sender common.Address, t SubPoolType) {
+		tx := &txpool_proto.InspectReply_Tx{
+			TxnType: convertSubPoolType(t),
+			Sender:  gointerfaces.ConvertAddressToH160(sender),
+			Nonce:   slot.Nonce,
+			Value:   gointerfaces.ConvertUint256IntToH256(&slot.Value),
+			Gas:     slot.Gas,
+			FeeCap:  gointerfaces.ConvertUint256IntToH256(&slot.FeeCap),
+		}
+		if !slot.Creation {
+			tx.To = gointerfaces.ConvertAddressToH160(slot.To)
+		}
+		reply.Txs = append(reply.Txs, tx)
+	})
+	return reply, nil
+}
+
+func convertDiscardEntry(e *DiscardEntry) *txpool_proto.DiscardEntry {
+	res := &txpool_proto.DiscardEntry{
+		Hash:      gointerfaces.ConvertHashToH256(e.Hash),
+		Sender:    gointerfaces.ConvertAddressToH160(e.Sender),
+		Nonce:     e.Nonce,
+		Reason:    uint32(e.Reason),
+		Timestamp: e.Time,
+	}
+	if e.ReplacedBy != (common.Hash{}) {
+		res.ReplacedBy = gointerfaces.ConvertHashToH256(e.ReplacedBy)
+	}
+	return res
+}
+
+func (s *GrpcServer) Discards(ctx context.Context, in *txpool_proto.DiscardsRequest) (*txpool_proto.DiscardsReply, error) {
+	reply := &txpool_proto.DiscardsReply{}
+	var discards []DiscardEntry
+	switch {
+	case in.Hash != nil:
+		var t SubPoolType
+		var inPool bool
+		discards, t, inPool = s.txPool.TxDiscards(gointerfaces.ConvertH256ToHash(in.Hash))
+		if inPool {
+			txnType := convertSubPoolType(t)
+			reply.TxnType = &txnType
+		}
+	case in.Sender != nil:
+		discards = s.txPool.SenderDiscards(gointerfaces.ConvertH160toAddress(in.Sender))
+	default:
+		return nil, fmt.Errorf("either hash or sender has to be set")
+	}
+	for i := range discards {
+		reply.Discards = append(reply.Discards, convertDiscardEntry(&discards[i]))
+	}
+	return reply, nil
+}
+
+func (s *GrpcServer) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequest) (*txpool_proto.GetBlobsReply, error) {
+	hashes := make([]common.Hash, len(in.BlobHashes))
+	for i := range in.BlobHashes {
+		hashes[i] = gointerfaces.ConvertH256ToHash(in.BlobHashes[i])
+	}
+	blobs, proofs := s.txPool.GetBlobsByVersionedHash(hashes)
+	reply := &txpool_proto.GetBlobsReply{BlobsAndProofs: make([]*txpool_proto.BlobAndProof, len(hashes))}
+	for i := range blobs {
+		reply.BlobsAndProofs[i] = &txpool_proto.BlobAndProof{}
+		if blobs[i] != nil {
+			reply.BlobsAndProofs[i].Blob, reply.BlobsAndProofs[i].Proof = blobs[i], proofs[i][:]
+		}
+	}
+	return reply, nil
+}
+
+func (s *GrpcServer) AddBundle(ctx context.Context, in *txpool_proto.AddBundleRequest) (*txpool_proto.AddBundleReply, error) {
+	parseCtx := types.NewTxParseContext(s.chainID).ChainIDRequired()
+	parseCtx.ValidateRLP(s.txPool.ValidateSerializedTxn)
+
+	bundle := &types.TxsBundle{
+		Txs:          in.RlpTxs,
+		BlockNumber:  in.BlockNumber,
+		MinTimestamp: in.MinTimestamp,
+		MaxTimestamp: in.MaxTimestamp,
+	}
+	hashes := make([]byte, 0, len(in.RlpTxs)*32)
+	for i := range in.RlpTxs {
+		slot, sender := &types.TxSlot{}, make([]byte, 20)
+		if _, err := parseCtx.ParseTransaction(in.RlpTxs[i], 0, slot, sender, false /* hasEnvelope */, true /* wrappedWithBlobs */, nil); err != nil {
+			return nil, fmt.Errorf("bundle transaction %d: %w", i, err)
+		}
+		hashes = append(hashes, slot.IDHash[:]...)
+	}
+	for _, h := range in.RevertingTxHashes {
+		bundle.RevertingTxHashes = append(bundle.RevertingTxHashes, gointerfaces.ConvertH256ToHash(h))
+	}
+	bundleHash, err := common.HashData(hashes)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Hash = bundleHash
+	if err := s.txPool.AddBundle(bundle); err != nil {
+		return nil, err
+	}
+	return &txpool_proto.AddBundleReply{BundleHash: gointerfaces.ConvertHashToH256(bundle.Hash)}, nil
+}
+
+func (s *GrpcServer) OnDiscard(_ *txpool_proto.OnDiscardRequest, stream txpool_proto.Txpool_OnDiscardServer) error {
+	discards, unsubscribe := s.txPool.SubscribeDiscards()
+	defer unsubscribe()
+	for {
+		select {
+		case e := <-discards:
+			if err := stream.Send(&txpool_proto.DiscardsReply{Discards: []*txpool_proto.DiscardEntry{convertDiscardEntry(&e)}}); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+}
+
 func (s *GrpcServer) Pending(ctx context.Context, _ *emptypb.Empty) (*txpool_proto.PendingReply, error) {
 	tx, err := s.db.BeginRo(ctx)
 	if err != nil {
@@ -191,7 +431,7 @@ func (s *GrpcServer) Add(ctx context.Context, in *txpool_proto.AddRequest) (*txp
 	for i := 0; i < len(in.RlpTxs); i++ { // some incoming txs may be rejected, so - need second index
 		slots.Resize(uint(j + 1))
 		slots.Txs[j] = &types.TxSlot{}
-		slots.IsLocal[j] = true
+		slots.IsLocal[j] = !in.Remote || in.Private
 		if _, err := parseCtx.ParseTransaction(in.RlpTxs[i], 0, slots.Txs[j], slots.Senders.At(j), false /* hasEnvelope */, true /* wrappedWithBlobs */, func(hash []byte) error {
 			if known, _ := s.txPool.IdHashKnown(tx, hash); known {
 				return types.ErrAlreadyKnown
@@ -213,7 +453,12 @@ func (s *GrpcServer) Add(ctx context.Context, in *txpool_proto.AddRequest) (*txp
 		j++
 	}
 
-	discardReasons, err := s.txPool.AddLocalTxs(ctx, slots, tx)
+	var discardReasons []txpoolcfg.DiscardReason
+	if in.Private {
+		discardReasons, err = s.txPool.AddPrivateTxs(ctx, slots, in.MaxBlockNumber, tx)
+	} else {
+		discardReasons, err = s.txPool.AddLocalTxs(ctx, slots, tx)
+	}
 	if err != nil {
 		return nil, err
 	}
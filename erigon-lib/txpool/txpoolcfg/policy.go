@@ -0,0 +1,54 @@
+/*
+   Copyright 2024 The Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpoolcfg
+
+import (
+	"github.com/holiman/uint256"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/types"
+)
+
+// PoolTx is the view of a pooled transaction given to a Policy
+type PoolTx interface {
+	TxSlot() *types.TxSlot
+	// SubPoolMarker is the bitmap of the sub-pool conditions the transaction meets, the higher the better
+	SubPoolMarker() uint8
+	// CurrentSubPool is the txpool.SubPoolType of the sub-pool holding the transaction
+	CurrentSubPool() uint8
+	// NonceDistance is the distance of the transaction nonce from the sender nonce in the state
+	NonceDistance() uint64
+	// CumulativeBalanceDistance is how much the sender balance lacks to pay for this and the preceding transactions
+	CumulativeBalanceDistance() uint64
+	// MinFeeCap is the minimal fee cap of this and the preceding transactions of the sender
+	MinFeeCap() *uint256.Int
+	// MinTip is the minimal tip of this and the preceding transactions of the sender
+	MinTip() uint64
+	// Timestamp is the pool sequence number of the transaction arrival
+	Timestamp() uint64
+}
+
+// Policy decides which transactions are admitted to the txpool and in which order they are mined and evicted.
+// It's called under the pool lock, so it must be fast and must not call the pool.
+type Policy interface {
+	// Admit is called for the new transactions which passed the built-in validation, any reason but Success rejects the transaction
+	Admit(txn *types.TxSlot, sender common.Address, isLocal bool) DiscardReason
+	// Better tells whether mt goes to the block before than, both are in the same sub-pool
+	Better(mt, than PoolTx, pendingBaseFee uint64) bool
+	// Worse tells whether mt is evicted before than when the sub-pool overflows, both are in the same sub-pool
+	Worse(mt, than PoolTx, pendingBaseFee uint64) bool
+}
@@ -39,6 +39,7 @@ type Config struct {
 	BlobSlots           uint64 // Total number of blobs (not txs) allowed per account
 	PriceBump           uint64 // Price bump percentage to replace an already existing transaction
 	BlobPriceBump       uint64 //Price bump percentage to replace an existing 4844 blob tx (type-3)
+	DiscardJournalSize  int    // Number of the latest discards and replacements kept in the journal
 	OverrideCancunTime  *big.Int
 
 	// regular batch tasks processing
@@ -53,6 +54,8 @@ type Config struct {
 	MdbxGrowthStep  datasize.ByteSize
 
 	NoGossip bool // this mode doesn't broadcast any txs, and if receive remote-txn - skip it
+
+	Policy Policy // Admission and ordering of transactions, txpool.DefaultPolicy when nil
 }
 
 var DefaultConfig = Config{
@@ -71,6 +74,8 @@ var DefaultConfig = Config{
 	PriceBump:     10, // Price bump percentage to replace an already existing transaction
 	BlobPriceBump: 100,
 
+	DiscardJournalSize: 10_000,
+
 	NoGossip: false,
 }
 
@@ -108,69 +113,90 @@ const (
 	BlobHashCheckFail   DiscardReason = 28 // KZGcommitment's versioned hash has to be equal to blob_versioned_hash at the same index
 	UnmatchedBlobTxExt  DiscardReason = 29 // KZGcommitments must match the corresponding blobs and proofs
 	BlobTxReplace       DiscardReason = 30 // Cannot replace type-3 blob txn with another type of txn
+	PrivateTxExpired    DiscardReason = 31 // Private transaction wasn't included until its max block number
+	PolicyRejected      DiscardReason = 32 // Not admitted by the configured txpool policy
 )
 
-func (r DiscardReason) String() string {
+// Name - description of the reason, ok=false if reason is unknown (for example, received from newer txpool)
+func (r DiscardReason) Name() (name string, ok bool) {
 	switch r {
 	case NotSet:
-		return "not set"
+		return "not set", true
 	case Success:
-		return "success"
+		return "success", true
 	case AlreadyKnown:
-		return "already known"
+		return "already known", true
 	case Mined:
-		return "mined"
+		return "mined", true
 	case ReplacedByHigherTip:
-		return "replaced by transaction with higher tip"
+		return "replaced by transaction with higher tip", true
 	case UnderPriced:
-		return "underpriced"
+		return "underpriced", true
 	case ReplaceUnderpriced:
-		return "replacement transaction underpriced"
+		return "replacement transaction underpriced", true
 	case FeeTooLow:
-		return "fee too low"
+		return "fee too low", true
 	case OversizedData:
-		return "oversized data"
+		return "oversized data", true
 	case InvalidSender:
-		return "invalid sender"
+		return "invalid sender", true
 	case NegativeValue:
-		return "negative value"
+		return "negative value", true
 	case Spammer:
-		return "spammer"
+		return "spammer", true
 	case PendingPoolOverflow:
-		return "pending sub-pool is full"
+		return "pending sub-pool is full", true
 	case BaseFeePoolOverflow:
-		return "baseFee sub-pool is full"
+		return "baseFee sub-pool is full", true
 	case QueuedPoolOverflow:
-		return "queued sub-pool is full"
+		return "queued sub-pool is full", true
 	case GasUintOverflow:
-		return "GasUintOverflow"
+		return "GasUintOverflow", true
 	case IntrinsicGas:
-		return "IntrinsicGas"
+		return "IntrinsicGas", true
 	case RLPTooLong:
-		return "RLPTooLong"
+		return "RLPTooLong", true
 	case NonceTooLow:
-		return "nonce too low"
+		return "nonce too low", true
 	case InsufficientFunds:
-		return "insufficient funds"
+		return "insufficient funds", true
 	case NotReplaced:
-		return "could not replace existing tx"
+		return "could not replace existing tx", true
 	case DuplicateHash:
-		return "existing tx with same hash"
+		return "existing tx with same hash", true
 	case InitCodeTooLarge:
-		return "initcode too large"
+		return "initcode too large", true
 	case TypeNotActivated:
-		return "fork supporting this transaction type is not activated yet"
+		return "fork supporting this transaction type is not activated yet", true
 	case CreateBlobTxn:
-		return "blob transactions cannot have the form of a create transaction"
+		return "blob transactions cannot have the form of a create transaction", true
 	case NoBlobs:
-		return "blob transactions must have at least one blob"
+		return "blob transactions must have at least one blob", true
 	case TooManyBlobs:
-		return "max number of blobs exceeded"
+		return "max number of blobs exceeded", true
+	case UnequalBlobTxExt:
+		return "blob versioned hashes, blobs, commitments and proofs must have equal number", true
+	case BlobHashCheckFail:
+		return "KZG commitment doesn't match the blob versioned hash", true
+	case UnmatchedBlobTxExt:
+		return "KZG commitments must match the corresponding blobs and proofs", true
 	case BlobTxReplace:
-		return "can't replace blob-txn with a non-blob-txn"
+		return "can't replace blob-txn with a non-blob-txn", true
+	case PrivateTxExpired:
+		return "private transaction not included until its max block number", true
+	case PolicyRejected:
+		return "rejected by txpool policy", true
 	default:
+		return "", false
+	}
+}
+
+func (r DiscardReason) String() string {
+	name, ok := r.Name()
+	if !ok {
 		panic(fmt.Sprintf("discard reason: %d", r))
 	}
+	return name
 }
 
 // CalcIntrinsicGas computes the 'intrinsic gas' for a message with the given data.
@@ -93,14 +93,15 @@ type TxSlot struct {
 	Nonce          uint64      // Nonce of the transaction
 	DataLen        int         // Length of transaction's data (for calculation of intrinsic gas)
 	DataNonZeroLen int
-	AlAddrCount    int      // Number of addresses in the access list
-	AlStorCount    int      // Number of storage keys in the access list
-	Gas            uint64   // Gas limit of the transaction
-	IDHash         [32]byte // Transaction hash for the purposes of using it as a transaction Id
-	Traced         bool     // Whether transaction needs to be traced throughout transaction pool code and generate debug printing
-	Creation       bool     // Set to true if "To" field of the transaction is not set
-	Type           byte     // Transaction type
-	Size           uint32   // Size of the payload (without the RLP string envelope for typed transactions)
+	AlAddrCount    int            // Number of addresses in the access list
+	AlStorCount    int            // Number of storage keys in the access list
+	Gas            uint64         // Gas limit of the transaction
+	IDHash         [32]byte       // Transaction hash for the purposes of using it as a transaction Id
+	Traced         bool           // Whether transaction needs to be traced throughout transaction pool code and generate debug printing
+	Creation       bool           // Set to true if "To" field of the transaction is not set
+	To             common.Address // Recipient of the transaction, zero for contract creation
+	Type           byte           // Transaction type
+	Size           uint32         // Size of the payload (without the RLP string envelope for typed transactions)
 
 	// EIP-4844: Shard Blob Transactions
 	BlobFeeCap  uint256.Int // max_fee_per_blob_gas
@@ -374,8 +375,8 @@ func (ctx *TxParseContext) parseTransactionBody(payload []byte, pos, p0 int, slo
 		return 0, fmt.Errorf("%w: unexpected length of to field: %d", ErrParseTxn, dataLen)
 	}
 
-	// Only note if To field is empty or not
 	slot.Creation = dataLen == 0
+	slot.To = common.BytesToAddress(payload[dataPos : dataPos+dataLen])
 	p = dataPos + dataLen
 	// Next follows value
 	p, err = rlp.U256(payload, p, &slot.Value)
@@ -875,6 +876,26 @@ func (s *TxsRlp) Resize(targetSize uint) {
 
 var addressesGrowth = make([]byte, length.Addr)
 
+// TxsBundle is a list of transactions included together, in order, in a given block or not at all
+type TxsBundle struct {
+	Hash              [32]byte // keccak of the concatenated transaction hashes
+	Txs               [][]byte
+	BlockNumber       uint64     // the only block the bundle can be included in
+	MinTimestamp      uint64     // 0 - no lower bound for the block timestamp
+	MaxTimestamp      uint64     // 0 - no upper bound for the block timestamp
+	RevertingTxHashes [][32]byte // transactions allowed to fail without dropping the bundle
+}
+
+// CanRevert tells whether the transaction with the given hash may fail without dropping the bundle
+func (b *TxsBundle) CanRevert(hash [32]byte) bool {
+	for _, h := range b.RevertingTxHashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
 func EncodeSenderLengthForStorage(nonce uint64, balance uint256.Int) uint {
 	var structLength uint = 1 // 1 byte for fieldset
 	if !balance.IsZero() {
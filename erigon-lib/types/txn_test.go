@@ -279,6 +279,7 @@ func TestBlobTxParsing(t *testing.T) {
 	assert.Equal(t, thinTx.Gas, fatTx.Gas)
 	assert.Equal(t, thinTx.IDHash, fatTx.IDHash)
 	assert.Equal(t, thinTx.Creation, fatTx.Creation)
+	assert.Equal(t, thinTx.To, fatTx.To)
 	assert.Equal(t, thinTx.BlobFeeCap, fatTx.BlobFeeCap)
 	assert.Equal(t, thinTx.BlobHashes, fatTx.BlobHashes)
 
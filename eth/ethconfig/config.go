@@ -91,6 +91,8 @@ var Defaults = Config{
 		GasLimit: 30_000_000,
 		GasPrice: big.NewInt(params.GWei),
 		Recommit: 3 * time.Second,
+
+		OrderingBudget: 50 * time.Millisecond,
 	},
 	DeprecatedTxPool: DeprecatedDefaultTxPoolConfig,
 	RPCGasCap:        50000000,
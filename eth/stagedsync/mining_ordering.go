@@ -0,0 +1,337 @@
+package stagedsync
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/tenderly/erigon/erigon-lib/chain"
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/metrics"
+
+	"github.com/tenderly/erigon/consensus"
+	"github.com/tenderly/erigon/core"
+	"github.com/tenderly/erigon/core/state"
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/core/vm/evmtypes"
+	"github.com/tenderly/erigon/params"
+)
+
+// MiningOrdering decides the order in which the mining exec stage tries the transactions of a batch yielded by the
+// pool. The transactions of a sender come in nonce order and must stay so.
+type MiningOrdering interface {
+	Order(txs types.Transactions, sim *MiningSimulator) (types.Transactions, error)
+}
+
+var miningOrderings = map[string]func(cfg *params.MiningConfig) MiningOrdering{
+	"pool":      func(*params.MiningConfig) MiningOrdering { return PoolOrdering{} },
+	"tip":       func(*params.MiningConfig) MiningOrdering { return TipOrdering{} },
+	"profit":    func(*params.MiningConfig) MiningOrdering { return ProfitOrdering{} },
+	"iterative": func(cfg *params.MiningConfig) MiningOrdering { return IterativeOrdering{Budget: cfg.OrderingBudget} },
+}
+
+// RegisterMiningOrdering makes an ordering selectable by the miner.ordering setting
+func RegisterMiningOrdering(name string, f func(cfg *params.MiningConfig) MiningOrdering) {
+	miningOrderings[name] = f
+}
+
+// NewMiningOrdering returns the ordering selected by the config, the pool order when none is
+func NewMiningOrdering(cfg *params.MiningConfig) (MiningOrdering, error) {
+	if cfg.Ordering == "" {
+		return PoolOrdering{}, nil
+	}
+	f, ok := miningOrderings[cfg.Ordering]
+	if !ok {
+		names := make([]string, 0, len(miningOrderings))
+		for name := range miningOrderings {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown mining ordering %q, expected one of %v", cfg.Ordering, names)
+	}
+	return f(cfg), nil
+}
+
+// PoolOrdering keeps the order of the pool, the best transactions by its policy first
+type PoolOrdering struct{}
+
+func (PoolOrdering) Order(txs types.Transactions, _ *MiningSimulator) (types.Transactions, error) {
+	return txs, nil
+}
+
+// TipOrdering picks greedily the transaction paying the highest effective tip per gas
+type TipOrdering struct{}
+
+func (TipOrdering) Order(txs types.Transactions, sim *MiningSimulator) (types.Transactions, error) {
+	baseFee := sim.BaseFee()
+	tips := make([]*uint256.Int, len(txs))
+	for i, txn := range txs {
+		tips[i] = txn.GetEffectiveGasTip(baseFee)
+	}
+	return mergeBySender(txs, func(i, j int) bool { return tips[i].Gt(tips[j]) }), nil
+}
+
+// ProfitOrdering picks greedily the transaction paying the coinbase the most per gas used, as simulated alone at
+// the start of the block, which also accounts for the direct payments to the coinbase
+type ProfitOrdering struct{}
+
+func (ProfitOrdering) Order(txs types.Transactions, sim *MiningSimulator) (types.Transactions, error) {
+	res, err := sim.SimulateEach(txs)
+	if err != nil {
+		return nil, err
+	}
+	values, gas := make([]uint256.Int, len(txs)), make([]uint256.Int, len(txs))
+	for i := range res {
+		if res[i].Err == nil && res[i].GasUsed > 0 {
+			values[i], gas[i] = res[i].Value, *uint256.NewInt(res[i].GasUsed)
+		} else {
+			gas[i].SetOne()
+		}
+	}
+	// values[i]/gas[i] > values[j]/gas[j], without the rounding of a division
+	return mergeBySender(txs, func(i, j int) bool {
+		var l, r uint256.Int
+		return l.Mul(&values[i], &gas[j]).Gt(r.Mul(&values[j], &gas[i]))
+	}), nil
+}
+
+// IterativeOrdering starts from the profit ordering and swaps adjacent transactions of different senders as long as
+// it increases the simulated value of the batch, until no swap does or the budget is spent
+type IterativeOrdering struct {
+	Budget time.Duration // includes the profit ordering
+}
+
+func (o IterativeOrdering) Order(txs types.Transactions, sim *MiningSimulator) (types.Transactions, error) {
+	deadline := time.Now().Add(o.Budget)
+	order, err := ProfitOrdering{}.Order(txs, sim)
+	if err != nil || !time.Now().Before(deadline) {
+		return order, err
+	}
+	best, err := sim.Value(order)
+	if err != nil {
+		return nil, err
+	}
+	for improved := true; improved; {
+		improved = false
+		for i := 1; i < len(order); i++ {
+			if !time.Now().Before(deadline) {
+				return order, nil
+			}
+			prev, _ := order[i-1].GetSender()
+			sender, _ := order[i].GetSender()
+			if prev == sender {
+				continue
+			}
+			order[i-1], order[i] = order[i], order[i-1]
+			value, err := sim.Value(order)
+			if err != nil {
+				return nil, err
+			}
+			if value.Gt(best) {
+				best, improved = value, true
+			} else {
+				order[i-1], order[i] = order[i], order[i-1]
+			}
+		}
+	}
+	return order, nil
+}
+
+// mergeBySender orders the transactions picking each time the best next transaction of a sender,
+// the ties are broken by the original order
+func mergeBySender(txs types.Transactions, better func(i, j int) bool) types.Transactions {
+	var senders []libcommon.Address
+	queues := map[libcommon.Address][]int{}
+	for i, txn := range txs {
+		sender, _ := txn.GetSender()
+		if _, ok := queues[sender]; !ok {
+			senders = append(senders, sender)
+		}
+		queues[sender] = append(queues[sender], i)
+	}
+	res := make(types.Transactions, 0, len(txs))
+	for len(res) < len(txs) {
+		best, bestSender := -1, libcommon.Address{}
+		for _, sender := range senders {
+			queue := queues[sender]
+			if len(queue) == 0 {
+				continue
+			}
+			if best < 0 || better(queue[0], best) || (!better(best, queue[0]) && queue[0] < best) {
+				best, bestSender = queue[0], sender
+			}
+		}
+		res = append(res, txs[best])
+		queues[bestSender] = queues[bestSender][1:]
+	}
+	return res
+}
+
+// SimulatedTx is the outcome of a transaction applied by MiningSimulator.Simulate
+type SimulatedTx struct {
+	Value   uint256.Int // paid to the coinbase
+	GasUsed uint64
+	Err     error // the transaction couldn't be applied
+}
+
+// MiningSimulator applies transactions on throwaway states at the start of the block being built, so the effects of
+// the transactions already in the block are not accounted for. The nonces are not checked, so the later transactions
+// of a sender can be simulated alone.
+type MiningSimulator struct {
+	chainConfig chain.Config
+	engine      consensus.Engine
+	chainReader consensus.ChainHeaderReader
+	stateReader state.StateReader
+	header      *types.Header
+	getHashFn   func(n uint64) libcommon.Hash
+	coinbase    libcommon.Address
+	vmConfig    vm.Config
+	logger      log.Logger
+}
+
+func newMiningSimulator(chainConfig chain.Config, engine consensus.Engine, chainReader consensus.ChainHeaderReader, stateReader state.StateReader,
+	header *types.Header, getHeader func(hash libcommon.Hash, number uint64) *types.Header, coinbase libcommon.Address, vmConfig vm.Config, logger log.Logger) *MiningSimulator {
+	header = types.CopyHeader(header)
+	vmConfig.StatelessExec = true
+	vmConfig.NoReceipts = true
+	vmConfig.SkipAnalysis = core.SkipAnalysis(&chainConfig, header.Number.Uint64())
+	return &MiningSimulator{
+		chainConfig: chainConfig,
+		engine:      engine,
+		chainReader: chainReader,
+		stateReader: stateReader,
+		header:      header,
+		getHashFn:   core.GetHashFn(header, getHeader),
+		coinbase:    coinbase,
+		vmConfig:    vmConfig,
+		logger:      logger,
+	}
+}
+
+// BaseFee of the block being built, nil before London
+func (s *MiningSimulator) BaseFee() *uint256.Int {
+	baseFee, _ := uint256.FromBig(s.header.BaseFee)
+	return baseFee
+}
+
+// newState returns a throwaway state at the start of the block, and a header to apply transactions on it
+func (s *MiningSimulator) newState() (*state.IntraBlockState, *types.Header, error) {
+	ibs, header := state.New(s.stateReader), types.CopyHeader(s.header)
+	if err := core.InitializeBlockExecution(s.engine, s.chainReader, header, &s.chainConfig, ibs, s.logger); err != nil {
+		return nil, nil, err
+	}
+	return ibs, header, nil
+}
+
+// Simulate applies the transactions in order, the ones which can't be applied are skipped
+func (s *MiningSimulator) Simulate(txs types.Transactions) ([]SimulatedTx, error) {
+	ibs, header, err := s.newState()
+	if err != nil {
+		return nil, err
+	}
+	gasPool := new(core.GasPool).AddGas(header.GasLimit - header.GasUsed).AddBlobGas(s.chainConfig.GetMaxBlobGasPerBlock())
+	noop := state.NewNoopWriter()
+	var blobGasUsed uint64
+	res := make([]SimulatedTx, len(txs))
+	for i, txn := range txs {
+		ibs.SetTxContext(txn.Hash(), libcommon.Hash{}, i)
+		before, gasUsed := *ibs.GetBalance(s.coinbase), header.GasUsed
+		snap := ibs.Snapshot()
+		gasSnap, blobGasSnap := gasPool.Gas(), gasPool.BlobGas()
+		if _, _, err := core.ApplyTransaction(&s.chainConfig, s.getHashFn, s.engine, &s.coinbase, gasPool, ibs, noop, header, txn, &header.GasUsed, &blobGasUsed, s.vmConfig); err != nil {
+			ibs.RevertToSnapshot(snap)
+			gasPool = new(core.GasPool).AddGas(gasSnap).AddBlobGas(blobGasSnap)
+			res[i].Err = err
+			continue
+		}
+		if after := ibs.GetBalance(s.coinbase); after.Gt(&before) {
+			res[i].Value.Sub(after, &before)
+		}
+		res[i].GasUsed = header.GasUsed - gasUsed
+	}
+	return res, nil
+}
+
+// SimulateEach applies every transaction alone at the start of the block
+func (s *MiningSimulator) SimulateEach(txs types.Transactions) ([]SimulatedTx, error) {
+	ibs, header, err := s.newState()
+	if err != nil {
+		return nil, err
+	}
+	signer, rules := types.MakeSigner(&s.chainConfig, header.Number.Uint64(), header.Time), s.chainConfig.Rules(header.Number.Uint64(), header.Time)
+	evm := vm.NewEVM(core.NewEVMBlockContext(header, s.getHashFn, s.engine, &s.coinbase), evmtypes.TxContext{}, ibs, &s.chainConfig, s.vmConfig)
+	before := *ibs.GetBalance(s.coinbase)
+	res := make([]SimulatedTx, len(txs))
+	for i, txn := range txs {
+		msg, err := txn.AsMessage(*signer, header.BaseFee, rules)
+		if err != nil {
+			res[i].Err = err
+			continue
+		}
+		msg.SetCheckNonce(false)
+		// the changes of a transaction are reverted before it's finalized, so the block is initialized only once
+		ibs.SetTxContext(txn.Hash(), libcommon.Hash{}, 0)
+		snap := ibs.Snapshot()
+		evm.Reset(core.NewEVMTxContext(msg), ibs)
+		gasPool := new(core.GasPool).AddGas(header.GasLimit - header.GasUsed).AddBlobGas(s.chainConfig.GetMaxBlobGasPerBlock())
+		if result, err := core.ApplyMessage(evm, msg, gasPool, true /* refunds */, false /* gasBailout */); err != nil {
+			res[i].Err = err
+		} else {
+			if after := ibs.GetBalance(s.coinbase); after.Gt(&before) {
+				res[i].Value.Sub(after, &before)
+			}
+			res[i].GasUsed = result.UsedGas
+		}
+		ibs.RevertToSnapshot(snap)
+	}
+	return res, nil
+}
+
+// Value is the total the transactions pay to the coinbase when applied in order
+func (s *MiningSimulator) Value(txs types.Transactions) (*uint256.Int, error) {
+	res, err := s.Simulate(txs)
+	if err != nil {
+		return nil, err
+	}
+	value := new(uint256.Int)
+	for i := range res {
+		value.Add(value, &res[i].Value)
+	}
+	return value, nil
+}
+
+var gwei = big.NewFloat(params.GWei)
+
+func toGwei(wei *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), gwei).Float64()
+	return f
+}
+
+// miningValueMetrics reports, per ordering, the value of the last built block and what the ordering gained over the
+// pool order in it, as estimated by the simulations when MiningConfig.OrderingGain is set
+type miningValueMetrics struct {
+	blockValue metrics.Gauge
+	gain       metrics.Gauge
+}
+
+func newMiningValueMetrics(ordering string) miningValueMetrics {
+	return miningValueMetrics{
+		blockValue: metrics.GetOrCreateGauge(fmt.Sprintf(`mining_block_value_gwei{ordering="%s"}`, ordering)),
+		gain:       metrics.GetOrCreateGauge(fmt.Sprintf(`mining_ordering_gain_gwei{ordering="%s"}`, ordering)),
+	}
+}
+
+// blockValue is what the transactions pay to the fee recipient as tips, like the block value of the built payloads
+func blockValue(txs types.Transactions, receipts types.Receipts, baseFee *uint256.Int) *uint256.Int {
+	value := new(uint256.Int)
+	for i := range txs {
+		gas := new(uint256.Int).SetUint64(receipts[i].GasUsed)
+		value.Add(value, gas.Mul(gas, txs[i].GetEffectiveGasTip(baseFee)))
+	}
+	return value
+}
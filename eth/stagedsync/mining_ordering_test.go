@@ -0,0 +1,113 @@
+package stagedsync
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/crypto"
+	"github.com/tenderly/erigon/params"
+)
+
+func TestNewMiningOrdering(t *testing.T) {
+	for name, expected := range map[string]MiningOrdering{
+		"":          PoolOrdering{},
+		"pool":      PoolOrdering{},
+		"tip":       TipOrdering{},
+		"profit":    ProfitOrdering{},
+		"iterative": IterativeOrdering{Budget: 5},
+	} {
+		ordering, err := NewMiningOrdering(&params.MiningConfig{Ordering: name, OrderingBudget: 5})
+		require.NoError(t, err, name)
+		require.Equal(t, expected, ordering, name)
+	}
+	_, err := NewMiningOrdering(&params.MiningConfig{Ordering: "random"})
+	require.ErrorContains(t, err, "[iterative pool profit tip]")
+
+	// the mining stage doesn't fall back to the pool order
+	cfg := StageMiningExecCfg(nil, MiningState{MiningConfig: &params.MiningConfig{Ordering: "random"}}, nil, *params.TestChainConfig, nil, &vm.Config{}, "", nil, 0, nil, nil, nil)
+	require.ErrorContains(t, SpawnMiningExecStage(nil, nil, cfg, nil, log.New()), "unknown mining ordering")
+}
+
+func TestTipOrdering(t *testing.T) {
+	alice, bob := libcommon.Address{1}, libcommon.Address{2}
+	newTx := func(sender libcommon.Address, nonce, tip uint64) types.Transaction {
+		txn := types.NewEIP1559Transaction(*uint256.NewInt(1), nonce, libcommon.Address{}, uint256.NewInt(0), 21000,
+			uint256.NewInt(0), uint256.NewInt(tip), uint256.NewInt(100+tip), nil)
+		txn.SetSender(sender)
+		return txn
+	}
+	// alice's second transaction pays the most but stays after her first one
+	txs := types.Transactions{newTx(alice, 0, 1), newTx(alice, 1, 9), newTx(bob, 0, 5), newTx(bob, 1, 5)}
+	sim := &MiningSimulator{header: &types.Header{BaseFee: big.NewInt(100)}}
+
+	ordered, err := TipOrdering{}.Order(txs, sim)
+	require.NoError(t, err)
+	require.Equal(t, types.Transactions{txs[2], txs[3], txs[0], txs[1]}, ordered)
+
+	ordered, err = PoolOrdering{}.Order(txs, sim)
+	require.NoError(t, err)
+	require.Equal(t, txs, ordered)
+}
+
+func TestProfitOrdering(t *testing.T) {
+	alice, _ := crypto.GenerateKey()
+	bob, _ := crypto.GenerateKey()
+	_, _, sim := newMiningTestState(t, alice, bob)
+	// alice pays the coinbase directly, more than bob's higher gas price
+	txs := types.Transactions{newMiningTestTx(t, bob, 0, libcommon.Address{1}, 1, 10), newMiningTestTx(t, alice, 0, miningTestCoinbase, 1_000_000, 1)}
+
+	res, err := sim.SimulateEach(txs)
+	require.NoError(t, err)
+	for i, txn := range txs {
+		alone, err := sim.Simulate(types.Transactions{txn})
+		require.NoError(t, err)
+		require.Equal(t, alone[0], res[i])
+	}
+	require.Equal(t, uint64(21000*10), res[0].Value.Uint64())
+	require.Equal(t, uint64(21000+1_000_000), res[1].Value.Uint64())
+
+	ordered, err := ProfitOrdering{}.Order(txs, sim)
+	require.NoError(t, err)
+	require.Equal(t, miningTestHashes(txs[1], txs[0]), miningTestHashes(ordered...))
+
+	ordered, err = TipOrdering{}.Order(txs, sim)
+	require.NoError(t, err)
+	require.Equal(t, miningTestHashes(txs...), miningTestHashes(ordered...))
+}
+
+func TestIterativeOrdering(t *testing.T) {
+	alice, _ := crypto.GenerateKey()
+	bob, _ := crypto.GenerateKey()
+	_, _, sim := newMiningTestState(t, alice, bob)
+	// alice's bribe pays more per gas alone, but bob's deposit to the briber before it makes it pay much more
+	bribe, deposit := newMiningTestTx(t, alice, 0, miningTestBriber, 0, 1), newMiningTestTx(t, bob, 0, miningTestBriber, 1_000_000, 1)
+	txs := types.Transactions{deposit, bribe}
+
+	ordered, err := ProfitOrdering{}.Order(txs, sim)
+	require.NoError(t, err)
+	require.Equal(t, miningTestHashes(bribe, deposit), miningTestHashes(ordered...))
+	profitValue, err := sim.Value(ordered)
+	require.NoError(t, err)
+
+	// the budget is spent by the profit ordering
+	ordered, err = IterativeOrdering{}.Order(txs, sim)
+	require.NoError(t, err)
+	require.Equal(t, miningTestHashes(bribe, deposit), miningTestHashes(ordered...))
+
+	ordered, err = IterativeOrdering{Budget: time.Minute}.Order(txs, sim)
+	require.NoError(t, err)
+	require.Equal(t, miningTestHashes(deposit, bribe), miningTestHashes(ordered...))
+	value, err := sim.Value(ordered)
+	require.NoError(t, err)
+	require.True(t, value.Gt(profitValue))
+	require.Greater(t, value.Uint64(), uint64(1_000_000+1000)) // the bribe pays the deposit
+}
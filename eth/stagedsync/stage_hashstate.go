@@ -191,10 +191,12 @@ func promotePlainState(
 	ctx context.Context,
 	logger log.Logger,
 ) error {
-	accCollector := etl.NewCollector(logPrefix, tmpdir, etl.NewSortableBuffer(etl.BufferOptimalSize), logger)
+	// whole state goes through tmpdir - compress it
+	collectorCfg := etl.CollectorCfg{Compression: etl.CompressionSnappy}
+	accCollector := etl.NewCollectorWithCfg(logPrefix, tmpdir, etl.NewSortableBuffer(etl.BufferOptimalSize), collectorCfg, logger)
 	defer accCollector.Close()
 	accCollector.LogLvl(log.LvlTrace)
-	storageCollector := etl.NewCollector(logPrefix, tmpdir, etl.NewSortableBuffer(etl.BufferOptimalSize), logger)
+	storageCollector := etl.NewCollectorWithCfg(logPrefix, tmpdir, etl.NewSortableBuffer(etl.BufferOptimalSize), collectorCfg, logger)
 	defer storageCollector.Close()
 	storageCollector.LogLvl(log.LvlTrace)
 
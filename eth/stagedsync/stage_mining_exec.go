@@ -26,6 +26,7 @@ import (
 	"github.com/tenderly/erigon/core/types"
 	"github.com/tenderly/erigon/core/types/accounts"
 	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/core/vm/evmtypes"
 	"github.com/tenderly/erigon/eth/stagedsync/stages"
 	"github.com/tenderly/erigon/params"
 	"github.com/tenderly/erigon/turbo/services"
@@ -44,10 +45,14 @@ type MiningExecCfg struct {
 	payloadId   uint64
 	txPool2     TxPoolForMining
 	txPool2DB   kv.RoDB
+	ordering    MiningOrdering
+	orderingErr error // the ordering is unknown
+	metrics     miningValueMetrics
 }
 
 type TxPoolForMining interface {
 	YieldBest(n uint16, txs *types2.TxsRlp, tx kv.Tx, onTopOf, availableGas, availableBlobGas uint64, toSkip mapset.Set[[32]byte]) (bool, int, error)
+	BundlesFor(blockNum, timestamp uint64) []*types2.TxsBundle
 }
 
 func StageMiningExecCfg(
@@ -58,6 +63,11 @@ func StageMiningExecCfg(
 	txPool2 TxPoolForMining, txPool2DB kv.RoDB,
 	blockReader services.FullBlockReader,
 ) MiningExecCfg {
+	orderingName := miningState.MiningConfig.Ordering
+	ordering, orderingErr := NewMiningOrdering(miningState.MiningConfig)
+	if orderingName == "" {
+		orderingName = "pool"
+	}
 	return MiningExecCfg{
 		db:          db,
 		miningState: miningState,
@@ -71,6 +81,9 @@ func StageMiningExecCfg(
 		payloadId:   payloadId,
 		txPool2:     txPool2,
 		txPool2DB:   txPool2DB,
+		ordering:    ordering,
+		orderingErr: orderingErr,
+		metrics:     newMiningValueMetrics(orderingName),
 	}
 }
 
@@ -78,6 +91,9 @@ func StageMiningExecCfg(
 // TODO:
 // - resubmitAdjustCh - variable is not implemented
 func SpawnMiningExecStage(s *StageState, tx kv.RwTx, cfg MiningExecCfg, quit <-chan struct{}, logger log.Logger) error {
+	if cfg.orderingErr != nil {
+		return cfg.orderingErr
+	}
 	cfg.vmConfig.NoReceipts = false
 	chainID, _ := uint256.FromBig(cfg.chainConfig.ChainID)
 	logPrefix := s.LogPrefix()
@@ -114,6 +130,7 @@ func SpawnMiningExecStage(s *StageState, tx kv.RwTx, cfg MiningExecCfg, quit <-c
 		} else {
 
 			yielded := mapset.NewSet[[32]byte]()
+			gain := new(big.Int) // estimated value gained by the ordering over the pool order
 			var simulationTx kv.StatelessRwTx
 			m := membatch.NewHashBatch(tx, quit, cfg.tmpdir, logger)
 			defer m.Close()
@@ -124,14 +141,34 @@ func SpawnMiningExecStage(s *StageState, tx kv.RwTx, cfg MiningExecCfg, quit <-c
 				return err
 			}
 
+			sim := newMiningSimulator(cfg.chainConfig, cfg.engine, chainReader, stateReader, current.Header, getHeader, cfg.miningState.MiningConfig.Etherbase, *cfg.vmConfig, logger)
+			bundles := cfg.txPool2.BundlesFor(current.Header.Number.Uint64(), current.Header.Time)
+			if len(bundles) > 0 {
+				var logs types.Logs
+				logs, ibs, err = addBundlesToMiningBlock(logPrefix, current, cfg.chainConfig, cfg.vmConfig, getHeader, cfg.engine, sim, bundles, chainID, cfg.miningState.MiningConfig.Etherbase, ibs, yielded, logger)
+				if err != nil {
+					return err
+				}
+				NotifyPendingLogs(logPrefix, cfg.notifier, logs, logger)
+			}
+
 			for {
 				txs, y, err := getNextTransactions(cfg, chainID, current.Header, 50, executionAt, simulationTx, yielded, logger)
 				if err != nil {
 					return err
 				}
 
-				if !txs.Empty() {
-					logs, stop, err := addTransactionsToMiningBlock(logPrefix, current, cfg.chainConfig, cfg.vmConfig, getHeader, cfg.engine, txs, cfg.miningState.MiningConfig.Etherbase, ibs, quit, cfg.interrupt, cfg.payloadId, logger)
+				if len(txs) > 0 {
+					ordered, err := cfg.ordering.Order(txs, sim)
+					if err != nil {
+						return err
+					}
+					if _, ok := cfg.ordering.(PoolOrdering); !ok && cfg.miningState.MiningConfig.OrderingGain {
+						if err := addOrderingGain(gain, txs, ordered, sim); err != nil {
+							return err
+						}
+					}
+					logs, stop, err := addTransactionsToMiningBlock(logPrefix, current, cfg.chainConfig, cfg.vmConfig, getHeader, cfg.engine, types.NewTransactionsFixedOrder(ordered), cfg.miningState.MiningConfig.Etherbase, ibs, quit, cfg.interrupt, cfg.payloadId, logger)
 					if err != nil {
 						return err
 					}
@@ -148,6 +185,9 @@ func SpawnMiningExecStage(s *StageState, tx kv.RwTx, cfg MiningExecCfg, quit <-c
 					break
 				}
 			}
+			if cfg.miningState.MiningConfig.OrderingGain {
+				cfg.metrics.gain.Set(toGwei(gain))
+			}
 		}
 	}
 
@@ -161,6 +201,8 @@ func SpawnMiningExecStage(s *StageState, tx kv.RwTx, cfg MiningExecCfg, quit <-c
 	if current.Receipts == nil {
 		current.Receipts = types.Receipts{}
 	}
+	baseFee, _ := uint256.FromBig(current.Header.BaseFee)
+	cfg.metrics.blockValue.Set(toGwei(blockValue(current.Txs, current.Receipts, baseFee).ToBig()))
 
 	var err error
 	_, current.Txs, current.Receipts, err = core.FinalizeBlockExecution(cfg.engine, stateReader, current.Header, current.Txs, current.Uncles, stateWriter, &cfg.chainConfig, ibs, current.Receipts, current.Withdrawals, ChainReaderImpl{config: &cfg.chainConfig, tx: tx, blockReader: cfg.blockReader}, true, logger)
@@ -185,7 +227,7 @@ func getNextTransactions(
 	simulationTx kv.StatelessRwTx,
 	alreadyYielded mapset.Set[[32]byte],
 	logger log.Logger,
-) (types.TransactionsStream, int, error) {
+) (types.Transactions, int, error) {
 	txSlots := types2.TxsRlp{}
 	var onTime bool
 	count := 0
@@ -236,7 +278,7 @@ func getNextTransactions(
 		return nil, 0, err
 	}
 
-	return types.NewTransactionsFixedOrder(txs), count, nil
+	return txs, count, nil
 }
 
 func filterBadTransactions(transactions []types.Transaction, config chain.Config, blockNumber uint64, baseFee *big.Int, simulationTx kv.StatelessRwTx, logger log.Logger) ([]types.Transaction, error) {
@@ -488,6 +530,126 @@ LOOP:
 
 }
 
+// addBundlesToMiningBlock includes, in order, the bundles whose transactions all apply and don't fail unless they
+// are allowed to revert. As the state can't be reverted across transactions, a bundle is simulated first on a
+// throwaway state, which follows the mining state until a bundle is rejected on it. A bundle failing on the mining
+// state after its simulation is skipped too, the returned state is then rebuilt from the included bundles and has
+// to replace ibs.
+func addBundlesToMiningBlock(logPrefix string, current *MiningBlock, chainConfig chain.Config, vmConfig *vm.Config, getHeader func(hash libcommon.Hash, number uint64) *types.Header,
+	engine consensus.Engine, sim *MiningSimulator, bundles []*types2.TxsBundle, chainID *uint256.Int, coinbase libcommon.Address,
+	ibs *state.IntraBlockState, yielded mapset.Set[[32]byte], logger log.Logger) (types.Logs, *state.IntraBlockState, error) {
+	header := current.Header
+	startTxIndex := len(current.Txs)
+	startGasUsed, startBlobGasUsed := header.GasUsed, uint64(0)
+	if header.BlobGasUsed != nil {
+		startBlobGasUsed = *header.BlobGasUsed
+	}
+	getHashFn := core.GetHashFn(header, getHeader)
+	cfg := *vmConfig
+	cfg.SkipAnalysis = core.SkipAnalysis(&chainConfig, header.Number.Uint64())
+	noop := state.NewNoopWriter()
+
+	apply := func(ibs *state.IntraBlockState, header *types.Header, txs types.Transactions, txIndex int, canRevert func(libcommon.Hash) bool) ([]*core.BundleTxResult, error) {
+		gasPool := new(core.GasPool).AddGas(header.GasLimit - header.GasUsed)
+		if header.BlobGasUsed != nil {
+			gasPool.AddBlobGas(chainConfig.GetMaxBlobGasPerBlock() - *header.BlobGasUsed)
+		}
+		evm := vm.NewEVM(core.NewEVMBlockContext(header, getHashFn, engine, &coinbase), evmtypes.TxContext{}, ibs, &chainConfig, cfg)
+		return core.ApplyBundle(&chainConfig, engine, gasPool, ibs, noop, header, txs, txIndex, canRevert, nil, &header.GasUsed, header.BlobGasUsed, evm, cfg)
+	}
+	// replay reverts the mining state to the included bundles: the journal doesn't survive the transactions
+	replay := func(included types.Transactions) (*state.IntraBlockState, error) {
+		ibs, _, err := sim.newState()
+		if err != nil {
+			return nil, err
+		}
+		header.GasUsed = startGasUsed
+		if header.BlobGasUsed != nil {
+			*header.BlobGasUsed = startBlobGasUsed
+		}
+		if _, err := apply(ibs, header, included, startTxIndex, nil); err != nil {
+			return nil, fmt.Errorf("replaying the included bundles: %w", err)
+		}
+		return ibs, nil
+	}
+
+	var coalescedLogs types.Logs
+	var included types.Transactions
+	var simIbs *state.IntraBlockState
+	var simHeader *types.Header
+	for _, bundle := range bundles {
+		txs, err := decodeBundle(bundle, chainID)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("[%s] Skipping bundle", logPrefix), "hash", libcommon.Hash(bundle.Hash), "err", err)
+			continue
+		}
+		canRevert := func(hash libcommon.Hash) bool { return bundle.CanRevert(hash) }
+
+		if simIbs == nil {
+			if simIbs, simHeader, err = sim.newState(); err != nil {
+				return nil, nil, err
+			}
+			if _, err := apply(simIbs, simHeader, included, startTxIndex, nil); err != nil {
+				return nil, nil, fmt.Errorf("replaying the included bundles: %w", err)
+			}
+		}
+		if _, err := apply(simIbs, simHeader, txs, len(current.Txs), canRevert); err != nil {
+			logger.Debug(fmt.Sprintf("[%s] Skipping bundle", logPrefix), "hash", libcommon.Hash(bundle.Hash), "err", err)
+			simIbs = nil
+			continue
+		}
+
+		results, err := apply(ibs, header, txs, len(current.Txs), canRevert)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("[%s] Skipping bundle failed after its simulation", logPrefix), "hash", libcommon.Hash(bundle.Hash), "err", err)
+			if ibs, err = replay(included); err != nil {
+				return nil, nil, err
+			}
+			simIbs = nil
+			continue
+		}
+		for i, txn := range txs {
+			current.Txs = append(current.Txs, txn)
+			current.Receipts = append(current.Receipts, results[i].Receipt)
+			coalescedLogs = append(coalescedLogs, results[i].Receipt.Logs...)
+			yielded.Add(txn.Hash())
+		}
+		included = append(included, txs...)
+		logger.Debug(fmt.Sprintf("[%s] Bundle included", logPrefix), "hash", libcommon.Hash(bundle.Hash), "txs", len(txs), "block", header.Number)
+	}
+	return coalescedLogs, ibs, nil
+}
+
+// addOrderingGain adds to gain the simulated value of the ordered batch minus the one of the pool order
+func addOrderingGain(gain *big.Int, txs, ordered types.Transactions, sim *MiningSimulator) error {
+	poolValue, err := sim.Value(txs)
+	if err != nil {
+		return err
+	}
+	orderedValue, err := sim.Value(ordered)
+	if err != nil {
+		return err
+	}
+	gain.Add(gain, orderedValue.ToBig())
+	gain.Sub(gain, poolValue.ToBig())
+	return nil
+}
+
+func decodeBundle(bundle *types2.TxsBundle, chainID *uint256.Int) (types.Transactions, error) {
+	txs := make(types.Transactions, len(bundle.Txs))
+	for i := range bundle.Txs {
+		txn, err := types.DecodeWrappedTransaction(bundle.Txs[i])
+		if err != nil {
+			return nil, err
+		}
+		if !txn.GetChainID().IsZero() && txn.GetChainID().Cmp(chainID) != 0 {
+			return nil, fmt.Errorf("transaction %x of chain %d", txn.Hash(), txn.GetChainID())
+		}
+		txs[i] = txn
+	}
+	return txs, nil
+}
+
 func NotifyPendingLogs(logPrefix string, notifier ChainEventNotifier, logs types.Logs, logger log.Logger) {
 	if len(logs) == 0 {
 		return
@@ -0,0 +1,135 @@
+package stagedsync
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/kv/memdb"
+	types2 "github.com/tenderly/erigon/erigon-lib/types"
+
+	"github.com/tenderly/erigon/consensus/ethash"
+	"github.com/tenderly/erigon/core"
+	"github.com/tenderly/erigon/core/state"
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/core/vm/evmtypes"
+	"github.com/tenderly/erigon/crypto"
+	"github.com/tenderly/erigon/params"
+)
+
+var (
+	miningTestCoinbase = libcommon.Address{0xc0}
+	miningTestReverter = libcommon.Address{0xfd} // always reverts
+	miningTestBriber   = libcommon.Address{0xff} // keeps the value sent to it, sends all its balance to the coinbase when called without
+	miningTestSigner   = types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+)
+
+// newMiningTestState returns the mining state at the start of block 1, where the senders have an ether each and the
+// briber 1000 wei, and its simulator
+func newMiningTestState(t *testing.T, senders ...*ecdsa.PrivateKey) (*MiningBlock, *state.IntraBlockState, *MiningSimulator) {
+	_, tx := memdb.NewTestTx(t)
+	genesis := state.New(state.NewPlainStateReader(tx))
+	for _, key := range senders {
+		genesis.AddBalance(crypto.PubkeyToAddress(key.PublicKey), uint256.NewInt(params.Ether))
+	}
+	genesis.SetCode(miningTestReverter, []byte{byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.REVERT)})
+	genesis.SetCode(miningTestBriber, []byte{byte(vm.CALLVALUE), byte(vm.ISZERO), byte(vm.PUSH1), 6, byte(vm.JUMPI), byte(vm.STOP),
+		byte(vm.JUMPDEST), byte(vm.COINBASE), byte(vm.SELFDESTRUCT)})
+	genesis.AddBalance(miningTestBriber, uint256.NewInt(1000))
+	require.NoError(t, genesis.CommitBlock(params.TestRules, state.NewPlainStateWriter(tx, tx, 0)))
+
+	chainConfig, engine, logger := *params.TestChainConfig, ethash.NewFaker(), log.New()
+	header := &types.Header{Number: big.NewInt(1), GasLimit: 10_000_000, Difficulty: big.NewInt(1), Coinbase: miningTestCoinbase}
+	getHeader := func(libcommon.Hash, uint64) *types.Header { return nil }
+	stateReader := state.NewPlainStateReader(tx)
+	ibs := state.New(stateReader)
+	require.NoError(t, core.InitializeBlockExecution(engine, nil, header, &chainConfig, ibs, logger))
+	sim := newMiningSimulator(chainConfig, engine, nil, stateReader, header, getHeader, miningTestCoinbase, vm.Config{}, logger)
+	return &MiningBlock{Header: header}, ibs, sim
+}
+
+func newMiningTestTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, to libcommon.Address, value, gasPrice uint64) types.Transaction {
+	txn, err := types.SignTx(types.NewTransaction(nonce, to, uint256.NewInt(value), 100_000, uint256.NewInt(gasPrice), nil), *miningTestSigner, key)
+	require.NoError(t, err)
+	txn.SetSender(crypto.PubkeyToAddress(key.PublicKey)) // as yielded by the pool
+	return txn
+}
+
+func newMiningTestBundle(t *testing.T, txs types.Transactions, reverting ...types.Transaction) *types2.TxsBundle {
+	bundle := &types2.TxsBundle{BlockNumber: 1}
+	for _, txn := range txs {
+		var buf bytes.Buffer
+		require.NoError(t, txn.MarshalBinary(&buf))
+		bundle.Txs = append(bundle.Txs, buf.Bytes())
+		copy(bundle.Hash[:], crypto.Keccak256(bundle.Hash[:], txn.Hash().Bytes()))
+	}
+	for _, txn := range reverting {
+		bundle.RevertingTxHashes = append(bundle.RevertingTxHashes, txn.Hash())
+	}
+	return bundle
+}
+
+func miningTestHashes(txs ...types.Transaction) []libcommon.Hash {
+	hashes := make([]libcommon.Hash, len(txs))
+	for i, txn := range txs {
+		hashes[i] = txn.Hash()
+	}
+	return hashes
+}
+
+func TestAddBundlesToMiningBlock(t *testing.T) {
+	alice, _ := crypto.GenerateKey()
+	bob, _ := crypto.GenerateKey()
+	current, ibs, sim := newMiningTestState(t, alice, bob)
+	chainID, _ := uint256.FromBig(params.TestChainConfig.ChainID)
+
+	// alice's transfer is dropped with the transaction reverting after it
+	aliceTransfer, aliceReverted := newMiningTestTx(t, alice, 0, libcommon.Address{1}, 1, 1), newMiningTestTx(t, alice, 1, miningTestReverter, 1, 1)
+	// bob's transaction is allowed to revert
+	bobTransfer, bobReverted := newMiningTestTx(t, bob, 0, libcommon.Address{1}, 1, 1), newMiningTestTx(t, bob, 1, miningTestReverter, 1, 1)
+	// alice's transfer is included alone, the state isn't changed by her failed bundle
+	aliceAlone := newMiningTestTx(t, alice, 0, libcommon.Address{2}, 1, 1)
+	bundles := []*types2.TxsBundle{
+		newMiningTestBundle(t, types.Transactions{aliceTransfer, aliceReverted}),
+		newMiningTestBundle(t, types.Transactions{bobTransfer, bobReverted}, bobReverted),
+		newMiningTestBundle(t, types.Transactions{aliceAlone}),
+	}
+
+	yielded := mapset.NewSet[[32]byte]()
+	_, ibs, err := addBundlesToMiningBlock("test", current, *params.TestChainConfig, &vm.Config{}, nil, sim.engine, sim, bundles, chainID, miningTestCoinbase, ibs, yielded, log.New())
+	require.NoError(t, err)
+	require.Equal(t, miningTestHashes(bobTransfer, bobReverted, aliceAlone), miningTestHashes(current.Txs...))
+	require.Equal(t, []uint64{types.ReceiptStatusSuccessful, types.ReceiptStatusFailed, types.ReceiptStatusSuccessful},
+		[]uint64{current.Receipts[0].Status, current.Receipts[1].Status, current.Receipts[2].Status})
+	require.False(t, yielded.Contains(aliceTransfer.Hash()))
+	require.False(t, yielded.Contains(aliceReverted.Hash()))
+	require.Equal(t, uint64(1), ibs.GetNonce(crypto.PubkeyToAddress(alice.PublicKey)))
+	require.True(t, ibs.GetBalance(libcommon.Address{1}).Eq(uint256.NewInt(1)))
+}
+
+func TestApplyBundleInterrupted(t *testing.T) {
+	alice, _ := crypto.GenerateKey()
+	current, ibs, sim := newMiningTestState(t, alice)
+	header := current.Header
+	txs := types.Transactions{newMiningTestTx(t, alice, 0, libcommon.Address{1}, 1, 1), newMiningTestTx(t, alice, 1, libcommon.Address{1}, 1, 1)}
+	getHeader := func(libcommon.Hash, uint64) *types.Header { return nil }
+	evm := vm.NewEVM(core.NewEVMBlockContext(header, core.GetHashFn(header, getHeader), sim.engine, &miningTestCoinbase), evmtypes.TxContext{}, ibs, params.TestChainConfig, vm.Config{})
+	gasPool := new(core.GasPool).AddGas(header.GasLimit)
+
+	// the bundle stops after the first transaction
+	errInterrupted := errors.New("interrupted")
+	interrupted := func() error { return errInterrupted }
+	results, err := core.ApplyBundle(params.TestChainConfig, sim.engine, gasPool, ibs, state.NewNoopWriter(), header, txs, 0, nil, interrupted, &header.GasUsed, header.BlobGasUsed, evm, vm.Config{})
+	require.ErrorIs(t, err, errInterrupted)
+	require.Len(t, results, 1)
+	require.Equal(t, uint64(1), ibs.GetNonce(crypto.PubkeyToAddress(alice.PublicKey)))
+}
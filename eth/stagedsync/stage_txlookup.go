@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
 	"github.com/ledgerwatch/log/v3"
 	"github.com/tenderly/erigon/erigon-lib/chain"
 	libcommon "github.com/tenderly/erigon/erigon-lib/common"
@@ -26,6 +27,13 @@ type TxLookupCfg struct {
 	tmpdir      string
 	borConfig   *chain.BorConfig
 	blockReader services.FullBlockReader
+	blobs       BlobSidecarSource
+}
+
+// BlobSidecarSource provides the blobs of the transactions which were seen before they got mined,
+// the blocks themselves carry only the versioned hashes
+type BlobSidecarSource interface {
+	GetBlobs(hash []byte) (blobs [][]byte, commitments []gokzg4844.KZGCommitment, proofs []gokzg4844.KZGProof)
 }
 
 func StageTxLookupCfg(
@@ -34,6 +42,7 @@ func StageTxLookupCfg(
 	tmpdir string,
 	borConfig *chain.BorConfig,
 	blockReader services.FullBlockReader,
+	blobs BlobSidecarSource,
 ) TxLookupCfg {
 	return TxLookupCfg{
 		db:          db,
@@ -41,6 +50,7 @@ func StageTxLookupCfg(
 		tmpdir:      tmpdir,
 		borConfig:   borConfig,
 		blockReader: blockReader,
+		blobs:       blobs,
 	}
 }
 
@@ -100,6 +110,12 @@ func SpawnTxLookup(s *StageState, tx kv.RwTx, toBlock uint64, cfg TxLookupCfg, c
 		}
 	}
 
+	if cfg.blobs != nil {
+		if err = writeBlobSidecars(tx, startBlock, endBlock, ctx, cfg); err != nil {
+			return fmt.Errorf("writeBlobSidecars: %w", err)
+		}
+	}
+
 	if err = s.Update(tx, endBlock); err != nil {
 		return err
 	}
@@ -144,6 +160,58 @@ func txnLookupTransform(logPrefix string, tx kv.RwTx, blockFrom, blockTo uint64,
 	}, logger)
 }
 
+// writeBlobSidecars stores the known blobs of the blob transactions in [blockFrom, blockTo],
+// the blocks outside of the retention window are skipped
+func writeBlobSidecars(tx kv.RwTx, blockFrom, blockTo uint64, ctx context.Context, cfg TxLookupCfg) error {
+	if blockTo >= rawdb.BlobSidecarsRetentionBlocks {
+		blockFrom = cmp.Max(blockFrom, blockTo-rawdb.BlobSidecarsRetentionBlocks+1)
+	}
+	for blockNum := blockFrom; blockNum <= blockTo; blockNum++ {
+		header, err := cfg.blockReader.HeaderByNumber(ctx, tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if header == nil || header.BlobGasUsed == nil || *header.BlobGasUsed == 0 {
+			continue
+		}
+		body, err := cfg.blockReader.BodyWithTransactions(ctx, tx, header.Hash(), blockNum)
+		if err != nil {
+			return err
+		}
+		if body == nil {
+			continue
+		}
+		for _, txn := range body.Transactions {
+			if txn.Type() != types.BlobTxType {
+				continue
+			}
+			txHash := txn.Hash()
+			blobs, commitments, proofs := cfg.blobs.GetBlobs(txHash[:])
+			if len(blobs) == 0 {
+				continue
+			}
+			sidecar := &rawdb.BlobSidecar{
+				Blobs:       make(types.Blobs, len(blobs)),
+				Commitments: make(types.BlobKzgs, len(commitments)),
+				Proofs:      make(types.KZGProofs, len(proofs)),
+			}
+			for i := range blobs {
+				copy(sidecar.Blobs[i][:], blobs[i])
+			}
+			for i := range commitments {
+				sidecar.Commitments[i] = types.KZGCommitment(commitments[i])
+			}
+			for i := range proofs {
+				sidecar.Proofs[i] = types.KZGProof(proofs[i])
+			}
+			if err = rawdb.WriteBlobSidecar(tx, blockNum, txHash, sidecar); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // txnLookupTransform - [startKey, endKey)
 func borTxnLookupTransform(logPrefix string, tx kv.RwTx, blockFrom, blockTo uint64, quitCh <-chan struct{}, cfg TxLookupCfg, logger log.Logger) error {
 	bigNum := new(big.Int)
@@ -199,6 +267,9 @@ func UnwindTxLookup(u *UnwindState, s *StageState, tx kv.RwTx, cfg TxLookupCfg,
 			return fmt.Errorf("unwind BorTxLookUp: %w", err)
 		}
 	}
+	if err := rawdb.DeleteBlobSidecarsFrom(tx, u.UnwindPoint+1); err != nil {
+		return fmt.Errorf("unwind BlobSidecars: %w", err)
+	}
 	if err := u.Done(tx); err != nil {
 		return err
 	}
@@ -250,6 +321,12 @@ func PruneTxLookup(s *PruneState, tx kv.RwTx, cfg TxLookupCfg, ctx context.Conte
 		}
 	}
 
+	if s.ForwardProgress > rawdb.BlobSidecarsRetentionBlocks {
+		if err = rawdb.PruneBlobSidecars(tx, s.ForwardProgress-rawdb.BlobSidecarsRetentionBlocks+1); err != nil {
+			return fmt.Errorf("prune BlobSidecars: %w", err)
+		}
+	}
+
 	if !useExternalTx {
 		if err = tx.Commit(); err != nil {
 			return err
@@ -10,6 +10,7 @@ import (
 	"github.com/tenderly/erigon/erigon-lib/common/dbg"
 	"github.com/tenderly/erigon/erigon-lib/kv"
 
+	"github.com/tenderly/erigon/core/rawdb"
 	"github.com/tenderly/erigon/eth/stagedsync/stages"
 )
 
@@ -200,6 +201,9 @@ func (s *Sync) RunUnwind(db kv.RwDB, tx kv.RwTx) error {
 	if s.unwindPoint == nil {
 		return nil
 	}
+	if err := s.saveBadBlock(db, tx); err != nil {
+		return err
+	}
 	for j := 0; j < len(s.unwindOrder); j++ {
 		if s.unwindOrder[j] == nil || s.unwindOrder[j].Disabled || s.unwindOrder[j].Unwind == nil {
 			continue
@@ -224,6 +228,9 @@ func (s *Sync) RunNoInterrupt(db kv.RwDB, tx kv.RwTx, firstCycle bool) error {
 	for !s.IsDone() {
 		var badBlockUnwind bool
 		if s.unwindPoint != nil {
+			if err := s.saveBadBlock(db, tx); err != nil {
+				return err
+			}
 			for j := 0; j < len(s.unwindOrder); j++ {
 				if s.unwindOrder[j] == nil || s.unwindOrder[j].Disabled || s.unwindOrder[j].Unwind == nil {
 					continue
@@ -287,6 +294,9 @@ func (s *Sync) Run(db kv.RwDB, tx kv.RwTx, firstCycle bool) error {
 	for !s.IsDone() {
 		var badBlockUnwind bool
 		if s.unwindPoint != nil {
+			if err := s.saveBadBlock(db, tx); err != nil {
+				return err
+			}
 			for j := 0; j < len(s.unwindOrder); j++ {
 				if s.unwindOrder[j] == nil || s.unwindOrder[j].Disabled || s.unwindOrder[j].Unwind == nil {
 					continue
@@ -444,6 +454,32 @@ func (s *Sync) runStage(stage *Stage, db kv.RwDB, tx kv.RwTx, firstCycle bool, b
 	return nil
 }
 
+// saveBadBlock keeps the block which caused the current unwind in kv.BadBlocks, before the unwind removes it
+func (s *Sync) saveBadBlock(db kv.RwDB, tx kv.RwTx) error {
+	if !s.unwindReason.IsBadBlock() || s.unwindReason.Block == nil {
+		return nil
+	}
+	if tx == nil {
+		if db == nil {
+			return nil
+		}
+		return db.Update(context.Background(), func(tx kv.RwTx) error {
+			return s.saveBadBlock(nil, tx)
+		})
+	}
+	hash := *s.unwindReason.Block
+	number := rawdb.ReadHeaderNumber(tx, hash)
+	if number == nil {
+		return nil
+	}
+	block := rawdb.ReadBlock(tx, hash, *number)
+	if block == nil { // the body may be not downloaded yet
+		return nil
+	}
+	s.logger.Debug("Saving bad block", "number", *number, "hash", hash, "err", s.unwindReason.Err)
+	return rawdb.WriteBadBlock(tx, block, s.unwindReason.Err.Error())
+}
+
 func (s *Sync) unwindStage(firstCycle bool, stage *Stage, db kv.RwDB, tx kv.RwTx) error {
 	start := time.Now()
 	s.logger.Trace("Unwind...", "stage", stage.ID)
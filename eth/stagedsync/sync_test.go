@@ -3,6 +3,7 @@ package stagedsync
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/ledgerwatch/log/v3"
@@ -10,6 +11,8 @@ import (
 	"github.com/tenderly/erigon/erigon-lib/kv"
 	"github.com/tenderly/erigon/erigon-lib/kv/memdb"
 
+	"github.com/tenderly/erigon/core/rawdb"
+	"github.com/tenderly/erigon/core/types"
 	"github.com/tenderly/erigon/eth/stagedsync/stages"
 )
 
@@ -617,3 +620,49 @@ func TestSyncInterruptLongUnwind(t *testing.T) {
 func unwindOf(s stages.SyncStage) stages.SyncStage {
 	return stages.SyncStage(append([]byte(s), 0xF0))
 }
+
+func TestBadBlockUnwindSavesBlock(t *testing.T) {
+	db, tx := memdb.NewTestTx(t)
+	header := &types.Header{Number: big.NewInt(1), Extra: []byte("bad block")}
+	block := types.NewBlockWithHeader(header)
+	assert.NoError(t, rawdb.WriteBlock(tx, block))
+
+	unwound := false
+	s := []*Stage{
+		{
+			ID:          stages.Headers,
+			Description: "Downloading headers",
+			Forward: func(firstCycle bool, badBlockUnwind bool, s *StageState, u Unwinder, tx kv.RwTx, logger log.Logger) error {
+				return s.Update(tx, 1)
+			},
+			Unwind: func(firstCycle bool, u *UnwindState, s *StageState, tx kv.RwTx, logger log.Logger) error {
+				rawdb.DeleteHeader(tx, block.Hash(), block.NumberU64())
+				return u.Done(tx)
+			},
+		},
+		{
+			ID:          stages.Execution,
+			Description: "Executing blocks",
+			Forward: func(firstCycle bool, badBlockUnwind bool, s *StageState, u Unwinder, tx kv.RwTx, logger log.Logger) error {
+				if !unwound {
+					unwound = true
+					u.UnwindTo(0, BadBlock(block.Hash(), errors.New("invalid state root")))
+					return s.Update(tx, 1)
+				}
+				return nil
+			},
+			Unwind: func(firstCycle bool, u *UnwindState, s *StageState, tx kv.RwTx, logger log.Logger) error {
+				return u.Done(tx)
+			},
+		},
+	}
+	state := New(s, []stages.SyncStage{s[1].ID, s[0].ID}, nil, log.New())
+	assert.NoError(t, state.Run(db, tx, true /* initialCycle */))
+
+	badBlock, err := rawdb.ReadBadBlock(tx, block.Hash())
+	assert.NoError(t, err)
+	assert.NotNil(t, badBlock)
+	assert.Equal(t, block.Hash(), badBlock.Block.Hash())
+	assert.Equal(t, "invalid state root", badBlock.Reason)
+	assert.Nil(t, rawdb.ReadHeader(tx, block.Hash(), block.NumberU64()))
+}
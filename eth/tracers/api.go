@@ -3,6 +3,7 @@ package tracers
 import (
 	"encoding/json"
 
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
 	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
 	"github.com/tenderly/erigon/eth/tracers/logger"
 	"github.com/tenderly/erigon/turbo/adapter/ethapi"
@@ -22,3 +23,15 @@ type TraceConfig struct {
 	BorTx           *bool
 	TxIndex         *hexutil.Uint
 }
+
+// TraceChainConfig holds extra parameters to the debug_traceChain subscription.
+type TraceChainConfig struct {
+	TraceConfig
+	Threads *uint64 // Number of blocks traced in parallel, defaults to the number of CPUs
+}
+
+// StdTraceConfig holds extra parameters to the standard-json trace functions.
+type StdTraceConfig struct {
+	logger.LogConfig
+	TxHash *libcommon.Hash // Only trace the transaction with this hash, defaults to all the transactions of the block
+}
@@ -0,0 +1,172 @@
+package tracetest
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
+
+	"github.com/tenderly/erigon/common"
+	"github.com/tenderly/erigon/core"
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/core/vm/evmtypes"
+	"github.com/tenderly/erigon/eth/tracers"
+	"github.com/tenderly/erigon/tests"
+	"github.com/tenderly/erigon/turbo/stages/mock"
+)
+
+// flatCallTrace is the subset of a flatCallTracer frame checked by the tests.
+type flatCallTrace struct {
+	Action struct {
+		CallType string            `json:"callType"`
+		From     libcommon.Address `json:"from"`
+		To       libcommon.Address `json:"to"`
+		Gas      hexutil.Uint64    `json:"gas"`
+	} `json:"action"`
+	BlockNumber     uint64          `json:"blockNumber"`
+	TransactionHash *libcommon.Hash `json:"transactionHash"`
+	Error           string          `json:"error"`
+	Subtraces       int             `json:"subtraces"`
+	TraceAddress    []int           `json:"traceAddress"`
+	Type            string          `json:"type"`
+}
+
+// flattenCallTrace converts the expected output of callTracer into the
+// frames which flatCallTracer must produce.
+func flattenCallTrace(call *callTrace, traceAddress []int) []flatCallTrace {
+	var frame flatCallTrace
+	frame.TraceAddress = traceAddress
+	frame.Subtraces = len(call.Calls)
+	frame.Error = call.Error
+	switch call.Type {
+	case "CREATE", "CREATE2":
+		frame.Type = "create"
+		frame.Action.From = call.From
+	case "SELFDESTRUCT":
+		frame.Type = "suicide"
+	default:
+		frame.Type = "call"
+		frame.Action.CallType = strings.ToLower(call.Type)
+		frame.Action.From = call.From
+		frame.Action.To = call.To
+	}
+	if call.Gas != nil && frame.Type != "suicide" {
+		frame.Action.Gas = *call.Gas
+	}
+	res := []flatCallTrace{frame}
+	for i := range call.Calls {
+		child := append(append([]int{}, traceAddress...), i)
+		res = append(res, flattenCallTrace(&call.Calls[i], child)...)
+	}
+	return res
+}
+
+func runFlatCallTracer(t *testing.T, test *callTracerTest, tracerCtx *tracers.Context, cfg string) []flatCallTrace {
+	tx, err := types.UnmarshalTransactionFromBinary(common.FromHex(test.Input))
+	require.NoError(t, err)
+	var (
+		signer    = types.MakeSigner(test.Genesis.Config, uint64(test.Context.Number), uint64(test.Context.Time))
+		origin, _ = signer.Sender(tx)
+		txContext = evmtypes.TxContext{
+			Origin:   origin,
+			GasPrice: tx.GetPrice(),
+		}
+		context = evmtypes.BlockContext{
+			CanTransfer: core.CanTransfer,
+			Transfer:    core.Transfer,
+			Coinbase:    test.Context.Miner,
+			BlockNumber: uint64(test.Context.Number),
+			Time:        uint64(test.Context.Time),
+			Difficulty:  (*big.Int)(test.Context.Difficulty),
+			GasLimit:    uint64(test.Context.GasLimit),
+		}
+		rules = test.Genesis.Config.Rules(context.BlockNumber, context.Time)
+	)
+	m := mock.Mock(t)
+	dbTx, err := m.DB.BeginRw(m.Ctx)
+	require.NoError(t, err)
+	defer dbTx.Rollback()
+	statedb, _ := tests.MakePreState(rules, dbTx, test.Genesis.Alloc, uint64(test.Context.Number))
+	if test.Genesis.BaseFee != nil {
+		context.BaseFee, _ = uint256.FromBig(test.Genesis.BaseFee)
+	}
+	tracer, err := tracers.New("flatCallTracer", tracerCtx, json.RawMessage(cfg))
+	require.NoError(t, err)
+	evm := vm.NewEVM(context, txContext, statedb, test.Genesis.Config, vm.Config{Debug: true, Tracer: tracer})
+	msg, err := tx.AsMessage(*signer, test.Genesis.BaseFee, rules)
+	require.NoError(t, err)
+	_, err = core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(tx.GetGas()).AddBlobGas(tx.GetBlobGas()), true /* refunds */, false /* gasBailout */)
+	require.NoError(t, err)
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	var frames []flatCallTrace
+	require.NoError(t, json.Unmarshal(res, &frames))
+	return frames
+}
+
+func readCallTracerTest(t *testing.T, name string) *callTracerTest {
+	blob, err := os.ReadFile(filepath.Join("testdata", "call_tracer", name))
+	require.NoError(t, err)
+	test := new(callTracerTest)
+	require.NoError(t, json.Unmarshal(blob, test))
+	return test
+}
+
+// The flat tracer must produce exactly the frames of the nested call tracer,
+// in depth-first order, when precompiles are included.
+func TestFlatCallTracerNative(t *testing.T) {
+	files, err := os.ReadDir(filepath.Join("testdata", "call_tracer"))
+	require.NoError(t, err)
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		file := file // capture range variable
+		t.Run(camel(strings.TrimSuffix(file.Name(), ".json")), func(t *testing.T) {
+			t.Parallel()
+			test := readCallTracerTest(t, file.Name())
+			if strings.Contains(string(test.TracerConfig), "onlyTopCall") {
+				t.Skip("flatCallTracer always traces all the call frames")
+			}
+			have := runFlatCallTracer(t, test, new(tracers.Context), `{"includePrecompiles":true}`)
+			want := flattenCallTrace(test.Result, []int{})
+			require.Equal(t, len(want), len(have))
+			for i := range want {
+				require.Equal(t, want[i].Type, have[i].Type, "frame %d", i)
+				require.Equal(t, want[i].Action.CallType, have[i].Action.CallType, "frame %d", i)
+				require.Equal(t, want[i].Action.From, have[i].Action.From, "frame %d", i)
+				require.Equal(t, want[i].Action.To, have[i].Action.To, "frame %d", i)
+				require.Equal(t, want[i].Action.Gas, have[i].Action.Gas, "frame %d", i)
+				require.Equal(t, want[i].Error, have[i].Error, "frame %d", i)
+				require.Equal(t, want[i].Subtraces, have[i].Subtraces, "frame %d", i)
+				require.Equal(t, want[i].TraceAddress, have[i].TraceAddress, "frame %d", i)
+			}
+		})
+	}
+}
+
+func TestFlatCallTracerContextAndParityErrors(t *testing.T) {
+	test := readCallTracerTest(t, "revert.json")
+	txHash := libcommon.HexToHash("0x01")
+	tracerCtx := &tracers.Context{BlockNumber: big.NewInt(42), TxHash: txHash, TxIndex: 3}
+
+	frames := runFlatCallTracer(t, test, tracerCtx, `{}`)
+	require.Len(t, frames, 1)
+	require.Equal(t, vm.ErrExecutionReverted.Error(), frames[0].Error)
+	require.Equal(t, uint64(42), frames[0].BlockNumber)
+	require.Equal(t, txHash, *frames[0].TransactionHash)
+
+	frames = runFlatCallTracer(t, test, tracerCtx, `{"convertParityErrors":true}`)
+	require.Len(t, frames, 1)
+	require.Equal(t, "Reverted", frames[0].Error)
+}
@@ -0,0 +1,136 @@
+package tracetest
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/vm"
+)
+
+var (
+	gasProfilerA = libcommon.HexToAddress("0x00000000000000000000000000000000000000aa")
+	gasProfilerB = libcommon.HexToAddress("0x00000000000000000000000000000000000000bb")
+)
+
+// gasProfilerTx runs a transaction sending 5 wei to the contract a, which stores 1 in the mapping at slot 0
+// under the caller, clears slot 1 (for the refund) and sends 1 wei to the contract b, which writes slot 0 and reverts
+func gasProfilerTx(t *testing.T, tracerConfig json.RawMessage) json.RawMessage {
+	codeA := []byte{
+		byte(vm.CALLER), byte(vm.PUSH1), 0x0, byte(vm.MSTORE),
+		byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x20, byte(vm.MSTORE),
+		byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x40, byte(vm.PUSH1), 0x0, byte(vm.KECCAK256), byte(vm.SSTORE),
+		byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x1, byte(vm.SSTORE),
+		byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0xbb, byte(vm.GAS), byte(vm.CALL),
+		byte(vm.STOP),
+	}
+	codeB := []byte{
+		byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.SSTORE),
+		byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.REVERT),
+	}
+	res, _, _ := traceTestTx(t, "gasProfiler", nil, tracerConfig, gasProfilerA, types.GenesisAlloc{
+		gasProfilerA: types.GenesisAccount{Nonce: 1, Code: codeA, Balance: big.NewInt(0), Storage: map[libcommon.Hash]libcommon.Hash{{31: 1}: {31: 1}}},
+		gasProfilerB: types.GenesisAccount{Nonce: 1, Code: codeB, Balance: big.NewInt(0)},
+	})
+	return res
+}
+
+func TestGasProfiler(t *testing.T) {
+	res := gasProfilerTx(t, nil)
+	var profile struct {
+		GasLimit        uint64 `json:"gasLimit"`
+		GasUsed         uint64 `json:"gasUsed"`
+		IntrinsicGas    uint64 `json:"intrinsicGas"`
+		ExecutionGas    uint64 `json:"executionGas"`
+		MemoryExpansion uint64 `json:"memoryExpansion"`
+		RefundCounter   uint64 `json:"refundCounter"`
+		Refund          uint64 `json:"refund"`
+		Opcodes         map[string]struct {
+			Count  uint64 `json:"count"`
+			Gas    uint64 `json:"gas"`
+			Memory uint64 `json:"memory"`
+			Refund int64  `json:"refund"`
+		} `json:"opcodes"`
+		Frames []struct {
+			Type         string `json:"type"`
+			TraceAddress []int  `json:"traceAddress"`
+			Gas          uint64 `json:"gas"`
+			GasUsed      uint64 `json:"gasUsed"`
+			Exclusive    uint64 `json:"exclusive"`
+			Error        string `json:"error"`
+		} `json:"frames"`
+		Contracts map[string]struct {
+			Calls uint64 `json:"calls"`
+			Gas   uint64 `json:"gas"`
+		} `json:"contracts"`
+		PCs []struct {
+			PC  uint64 `json:"pc"`
+			Op  string `json:"op"`
+			Gas uint64 `json:"gas"`
+		} `json:"pcs"`
+	}
+	require.NoError(t, json.Unmarshal(res, &profile))
+
+	require.Equal(t, uint64(100000), profile.GasLimit)
+	require.Equal(t, uint64(21000), profile.IntrinsicGas)
+	require.Equal(t, profile.IntrinsicGas+profile.ExecutionGas-profile.Refund, profile.GasUsed)
+	// slot 1 of a is cleared, the refund is below the cap of half of the gas used
+	require.Equal(t, uint64(15000), profile.RefundCounter)
+	require.Equal(t, uint64(15000), profile.Refund)
+	require.Less(t, profile.Refund, (profile.IntrinsicGas+profile.ExecutionGas)/2)
+	// two words of memory are used by a
+	require.Equal(t, uint64(6), profile.MemoryExpansion)
+	require.Equal(t, uint64(6), profile.Opcodes["MSTORE"].Memory)
+	require.Equal(t, uint64(2), profile.Opcodes["MSTORE"].Count)
+	require.Equal(t, int64(15000), profile.Opcodes["SSTORE"].Refund)
+	require.Equal(t, uint64(3), profile.Opcodes["SSTORE"].Count)
+
+	require.Len(t, profile.Frames, 2)
+	require.Equal(t, "CALL", profile.Frames[0].Type)
+	require.Equal(t, []int{0}, profile.Frames[1].TraceAddress)
+	require.Equal(t, "execution reverted", profile.Frames[1].Error)
+	require.Equal(t, profile.ExecutionGas, profile.Frames[0].GasUsed)
+	require.Equal(t, profile.Frames[0].GasUsed, profile.Frames[0].Exclusive+profile.Frames[1].GasUsed)
+	require.Equal(t, profile.Frames[1].GasUsed, profile.Frames[1].Exclusive)
+
+	var opcodes, contracts, pcs uint64
+	for _, op := range profile.Opcodes {
+		opcodes += op.Gas
+	}
+	for _, code := range profile.Contracts {
+		require.Equal(t, uint64(1), code.Calls)
+		contracts += code.Gas
+	}
+	for i, pc := range profile.PCs {
+		if i > 0 {
+			require.LessOrEqual(t, pc.Gas, profile.PCs[i-1].Gas)
+		}
+		pcs += pc.Gas
+	}
+	require.Len(t, profile.Contracts, 2)
+	require.Equal(t, profile.ExecutionGas, opcodes)
+	require.Equal(t, profile.ExecutionGas, contracts)
+	require.Equal(t, profile.ExecutionGas, pcs)
+	require.Equal(t, "SSTORE", profile.PCs[0].Op)
+
+	res = gasProfilerTx(t, json.RawMessage(`{"folded":true}`))
+	var folded string
+	require.NoError(t, json.Unmarshal(res, &folded))
+	var total uint64
+	for _, line := range strings.Split(strings.TrimSuffix(folded, "\n"), "\n") {
+		i := strings.LastIndexByte(line, ' ')
+		require.Positive(t, i, line)
+		gas, err := strconv.ParseUint(line[i+1:], 10, 64)
+		require.NoError(t, err)
+		total += gas
+	}
+	require.Contains(t, folded, "CALL:"+gasProfilerA.Hex()+";[intrinsic] 21000\n")
+	require.Contains(t, folded, "CALL:"+gasProfilerA.Hex()+";CALL:"+gasProfilerB.Hex()+";SSTORE 20000\n")
+	require.Equal(t, profile.IntrinsicGas+profile.ExecutionGas, total)
+}
@@ -0,0 +1,119 @@
+package tracetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutility"
+
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/crypto"
+	"github.com/tenderly/erigon/eth/tracers"
+	"github.com/tenderly/erigon/params"
+)
+
+type stateChange struct {
+	Kind         string            `json:"kind"`
+	Address      libcommon.Address `json:"address"`
+	Slot         *libcommon.Hash   `json:"slot"`
+	Preimage     hexutility.Bytes  `json:"preimage"`
+	From         libcommon.Hash    `json:"from"`
+	To           libcommon.Hash    `json:"to"`
+	Cause        string            `json:"cause"`
+	TraceAddress []int             `json:"traceAddress"`
+}
+
+func TestStateDiffTracer(t *testing.T) {
+	var (
+		a = libcommon.HexToAddress("0x00000000000000000000000000000000000000aa")
+		b = libcommon.HexToAddress("0x00000000000000000000000000000000000000bb")
+	)
+	// a stores 1 in the mapping at slot 0 under the caller and sends 1 wei to b, which reverts
+	codeA := []byte{
+		byte(vm.CALLER), byte(vm.PUSH1), 0x0, byte(vm.MSTORE),
+		byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x20, byte(vm.MSTORE),
+		byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x40, byte(vm.PUSH1), 0x0, byte(vm.KECCAK256), byte(vm.SSTORE),
+		byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0xbb, byte(vm.GAS), byte(vm.CALL),
+		byte(vm.STOP),
+	}
+	codeB := []byte{
+		byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.SSTORE),
+		byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.REVERT),
+	}
+	// the withdrawals are credited after the transaction, b gets one and then the other
+	withdrawals := types.Withdrawals{{Index: 1, Address: b, Amount: 2}, {Index: 2, Address: b, Amount: 3}}
+	res, origin, statedb := traceTestTx(t, "stateDiffTracer", &tracers.Context{Withdrawals: withdrawals}, nil, a, types.GenesisAlloc{
+		a: types.GenesisAccount{Nonce: 1, Code: codeA, Balance: big.NewInt(0)},
+		b: types.GenesisAccount{Nonce: 1, Code: codeB, Balance: big.NewInt(0)},
+	})
+
+	var changes []stateChange
+	require.NoError(t, json.Unmarshal(res, &changes))
+	var have []string
+	for _, c := range changes {
+		have = append(have, fmt.Sprintf("%s %s %x %v", c.Cause, c.Kind, c.Address, c.TraceAddress))
+	}
+	require.Equal(t, []string{
+		fmt.Sprintf("GAS_PURCHASE balance %x []", origin),
+		fmt.Sprintf("NONCE nonce %x []", origin),
+		fmt.Sprintf("CALL balance %x []", origin),
+		fmt.Sprintf("CALL balance %x []", a),
+		fmt.Sprintf("SSTORE storage %x []", a),
+		fmt.Sprintf("CALL balance %x []", a),
+		fmt.Sprintf("CALL balance %x []", b),
+		fmt.Sprintf("SSTORE storage %x [0]", b),
+		fmt.Sprintf("REVERT balance %x [0]", a),
+		fmt.Sprintf("REVERT balance %x [0]", b),
+		fmt.Sprintf("REVERT storage %x [0]", b),
+		fmt.Sprintf("GAS_REFUND balance %x []", origin),
+		fmt.Sprintf("FEE balance %x []", testCoinbase),
+		fmt.Sprintf("WITHDRAWAL balance %x []", b),
+		fmt.Sprintf("WITHDRAWAL balance %x []", b),
+	}, have)
+	require.Equal(t, libcommon.Hash(uint256.NewInt(5*params.GWei).Bytes32()), changes[len(changes)-1].To)
+	for _, w := range withdrawals {
+		statedb.AddBalance(w.Address, new(uint256.Int).Mul(uint256.NewInt(w.Amount), uint256.NewInt(params.GWei)))
+	}
+
+	// the mapping slot comes with the caller and the slot of the mapping
+	preimage := append(libcommon.BytesToHash(origin[:]).Bytes(), make([]byte, 32)...)
+	require.Equal(t, crypto.Keccak256Hash(preimage), *changes[4].Slot)
+	require.Equal(t, hexutility.Bytes(preimage), changes[4].Preimage)
+	require.Nil(t, changes[7].Preimage)
+
+	// every change starts from where the previous one of the same key ended, and the last one matches the state
+	type key struct {
+		kind    string
+		address libcommon.Address
+		slot    libcommon.Hash
+	}
+	last := map[key]libcommon.Hash{}
+	for i, c := range changes {
+		k := key{kind: c.Kind, address: c.Address}
+		if c.Slot != nil {
+			k.slot = *c.Slot
+		}
+		if prev, ok := last[k]; ok {
+			require.Equal(t, prev, c.From, "change %d", i)
+		}
+		last[k] = c.To
+	}
+	for k, v := range last {
+		var value uint256.Int
+		switch k.kind {
+		case "balance":
+			value.Set(statedb.GetBalance(k.address))
+		case "nonce":
+			value.SetUint64(statedb.GetNonce(k.address))
+		case "storage":
+			statedb.GetState(k.address, &k.slot, &value)
+		}
+		require.Equal(t, libcommon.Hash(value.Bytes32()), v, "%s of %x", k.kind, k.address)
+	}
+}
@@ -0,0 +1,75 @@
+package tracetest
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+
+	"github.com/tenderly/erigon/core"
+	"github.com/tenderly/erigon/core/state"
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/core/vm/evmtypes"
+	"github.com/tenderly/erigon/crypto"
+	"github.com/tenderly/erigon/eth/tracers"
+	"github.com/tenderly/erigon/params"
+	"github.com/tenderly/erigon/tests"
+	"github.com/tenderly/erigon/turbo/stages/mock"
+)
+
+var testCoinbase = libcommon.HexToAddress("0x00000000000000000000000000000000000000cc")
+
+// traceTestTx runs a transaction of the test account sending 5 wei to `to` with the tracer, in block 8000000 of
+// mainnet on top of alloc, to which the balance of the sender is added. Returns the result of the tracer, the sender
+// and the state after the transaction
+func traceTestTx(t *testing.T, tracerName string, tracerCtx *tracers.Context, tracerConfig json.RawMessage, to libcommon.Address, alloc types.GenesisAlloc) (json.RawMessage, libcommon.Address, *state.IntraBlockState) {
+	privkey, err := crypto.HexToECDSA("0000000000000000deadbeef00000000000000000000000000000000deadbeef")
+	require.NoError(t, err)
+	signer := types.LatestSigner(params.MainnetChainConfig)
+	tx, err := types.SignNewTx(privkey, *signer, &types.LegacyTx{
+		GasPrice: uint256.NewInt(1),
+		CommonTx: types.CommonTx{
+			Gas:   100000,
+			To:    &to,
+			Value: uint256.NewInt(5),
+		},
+	})
+	require.NoError(t, err)
+	origin, _ := signer.Sender(tx)
+	txContext := evmtypes.TxContext{
+		Origin:   origin,
+		GasPrice: uint256.NewInt(1),
+	}
+	context := evmtypes.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		Coinbase:    testCoinbase,
+		BlockNumber: 8000000,
+		Time:        5,
+		Difficulty:  big.NewInt(0x30000),
+		GasLimit:    uint64(6000000),
+	}
+	alloc[origin] = types.GenesisAccount{Balance: big.NewInt(500000000000000)}
+	rules := params.MainnetChainConfig.Rules(context.BlockNumber, context.Time)
+	m := mock.Mock(t)
+	dbTx, err := m.DB.BeginRw(m.Ctx)
+	require.NoError(t, err)
+	t.Cleanup(dbTx.Rollback)
+
+	statedb, _ := tests.MakePreState(rules, dbTx, alloc, context.BlockNumber)
+	tracer, err := tracers.New(tracerName, tracerCtx, tracerConfig)
+	require.NoError(t, err)
+	evm := vm.NewEVM(context, txContext, statedb, params.MainnetChainConfig, vm.Config{Debug: true, Tracer: tracer})
+	msg, err := tx.AsMessage(*signer, nil, rules)
+	require.NoError(t, err)
+	st := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(tx.GetGas()).AddBlobGas(tx.GetBlobGas()))
+	_, err = st.TransitionDb(true /* refunds */, false /* gasBailout */)
+	require.NoError(t, err)
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	return res, origin, statedb
+}
@@ -0,0 +1,373 @@
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/eth/tracers"
+	"github.com/tenderly/erigon/params"
+)
+
+func init() {
+	register("gasProfiler", newGasProfiler)
+}
+
+type gasProfilerConfig struct {
+	Folded bool `json:"folded"` // If true, the result is the gas of the opcodes per call stack in the folded stack format of flame graphs
+}
+
+// opcodeGas is the gas spent by the executions of an opcode. Memory is the part of the gas paid
+// for the memory expansion, Refund is the change of the refund counter.
+type opcodeGas struct {
+	Count  uint64 `json:"count"`
+	Gas    uint64 `json:"gas"`
+	Memory uint64 `json:"memory,omitempty"`
+	Refund int64  `json:"refund,omitempty"`
+}
+
+type pcGas struct {
+	CodeHash libcommon.Hash `json:"codeHash"`
+	PC       uint64         `json:"pc"`
+	Op       string         `json:"op"`
+	Count    uint64         `json:"count"`
+	Gas      uint64         `json:"gas"`
+}
+
+type codeGas struct {
+	Calls uint64 `json:"calls"`
+	Gas   uint64 `json:"gas"`
+}
+
+// gasFrame is a call frame. GasUsed includes the gas used by the subcalls, Exclusive doesn't.
+type gasFrame struct {
+	Type         string            `json:"type"`
+	From         libcommon.Address `json:"from"`
+	To           libcommon.Address `json:"to"`
+	CodeHash     *libcommon.Hash   `json:"codeHash,omitempty"`
+	TraceAddress []int             `json:"traceAddress"`
+	Gas          uint64            `json:"gas"`
+	GasUsed      uint64            `json:"gasUsed"`
+	Exclusive    uint64            `json:"exclusive"`
+	Memory       uint64            `json:"memory,omitempty"`
+	Refund       int64             `json:"refund,omitempty"`
+	Error        string            `json:"error,omitempty"`
+	calls        int               // number of subcalls
+	path         string            // call stack in the folded format
+	refund       uint64            // refund counter when the frame is entered
+	step         *gasStep          // last executed instruction, settled when the next one starts or the frame ends
+	childGasUsed uint64            // gas used by the subcalls of the last instruction
+	folded       map[string]uint64 // opcode -> gas, the gas spent without instructions under ""
+}
+
+type gasStep struct {
+	pc       uint64
+	op       vm.OpCode
+	gas      uint64
+	memory   uint64 // memory size before the instruction
+	memEnd   uint64 // memory size required by RETURN and REVERT, which end the frame
+	refund   int64  // change of the refund counter, made when the gas of the instruction is charged
+	codeHash libcommon.Hash
+}
+
+type gasProfilerResult struct {
+	GasLimit        uint64                      `json:"gasLimit"`
+	GasUsed         uint64                      `json:"gasUsed"`
+	IntrinsicGas    uint64                      `json:"intrinsicGas"`
+	ExecutionGas    uint64                      `json:"executionGas"`
+	MemoryExpansion uint64                      `json:"memoryExpansion"`
+	RefundCounter   uint64                      `json:"refundCounter"`
+	Refund          uint64                      `json:"refund"` // applied refund, capped by the gas used
+	Opcodes         map[string]*opcodeGas       `json:"opcodes"`
+	Frames          []*gasFrame                 `json:"frames"`
+	Contracts       map[libcommon.Hash]*codeGas `json:"contracts"`
+	PCs             []*pcGas                    `json:"pcs"`
+}
+
+// gasProfiler aggregates the gas spent by a transaction by opcode, by call frame, by contract code
+// and by program counter. The gas of the calls made by an instruction is counted in the subcalls,
+// not in the instruction.
+type gasProfiler struct {
+	noopTracer
+	env       *vm.EVM
+	config    gasProfilerConfig
+	result    gasProfilerResult
+	frames    []*gasFrame
+	pcs       map[libcommon.Hash]map[uint64]*pcGas
+	leftover  uint64 // gas left after the execution, before the refund
+	refund    uint64 // refund counter at the last instruction
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+func newGasProfiler(ctx *tracers.Context, cfg json.RawMessage) (tracers.Tracer, error) {
+	var config gasProfilerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	return &gasProfiler{
+		config: config,
+		result: gasProfilerResult{
+			Opcodes:   map[string]*opcodeGas{},
+			Frames:    []*gasFrame{},
+			Contracts: map[libcommon.Hash]*codeGas{},
+			PCs:       []*pcGas{},
+		},
+		pcs: map[libcommon.Hash]map[uint64]*pcGas{},
+	}, nil
+}
+
+// CaptureTxStart implements the EVMLogger interface to initialize the tracing operation.
+func (t *gasProfiler) CaptureTxStart(gasLimit uint64) {
+	t.result.GasLimit = gasLimit
+}
+
+// CaptureStart implements the EVMLogger interface to initialize the tracing operation.
+func (t *gasProfiler) CaptureStart(env *vm.EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.env = env
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+	if t.result.GasLimit > gas {
+		t.result.IntrinsicGas = t.result.GasLimit - gas
+	}
+	t.enter(typ, from, to, gas)
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *gasProfiler) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	if frame := t.exit(gasUsed, err); frame != nil {
+		t.result.ExecutionGas = frame.GasUsed
+		t.leftover = frame.Gas - frame.GasUsed
+		t.result.RefundCounter = t.env.IntraBlockState().GetRefund()
+	}
+}
+
+// CaptureState implements the EVMLogger interface to trace a single step of VM execution.
+func (t *gasProfiler) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 || len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	memory := uint64(scope.Memory.Len())
+	if frame.step != nil {
+		t.settle(frame, gas, memory)
+	}
+	refund := t.env.IntraBlockState().GetRefund()
+	step := &gasStep{pc: pc, op: op, gas: gas, memory: memory, refund: int64(refund) - int64(t.refund), codeHash: scope.Contract.CodeHash}
+	t.refund = refund
+	if stackData := scope.Stack.StackData; len(stackData) >= 2 && (op == vm.RETURN || op == vm.REVERT) {
+		offset, size := stackData[len(stackData)-1], stackData[len(stackData)-2]
+		if !size.IsZero() && offset.IsUint64() && size.IsUint64() {
+			step.memEnd = offset.Uint64() + size.Uint64()
+		}
+	}
+	if frame.CodeHash == nil && step.codeHash != (libcommon.Hash{}) {
+		codeHash := step.codeHash
+		frame.CodeHash = &codeHash
+	}
+	frame.step = step
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *gasProfiler) CaptureEnter(typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.enter(typ, from, to, gas)
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *gasProfiler) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.exit(gasUsed, err)
+}
+
+// CaptureTxEnd implements the EVMLogger interface to finalize the tracing.
+func (t *gasProfiler) CaptureTxEnd(restGas uint64) {
+	if t.result.GasLimit >= restGas {
+		t.result.GasUsed = t.result.GasLimit - restGas
+	}
+	if restGas > t.leftover {
+		t.result.Refund = restGas - t.leftover
+	}
+}
+
+// GetResult returns the json-encoded gas profile, and any error arising
+// from the encoding or forceful termination (via `Stop`).
+func (t *gasProfiler) GetResult() (json.RawMessage, error) {
+	var res []byte
+	var err error
+	if t.config.Folded {
+		res, err = json.Marshal(t.folded())
+	} else {
+		sort.SliceStable(t.result.PCs, func(i, j int) bool { return t.result.PCs[i].Gas > t.result.PCs[j].Gas })
+		res, err = json.Marshal(t.result)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(res), t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *gasProfiler) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+func (t *gasProfiler) enter(typ vm.OpCode, from, to libcommon.Address, gas uint64) {
+	frame := &gasFrame{
+		Type:         typ.String(),
+		From:         from,
+		To:           to,
+		TraceAddress: []int{},
+		Gas:          gas,
+		path:         fmt.Sprintf("%s:%s", typ, to.Hex()),
+		refund:       t.env.IntraBlockState().GetRefund(),
+		folded:       map[string]uint64{},
+	}
+	t.refund = frame.refund
+	if len(t.frames) > 0 {
+		parent := t.frames[len(t.frames)-1]
+		frame.TraceAddress = append(append(frame.TraceAddress, parent.TraceAddress...), parent.calls)
+		frame.path = parent.path + ";" + frame.path
+		parent.calls++
+	}
+	t.frames = append(t.frames, frame)
+	t.result.Frames = append(t.result.Frames, frame)
+}
+
+func (t *gasProfiler) exit(gasUsed uint64, err error) *gasFrame {
+	if len(t.frames) == 0 {
+		return nil
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	frame.GasUsed = gasUsed
+	if err != nil {
+		frame.Error = err.Error()
+	}
+	t.refund = t.env.IntraBlockState().GetRefund()
+	frame.Refund = int64(t.refund) - int64(frame.refund)
+	if step := frame.step; step != nil {
+		// The frame ended with the last instruction, the memory is only expanded by a successful RETURN or REVERT
+		memory := step.memory
+		if step.memEnd > memory && (err == nil || errors.Is(err, vm.ErrExecutionReverted)) {
+			memory = step.memEnd
+		}
+		var gasLeft uint64
+		if frame.Gas > gasUsed {
+			gasLeft = frame.Gas - gasUsed
+		}
+		t.settle(frame, gasLeft, memory)
+		frame.step = nil
+	} else if frame.calls == 0 {
+		// precompiles and accounts without code spend the gas without executing instructions
+		frame.Exclusive = gasUsed
+		frame.folded[""] = gasUsed
+	}
+	if frame.CodeHash != nil {
+		code := t.result.Contracts[*frame.CodeHash]
+		if code == nil {
+			code = &codeGas{}
+			t.result.Contracts[*frame.CodeHash] = code
+		}
+		code.Calls++
+		code.Gas += frame.Exclusive
+	}
+	if len(t.frames) > 0 {
+		t.frames[len(t.frames)-1].childGasUsed += gasUsed
+	}
+	return frame
+}
+
+// settle accounts the last instruction of the frame, given the gas and the memory size after it
+func (t *gasProfiler) settle(frame *gasFrame, gas uint64, memory uint64) {
+	step := frame.step
+	var spent uint64
+	if step.gas > gas {
+		spent = step.gas - gas
+	}
+	if spent > frame.childGasUsed {
+		spent -= frame.childGasUsed
+	} else {
+		spent = 0
+	}
+	frame.childGasUsed = 0
+	var memoryGas uint64
+	if memory > step.memory {
+		memoryGas = memoryGasCost(memory) - memoryGasCost(step.memory)
+	}
+
+	frame.Exclusive += spent
+	frame.Memory += memoryGas
+	frame.folded[step.op.String()] += spent
+	t.result.MemoryExpansion += memoryGas
+
+	opGas := t.result.Opcodes[step.op.String()]
+	if opGas == nil {
+		opGas = &opcodeGas{}
+		t.result.Opcodes[step.op.String()] = opGas
+	}
+	opGas.Count++
+	opGas.Gas += spent
+	opGas.Memory += memoryGas
+	opGas.Refund += step.refund
+
+	pcs := t.pcs[step.codeHash]
+	if pcs == nil {
+		pcs = map[uint64]*pcGas{}
+		t.pcs[step.codeHash] = pcs
+	}
+	pc := pcs[step.pc]
+	if pc == nil {
+		pc = &pcGas{CodeHash: step.codeHash, PC: step.pc, Op: step.op.String()}
+		pcs[step.pc] = pc
+		t.result.PCs = append(t.result.PCs, pc)
+	}
+	pc.Count++
+	pc.Gas += spent
+}
+
+// folded returns the gas of the opcodes per call stack, one "frame;frame;OPCODE gas" line each
+func (t *gasProfiler) folded() string {
+	stacks := map[string]uint64{}
+	if len(t.result.Frames) > 0 && t.result.IntrinsicGas > 0 {
+		stacks[t.result.Frames[0].path+";[intrinsic]"] = t.result.IntrinsicGas
+	}
+	for _, frame := range t.result.Frames {
+		for op, gas := range frame.folded {
+			stack := frame.path
+			if op != "" {
+				stack += ";" + op
+			}
+			stacks[stack] += gas
+		}
+	}
+	lines := make([]string, 0, len(stacks))
+	for stack, gas := range stacks {
+		if gas > 0 {
+			lines = append(lines, fmt.Sprintf("%s %d", stack, gas))
+		}
+	}
+	sort.Strings(lines)
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// memoryGasCost is the total gas paid for the memory of the given size
+func memoryGasCost(size uint64) uint64 {
+	words := (size + 31) / 32
+	return words*params.MemoryGas + words*words/params.QuadCoeffDiv
+}
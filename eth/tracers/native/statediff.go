@@ -0,0 +1,482 @@
+package native
+
+import (
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/fixedgas"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutility"
+
+	"github.com/tenderly/erigon/consensus/misc"
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/crypto"
+	"github.com/tenderly/erigon/eth/tracers"
+	"github.com/tenderly/erigon/params"
+)
+
+func init() {
+	register("stateDiffTracer", newStateDiffTracer)
+}
+
+const (
+	// maxPreimageSize is the largest KECCAK256 input remembered as a slot preimage
+	maxPreimageSize = 1024
+	// maxPreimageOffset is the largest distance of a slot from a keccak hash for the hash to be reported as its base,
+	// e.g. the field of a struct stored in a mapping or the element of a dynamic array
+	maxPreimageOffset = 1024
+)
+
+// Causes of the state changes which are not the result of an opcode. The changes made by
+// an instruction (SSTORE, CALL, CREATE, CREATE2, SELFDESTRUCT) have the name of the opcode as the cause.
+const (
+	causeGasPurchase = "GAS_PURCHASE" // the sender pays for the gas limit of the transaction
+	causeGasRefund   = "GAS_REFUND"   // the sender gets back the value of the unused gas
+	causeFee         = "FEE"          // the coinbase gets the priority fee, the burnt contract gets the base fee on bor
+	causeNonce       = "NONCE"        // the sender nonce of a non-creating transaction
+	causeRevert      = "REVERT"       // the changes of a failed call frame are undone
+	causeWithdrawal  = "WITHDRAWAL"   // the withdrawals of the block are credited after its last transaction
+)
+
+type stateDiffKind string
+
+const (
+	stateDiffBalance stateDiffKind = "balance"
+	stateDiffNonce   stateDiffKind = "nonce"
+	stateDiffCode    stateDiffKind = "code"
+	stateDiffStorage stateDiffKind = "storage"
+)
+
+type stateDiffKey struct {
+	kind    stateDiffKind
+	address libcommon.Address
+	slot    libcommon.Hash
+}
+
+// stateChange is a single change of the state. Balances and nonces are reported as 32-byte words,
+// the code as its hash.
+type stateChange struct {
+	Kind           stateDiffKind     `json:"kind"`
+	Address        libcommon.Address `json:"address"`
+	Slot           *libcommon.Hash   `json:"slot,omitempty"`
+	Preimage       hexutility.Bytes  `json:"preimage,omitempty"`       // keccak input the slot was derived from
+	PreimageOffset hexutil.Uint64    `json:"preimageOffset,omitempty"` // slot minus the keccak hash of the preimage
+	From           libcommon.Hash    `json:"from"`
+	To             libcommon.Hash    `json:"to"`
+	Cause          string            `json:"cause"`
+	TraceAddress   []int             `json:"traceAddress"`
+	PC             *hexutil.Uint64   `json:"pc,omitempty"`
+}
+
+type stateDiffFrame struct {
+	traceAddress []int
+	calls        int
+	pc           uint64 // last executed instruction
+	executed     bool
+	typ          vm.OpCode
+	create       bool
+	address      libcommon.Address
+	codeHash     libcommon.Hash // code hash of the created address before the creation
+	touched      []stateDiffKey // keys changed in the frame or its subcalls, in the order of the first change
+	seen         map[stateDiffKey]struct{}
+}
+
+// stateDiffCheck is a value read before an instruction is executed, to be compared with
+// the one after it. The change is reported in the frame of the instruction.
+type stateDiffCheck struct {
+	key     stateDiffKey
+	from    libcommon.Hash
+	cause   string
+	frame   *stateDiffFrame
+	pc      *uint64
+	entered bool // the change is made in the frame entered next and is undone when it fails
+}
+
+// stateDiffTracer reports every change of the state made by a transaction in the order of execution,
+// together with the call frame and the instruction which caused it. Storage slots derived from a keccak
+// hash computed in the transaction come with the hashed data, which is the solidity mapping key
+// followed by the slot of the mapping. Withdrawals are reported with the last transaction of a block traced
+// in full, after which they are credited. Block rewards are not reported.
+type stateDiffTracer struct {
+	noopTracer
+	env         *vm.EVM
+	withdrawals types.Withdrawals
+	from        libcommon.Address
+	gasLimit    uint64
+	changes     []*stateChange
+	last        map[stateDiffKey]libcommon.Hash // value after the last reported change
+	pending     []*stateDiffCheck
+	root        *stateDiffFrame
+	frames      []*stateDiffFrame
+	preimages   map[libcommon.Hash][]byte
+	hashes      []libcommon.Hash // keys of preimages in the order of execution
+	interrupt   uint32           // Atomic flag to signal execution interruption
+	reason      error            // Textual reason for the interruption
+}
+
+func newStateDiffTracer(ctx *tracers.Context, _ json.RawMessage) (tracers.Tracer, error) {
+	t := &stateDiffTracer{
+		changes:   []*stateChange{},
+		last:      map[stateDiffKey]libcommon.Hash{},
+		preimages: map[libcommon.Hash][]byte{},
+	}
+	if ctx != nil {
+		t.withdrawals = ctx.Withdrawals
+	}
+	return t, nil
+}
+
+func newStateDiffFrame(parent *stateDiffFrame, typ vm.OpCode, create bool, address libcommon.Address) *stateDiffFrame {
+	traceAddress := []int{}
+	if parent != nil {
+		traceAddress = append(append(traceAddress, parent.traceAddress...), parent.calls)
+		parent.calls++
+	}
+	return &stateDiffFrame{
+		traceAddress: traceAddress,
+		typ:          typ,
+		create:       create,
+		address:      address,
+		seen:         map[stateDiffKey]struct{}{},
+	}
+}
+
+func (f *stateDiffFrame) touch(key stateDiffKey) {
+	if _, ok := f.seen[key]; !ok {
+		f.seen[key] = struct{}{}
+		f.touched = append(f.touched, key)
+	}
+}
+
+func (f *stateDiffFrame) lastPC() *uint64 {
+	if !f.executed {
+		return nil
+	}
+	pc := f.pc
+	return &pc
+}
+
+// CaptureTxStart implements the EVMLogger interface to initialize the tracing operation.
+func (t *stateDiffTracer) CaptureTxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+// CaptureStart implements the EVMLogger interface to initialize the tracing operation.
+func (t *stateDiffTracer) CaptureStart(env *vm.EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.env = env
+	t.from = from
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+	t.root = newStateDiffFrame(nil, typ, create, to)
+	t.frames = []*stateDiffFrame{t.root}
+	ibs := env.IntraBlockState()
+
+	// The gas is bought and the value of a call is transferred before the execution starts,
+	// so the balances before them are derived from the current ones
+	balance := ibs.GetBalance(from)
+	paid := new(uint256.Int).Set(balance)
+	if !create && from != to {
+		paid.Add(paid, value)
+	}
+	bought := new(uint256.Int).Add(paid, t.gasCost())
+	t.record(balanceKey(from), bought.Bytes32(), paid.Bytes32(), causeGasPurchase, nil, t.root)
+	if create {
+		t.enter(t.root, from, to)
+	} else {
+		if nonce := ibs.GetNonce(from); nonce > 0 {
+			t.record(nonceKey(from), uint256.NewInt(nonce-1).Bytes32(), uint256.NewInt(nonce).Bytes32(), causeNonce, nil, t.root)
+		}
+		if from != to && !value.IsZero() {
+			received := ibs.GetBalance(to)
+			t.record(balanceKey(from), paid.Bytes32(), balance.Bytes32(), vm.CALL.String(), nil, t.root)
+			t.record(balanceKey(to), new(uint256.Int).Sub(received, value).Bytes32(), received.Bytes32(), vm.CALL.String(), nil, t.root)
+		}
+	}
+
+	// Remember the balances of the fee recipients, the fees are paid after the execution
+	t.lookup(balanceKey(env.Context.Coinbase))
+	if burntContract := env.ChainConfig().GetBurntContract(env.Context.BlockNumber); burntContract != nil {
+		t.lookup(balanceKey(*burntContract))
+	}
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *stateDiffTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.exit(err)
+}
+
+// CaptureState implements the EVMLogger interface to trace a single step of VM execution.
+func (t *stateDiffTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if err != nil || atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	t.flush(nil)
+	frame := t.frames[len(t.frames)-1]
+	frame.pc = pc
+	frame.executed = true
+
+	stackData := scope.Stack.StackData
+	stackLen := len(stackData)
+	caller := scope.Contract.Address()
+	switch {
+	case stackLen >= 2 && op == vm.KECCAK256:
+		offset, size := stackData[stackLen-1], stackData[stackLen-2]
+		if !offset.IsUint64() || !size.IsUint64() || size.Uint64() == 0 || size.Uint64() > maxPreimageSize {
+			return
+		}
+		data := scope.Memory.GetCopy(int64(offset.Uint64()), int64(size.Uint64()))
+		if len(data) == 0 {
+			// the memory is not expanded yet, the data is all zeros
+			data = make([]byte, size.Uint64())
+		}
+		hash := crypto.Keccak256Hash(data)
+		if _, ok := t.preimages[hash]; !ok {
+			t.preimages[hash] = data
+			t.hashes = append(t.hashes, hash)
+		}
+	case stackLen >= 1 && op == vm.SSTORE:
+		t.schedule(storageKey(caller, stackData[stackLen-1].Bytes32()), op.String(), frame, false)
+	case stackLen >= 3 && op == vm.CALL && !stackData[stackLen-3].IsZero():
+		to := libcommon.Address(stackData[stackLen-2].Bytes20())
+		t.schedule(balanceKey(caller), op.String(), frame, true)
+		t.schedule(balanceKey(to), op.String(), frame, true)
+	case stackLen >= 1 && op == vm.SELFDESTRUCT:
+		beneficiary := libcommon.Address(stackData[stackLen-1].Bytes20())
+		t.schedule(balanceKey(caller), op.String(), frame, false)
+		t.schedule(balanceKey(beneficiary), op.String(), frame, false)
+	}
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *stateDiffTracer) CaptureEnter(typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	parent := t.frames[len(t.frames)-1]
+	frame := newStateDiffFrame(parent, typ, create, to)
+	t.frames = append(t.frames, frame)
+	t.flush(frame)
+	if create {
+		t.enter(frame, from, to)
+	}
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *stateDiffTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.exit(err)
+}
+
+// CaptureTxEnd reports the gas refund and the fees, which are paid after the execution, and the withdrawals
+// credited after the transaction.
+func (t *stateDiffTracer) CaptureTxEnd(restGas uint64) {
+	if t.root == nil {
+		return
+	}
+	t.flush(nil)
+	t.settle(t.from, causeGasRefund)
+	t.settle(t.env.Context.Coinbase, causeFee)
+	if burntContract := t.env.ChainConfig().GetBurntContract(t.env.Context.BlockNumber); burntContract != nil {
+		t.settle(*burntContract, causeFee)
+	}
+	// the withdrawals are not applied to the state yet, the balances are computed from the last reported ones
+	for _, w := range t.withdrawals {
+		key := balanceKey(w.Address)
+		t.lookup(key)
+		from := t.last[key]
+		to := new(uint256.Int).SetBytes32(from[:])
+		to.Add(to, new(uint256.Int).Mul(uint256.NewInt(w.Amount), uint256.NewInt(params.GWei)))
+		t.record(key, from, to.Bytes32(), causeWithdrawal, nil, t.root)
+	}
+}
+
+// GetResult returns the json-encoded list of state changes, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *stateDiffTracer) GetResult() (json.RawMessage, error) {
+	res, err := json.Marshal(t.changes)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(res), t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *stateDiffTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+// enter schedules the checks of a contract creation. The nonces are incremented and the value
+// is transferred after the creation frame is entered.
+func (t *stateDiffTracer) enter(frame *stateDiffFrame, from, to libcommon.Address) {
+	cause := frame.typ.String()
+	parent := frame
+	if len(t.frames) > 1 {
+		parent = t.frames[len(t.frames)-2]
+	}
+	for _, key := range []stateDiffKey{balanceKey(from), nonceKey(from), balanceKey(to), nonceKey(to)} {
+		t.schedule(key, cause, parent, false)
+		frame.touch(key)
+	}
+	frame.codeHash = t.env.IntraBlockState().GetCodeHash(to)
+}
+
+// exit reports the code of a created contract and undoes the changes of a failed frame
+func (t *stateDiffTracer) exit(err error) {
+	if len(t.frames) == 0 {
+		return
+	}
+	t.flush(nil)
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	if frame.create {
+		t.check(codeKey(frame.address), frame.codeHash, frame.typ.String(), frame.lastPC(), frame)
+	}
+	if err != nil {
+		for _, key := range frame.touched {
+			if prev, ok := t.last[key]; ok {
+				t.record(key, prev, t.read(key), causeRevert, frame.lastPC(), frame)
+			}
+		}
+	}
+	if len(t.frames) > 0 {
+		parent := t.frames[len(t.frames)-1]
+		for _, key := range frame.touched {
+			parent.touch(key)
+		}
+	}
+}
+
+// settle reports the change of a balance since it was last seen
+func (t *stateDiffTracer) settle(address libcommon.Address, cause string) {
+	key := balanceKey(address)
+	if prev, ok := t.last[key]; ok {
+		t.record(key, prev, t.read(key), cause, nil, t.root)
+	}
+}
+
+func (t *stateDiffTracer) schedule(key stateDiffKey, cause string, frame *stateDiffFrame, entered bool) {
+	t.pending = append(t.pending, &stateDiffCheck{
+		key:     key,
+		from:    t.read(key),
+		cause:   cause,
+		frame:   frame,
+		pc:      frame.lastPC(),
+		entered: entered,
+	})
+}
+
+// flush reports the changes made by the instruction which was executed last
+func (t *stateDiffTracer) flush(entered *stateDiffFrame) {
+	for _, c := range t.pending {
+		t.check(c.key, c.from, c.cause, c.pc, c.frame)
+		if c.entered && entered != nil {
+			entered.touch(c.key)
+		}
+	}
+	t.pending = t.pending[:0]
+}
+
+func (t *stateDiffTracer) check(key stateDiffKey, from libcommon.Hash, cause string, pc *uint64, frame *stateDiffFrame) {
+	t.record(key, from, t.read(key), cause, pc, frame)
+}
+
+func (t *stateDiffTracer) record(key stateDiffKey, from, to libcommon.Hash, cause string, pc *uint64, frame *stateDiffFrame) {
+	frame.touch(key)
+	t.last[key] = to
+	if from == to {
+		return
+	}
+	change := &stateChange{
+		Kind:         key.kind,
+		Address:      key.address,
+		From:         from,
+		To:           to,
+		Cause:        cause,
+		TraceAddress: frame.traceAddress,
+		PC:           (*hexutil.Uint64)(pc),
+	}
+	if key.kind == stateDiffStorage {
+		slot := key.slot
+		change.Slot = &slot
+		change.Preimage, change.PreimageOffset = t.preimage(slot)
+	}
+	t.changes = append(t.changes, change)
+}
+
+// lookup remembers the current value, unless the key was already seen
+func (t *stateDiffTracer) lookup(key stateDiffKey) {
+	if _, ok := t.last[key]; !ok {
+		t.last[key] = t.read(key)
+	}
+}
+
+func (t *stateDiffTracer) read(key stateDiffKey) libcommon.Hash {
+	ibs := t.env.IntraBlockState()
+	switch key.kind {
+	case stateDiffBalance:
+		return ibs.GetBalance(key.address).Bytes32()
+	case stateDiffNonce:
+		return uint256.NewInt(ibs.GetNonce(key.address)).Bytes32()
+	case stateDiffCode:
+		return ibs.GetCodeHash(key.address)
+	default:
+		var value uint256.Int
+		ibs.GetState(key.address, &key.slot, &value)
+		return value.Bytes32()
+	}
+}
+
+// preimage finds the keccak hash the slot was derived from, the closest one below the slot
+func (t *stateDiffTracer) preimage(slot libcommon.Hash) (hexutility.Bytes, hexutil.Uint64) {
+	if data, ok := t.preimages[slot]; ok {
+		return data, 0
+	}
+	var (
+		found    []byte
+		offset   uint64
+		slotInt  = new(uint256.Int).SetBytes32(slot[:])
+		distance uint256.Int
+	)
+	for _, hash := range t.hashes {
+		if distance.Sub(slotInt, new(uint256.Int).SetBytes32(hash[:])); distance.IsZero() || !distance.LtUint64(maxPreimageOffset+1) {
+			continue
+		}
+		if found == nil || distance.Uint64() < offset {
+			found, offset = t.preimages[hash], distance.Uint64()
+		}
+	}
+	return found, hexutil.Uint64(offset)
+}
+
+// gasCost is the value of the gas bought by the sender, including the blob gas
+func (t *stateDiffTracer) gasCost() *uint256.Int {
+	cost := new(uint256.Int).Mul(t.env.GasPrice, uint256.NewInt(t.gasLimit))
+	if blobs := len(t.env.BlobHashes); blobs > 0 && t.env.ChainRules().IsCancun && t.env.Context.ExcessBlobGas != nil {
+		blobGasPrice, err := misc.GetBlobGasPrice(t.env.ChainConfig(), *t.env.Context.ExcessBlobGas)
+		if err == nil {
+			blobGas := uint256.NewInt(uint64(blobs) * fixedgas.BlobGasPerBlob)
+			cost.Add(cost, blobGas.Mul(blobGas, blobGasPrice))
+		}
+	}
+	return cost
+}
+
+func balanceKey(address libcommon.Address) stateDiffKey {
+	return stateDiffKey{kind: stateDiffBalance, address: address}
+}
+
+func nonceKey(address libcommon.Address) stateDiffKey {
+	return stateDiffKey{kind: stateDiffNonce, address: address}
+}
+
+func codeKey(address libcommon.Address) stateDiffKey {
+	return stateDiffKey{kind: stateDiffCode, address: address}
+}
+
+func storageKey(address libcommon.Address, slot libcommon.Hash) stateDiffKey {
+	return stateDiffKey{kind: stateDiffStorage, address: address, slot: slot}
+}
@@ -20,18 +20,22 @@ package tracers
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
 
 	libcommon "github.com/tenderly/erigon/erigon-lib/common"
 
+	"github.com/tenderly/erigon/core/types"
 	"github.com/tenderly/erigon/core/vm"
 )
 
 // Context contains some contextual infos for a transaction execution that is not
 // available from within the EVM object.
 type Context struct {
-	BlockHash libcommon.Hash // Hash of the block the tx is contained within (zero if dangling tx or call)
-	TxIndex   int            // Index of the transaction within a block (zero if dangling tx or call)
-	TxHash    libcommon.Hash // Hash of the transaction being traced (zero if dangling call)
+	BlockHash   libcommon.Hash    // Hash of the block the tx is contained within (zero if dangling tx or call)
+	BlockNumber *big.Int          // Number of the block the tx is contained within (nil if dangling tx or call)
+	TxIndex     int               // Index of the transaction within a block (zero if dangling tx or call)
+	TxHash      libcommon.Hash    // Hash of the transaction being traced (zero if dangling call)
+	Withdrawals types.Withdrawals // Credited to the balances right after the tx (only for the last tx of a block traced in full)
 }
 
 // Tracer interface extends vm.EVMLogger and additionally
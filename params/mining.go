@@ -21,4 +21,8 @@ type MiningConfig struct {
 	GasLimit   uint64            // Target gas limit for mined blocks.
 	GasPrice   *big.Int          // Minimum gas price for mining a transaction
 	Recommit   time.Duration     // The time interval for miner to re-create mining work.
+
+	Ordering       string        // Order of the pool transactions in mined blocks: pool, tip, profit or iterative
+	OrderingBudget time.Duration // Time the iterative ordering spends improving a batch of transactions
+	OrderingGain   bool          // Estimate the gain of the ordering over the pool order, simulating every batch in both orders
 }
@@ -2,34 +2,42 @@ package app
 
 import (
 	"fmt"
-	"github.com/tenderly/erigon/erigon-lib/common"
-	"os"
 	"path/filepath"
 
 	"github.com/c2h5oh/datasize"
+	"github.com/urfave/cli/v2"
+
 	"github.com/tenderly/erigon/cmd/utils"
 	"github.com/tenderly/erigon/cmd/utils/flags"
+	"github.com/tenderly/erigon/erigon-lib/common"
 	"github.com/tenderly/erigon/erigon-lib/common/datadir"
 	"github.com/tenderly/erigon/erigon-lib/common/dir"
 	"github.com/tenderly/erigon/erigon-lib/kv"
+	"github.com/tenderly/erigon/rpc"
 	"github.com/tenderly/erigon/turbo/backup"
 	"github.com/tenderly/erigon/turbo/debug"
-	"github.com/urfave/cli/v2"
 )
 
 // nolint
 var backupCommand = cli.Command{
 	Name: "alpha_backup",
 	Description: `Alpha verison of command. Backup all databases without stopping of Erigon.
-While this command has Alpha prefix - we recommend to stop Erigon for backup. 
-Limitations: 
+Each database is copied within one read transaction - so backup is consistent even if Erigon is running.
+With --incremental tables unchanged since the previous backup in --to.datadir are not written (stage progress is recorded
+in datadir/chaindata/erigon-backup.json). Every table is still read and hashed in full - on chaindata of a syncing node
+most tables change between backups, so it's close to a full copy plus one more full read.
+Check backup by alpha_backup_verify, and restore it by alpha_restore.
+Limitations:
 - no support of datadir/snapshots folder. Recommendation: backup snapshots dir manually AFTER databases backup. Possible to implement in future.
 - no support of Consensus DB (copy it manually if you need). Possible to implement in future.
 - way to pipe output to compressor (lz4/zstd). Can compress target floder later or use zfs-with-enabled-compression.
-- jwt tocken: copy it manually - if need. 
+- jwt tocken: copy it manually - if need.
 - no support of SentryDB (datadir/nodes folder). Because seems no much reason to backup it.
 
-Example: erigon backup --datadir=<your_datadir> --to.datadir=<backup_datadir>
+Example: erigon alpha_backup --datadir=<your_datadir> --to.datadir=<backup_datadir>
+Backup by admin_backup RPC (--http.api=admin) of running Erigon or its rpcdaemon, to the dir on the machine serving RPC
+(outside of its datadir; non-empty dir must hold the previous backup):
+  erigon alpha_backup --from.rpc=http://127.0.0.1:8545 --to.datadir=<backup_datadir> --incremental
 
 TODO:
 - support of Consensus DB (copy it manually if you need). Possible to implement in future.
@@ -44,6 +52,30 @@ TODO:
 		&BackupLabelsFlag,
 		&BackupTablesFlag,
 		&WarmupThreadsFlag,
+		&BackupIncrementalFlag,
+		&BackupFromRpcFlag,
+	}),
+}
+
+// nolint
+var backupVerifyCommand = cli.Command{
+	Name:        "alpha_backup_verify",
+	Description: `Check that databases of backup made by alpha_backup (in --datadir) have the tables it did copy.`,
+	Action:      doBackupVerify,
+	Flags: joinFlags([]cli.Flag{
+		&utils.DataDirFlag,
+	}),
+}
+
+// nolint
+var restoreCommand = cli.Command{
+	Name:        "alpha_restore",
+	Description: `Verify backup made by alpha_backup (in --from.datadir), and copy its databases to the new --datadir.`,
+	Action:      doRestore,
+	Flags: joinFlags([]cli.Flag{
+		&utils.DataDirFlag,
+		&FromDatadirFlag,
+		&WarmupThreadsFlag,
 	}),
 }
 
@@ -53,6 +85,11 @@ var (
 		Usage:    "Target datadir",
 		Required: true,
 	}
+	FromDatadirFlag = flags.DirectoryFlag{
+		Name:     "from.datadir",
+		Usage:    "Datadir of backup",
+		Required: true,
+	}
 	BackupLabelsFlag = cli.StringFlag{
 		Name:  "lables",
 		Usage: "Name of component to backup. Example: chaindata,txpool,downloader",
@@ -67,12 +104,20 @@ var (
 	}
 	WarmupThreadsFlag = cli.Uint64Flag{
 		Name: "warmup.threads",
-		Usage: `Erigon's db works as blocking-io: means it stops when read from disk. 
-It means backup speed depends on 'disk latency' (not throughput). 
-Can spawn many threads which will read-ahead the data and bring it to OS's PageCache. 
+		Usage: `Erigon's db works as blocking-io: means it stops when read from disk.
+It means backup speed depends on 'disk latency' (not throughput).
+Can spawn many threads which will read-ahead the data and bring it to OS's PageCache.
 CloudDrives (and ssd) have bad-latency and good-parallel-throughput - then having >1k of warmup threads will help.`,
 		Value: uint64(backup.ReadAheadThreads),
 	}
+	BackupIncrementalFlag = cli.BoolFlag{
+		Name:  "incremental",
+		Usage: "Don't write tables unchanged since the previous backup in --to.datadir (all tables are still read in full)",
+	}
+	BackupFromRpcFlag = cli.StringFlag{
+		Name:  "from.rpc",
+		Usage: "HTTP RPC (with 'admin' api) of running Erigon or its rpcdaemon - to backup chaindata by admin_backup. --to.datadir is written on the machine serving RPC (separate rpcdaemon reads chaindata over its private api)",
+	}
 )
 
 func doBackup(cliCtx *cli.Context) error {
@@ -81,9 +126,27 @@ func doBackup(cliCtx *cli.Context) error {
 		return err
 	}
 
+	ctx := cliCtx.Context
+	if cliCtx.IsSet(BackupFromRpcFlag.Name) {
+		// dirs are on the machine serving RPC - not creating them here
+		to, err := filepath.Abs(filepath.Join(cliCtx.String(ToDatadirFlag.Name), "chaindata"))
+		if err != nil {
+			return err
+		}
+		client, err := rpc.DialContext(ctx, cliCtx.String(BackupFromRpcFlag.Name), logger)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		var m backup.Marker
+		if err := client.CallContext(ctx, &m, "admin_backup", to, cliCtx.Bool(BackupIncrementalFlag.Name)); err != nil {
+			return err
+		}
+		logger.Info("backup done", "to", to, "txId", m.TxID, "incremental", m.Incremental, "tables", len(m.Tables))
+		return nil
+	}
 	defer logger.Info("backup done")
 
-	ctx := cliCtx.Context
 	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
 	toDirs := datadir.New(cliCtx.String(ToDatadirFlag.Name))
 
@@ -93,7 +156,7 @@ func doBackup(cliCtx *cli.Context) error {
 	}
 
 	var lables = []kv.Label{kv.ChainDB, kv.TxPoolDB, kv.DownloaderDB}
-	if cliCtx.IsSet(BackupToPageSizeFlag.Name) {
+	if cliCtx.IsSet(BackupLabelsFlag.Name) {
 		lables = lables[:0]
 		for _, l := range common.CliString2Array(cliCtx.String(BackupLabelsFlag.Name)) {
 			lables = append(lables, kv.UnmarshalLabel(l))
@@ -113,36 +176,83 @@ func doBackup(cliCtx *cli.Context) error {
 	//kv.SentryDB no much reason to backup
 	//TODO: add support of kv.ConsensusDB
 	for _, label := range lables {
-		var from, to string
-		switch label {
-		case kv.ChainDB:
-			from, to = dirs.Chaindata, toDirs.Chaindata
-		case kv.TxPoolDB:
-			from, to = dirs.TxPool, toDirs.TxPool
-		case kv.DownloaderDB:
-			from, to = filepath.Join(dirs.Snap, "db"), filepath.Join(toDirs.Snap, "db")
-		default:
-			panic(fmt.Sprintf("unexpected: %+v", label))
-		}
-
+		from, to := labelDir(dirs, label), labelDir(toDirs, label)
 		if !dir.Exist(from) {
 			continue
 		}
 
-		if len(tables) == 0 { // if not partial backup - just drop target dir, to make backup more compact/fast (instead of clean tables)
-			if err := os.RemoveAll(to); err != nil {
-				return fmt.Errorf("mkdir: %w, %s", err, to)
-			}
+		logger.Info("[backup] start", "label", label)
+		src := backup.OpenSource(from, label, logger)
+		_, err := backup.Backup(ctx, src, to, label, backup.Cfg{
+			Tables:           tables,
+			Incremental:      cliCtx.Bool(BackupIncrementalFlag.Name),
+			PageSize:         targetPageSize,
+			ReadAheadThreads: readAheadThreads,
+		}, logger)
+		src.Close()
+		if err != nil {
+			return err
 		}
-		if err := os.MkdirAll(to, 0740); err != nil { //owner: rw, group: r, others: -
-			return fmt.Errorf("mkdir: %w, %s", err, to)
+	}
+
+	return nil
+}
+
+func doBackupVerify(cliCtx *cli.Context) error {
+	logger, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	var verified int
+	for _, label := range []kv.Label{kv.ChainDB, kv.TxPoolDB, kv.DownloaderDB} {
+		dbDir := labelDir(dirs, label)
+		if !dir.FileExist(filepath.Join(dbDir, backup.MarkerFileName)) {
+			continue
 		}
-		logger.Info("[backup] start", "label", label)
-		fromDB, toDB := backup.OpenPair(from, to, label, targetPageSize, logger)
-		if err := backup.Kv2kv(ctx, fromDB, toDB, nil, readAheadThreads, logger); err != nil {
+		m, err := backup.Verify(cliCtx.Context, dbDir, logger)
+		if err != nil {
 			return err
 		}
+		logger.Info("[backup] ok", "label", label, "time", m.Time, "txId", m.TxID, "stages", m.Stages)
+		verified++
 	}
+	if verified == 0 {
+		return fmt.Errorf("no backup in %s", dirs.DataDir)
+	}
+	return nil
+}
 
+func doRestore(cliCtx *cli.Context) error {
+	logger, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	fromDirs := datadir.New(cliCtx.String(FromDatadirFlag.Name))
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	for _, label := range []kv.Label{kv.ChainDB, kv.TxPoolDB, kv.DownloaderDB} {
+		from := labelDir(fromDirs, label)
+		if !dir.FileExist(filepath.Join(from, backup.MarkerFileName)) {
+			continue
+		}
+		logger.Info("[restore] start", "label", label)
+		if err := backup.Restore(cliCtx.Context, from, labelDir(dirs, label), int(cliCtx.Uint64(WarmupThreadsFlag.Name)), logger); err != nil {
+			return err
+		}
+	}
+	logger.Info("restore done")
 	return nil
 }
+
+func labelDir(dirs datadir.Dirs, label kv.Label) string {
+	switch label {
+	case kv.ChainDB:
+		return dirs.Chaindata
+	case kv.TxPoolDB:
+		return dirs.TxPool
+	case kv.DownloaderDB:
+		return filepath.Join(dirs.Snap, "db")
+	default:
+		panic(fmt.Sprintf("unexpected: %+v", label))
+	}
+}
@@ -78,7 +78,9 @@ func MakeApp(name string, action cli.ActionFunc, cliFlags []cli.Flag) *cli.App {
 		&importCommand,
 		&snapshotCommand,
 		&supportCommand,
-		//&backupCommand,
+		&backupCommand,
+		&backupVerifyCommand,
+		&restoreCommand,
 	}
 	return app
 }
@@ -4,12 +4,15 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"time"
 
 	"github.com/c2h5oh/datasize"
@@ -17,10 +20,12 @@ import (
 	"github.com/tenderly/erigon/erigon-lib/common/dbg"
 	"github.com/urfave/cli/v2"
 
+	"github.com/tenderly/erigon/erigon-lib/chain/snapcfg"
 	"github.com/tenderly/erigon/erigon-lib/common"
 	"github.com/tenderly/erigon/erigon-lib/common/datadir"
 	"github.com/tenderly/erigon/erigon-lib/common/dir"
 	"github.com/tenderly/erigon/erigon-lib/compress"
+	"github.com/tenderly/erigon/erigon-lib/downloader"
 	"github.com/tenderly/erigon/erigon-lib/etl"
 	"github.com/tenderly/erigon/erigon-lib/kv"
 	"github.com/tenderly/erigon/erigon-lib/kv/kvcfg"
@@ -101,6 +106,29 @@ var snapshotCommand = cli.Command{
 			Action: doDecompressSpeed,
 			Flags:  joinFlags([]cli.Flag{&utils.DataDirFlag}),
 		},
+		{
+			Name:   "verify",
+			Action: doVerify,
+			Usage:  "Check the block snapshots of the datadir: hashes, indices, block ranges and a sample of the data",
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&utils.ChainFlag,
+				&utils.DownloaderPreverifiedFlag,
+				&SnapshotSampleFlag,
+				&SnapshotNoHashesFlag,
+				&SnapshotReportFlag,
+			}),
+		},
+		{
+			Name:   "publish",
+			Action: doPublish,
+			Usage:  "Create the .torrent files of the snapshots, and the preverified.toml and webseed.toml manifests to publish them",
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&SnapshotWebseedUrlFlag,
+				&SnapshotManifestsDirFlag,
+			}),
+		},
 		{
 			Name:   "diff",
 			Action: doDiff,
@@ -138,6 +166,27 @@ var (
 		Name:  "rebuild",
 		Usage: "Force rebuild",
 	}
+	SnapshotSampleFlag = cli.IntFlag{
+		Name:  "sample",
+		Usage: "Words decoded in each segment",
+		Value: 1_000,
+	}
+	SnapshotNoHashesFlag = cli.BoolFlag{
+		Name:  "no-hashes",
+		Usage: "Don't compare the info hashes of the files with the preverified ones and their .torrent files",
+	}
+	SnapshotWebseedUrlFlag = cli.StringFlag{
+		Name:  "webseed.url",
+		Usage: "Base URL the snapshots dir is served at, for the webseed.toml manifest. No webseed.toml without it",
+	}
+	SnapshotManifestsDirFlag = cli.PathFlag{
+		Name:  "manifests.dir",
+		Usage: "Where to write preverified.toml and webseed.toml, the snapshots dir by default",
+	}
+	SnapshotReportFlag = cli.PathFlag{
+		Name:  "report",
+		Usage: "Write the JSON report to this file instead of stdout",
+	}
 )
 
 func doDiff(cliCtx *cli.Context) error {
@@ -170,6 +219,95 @@ func doDiff(cliCtx *cli.Context) error {
 	return nil
 }
 
+func doVerify(cliCtx *cli.Context) error {
+	logger, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	if err := utils.SetPreverifiedFromFlag(cliCtx); err != nil {
+		return err
+	}
+	report, err := freezeblocks.VerifySnapshots(cliCtx.Context, dirs.Snap, freezeblocks.VerifyCfg{
+		Preverified: snapcfg.KnownCfg(cliCtx.String(utils.ChainFlag.Name), nil, nil).Preverified,
+		NoHashes:    cliCtx.Bool(SnapshotNoHashesFlag.Name),
+		Sample:      cliCtx.Int(SnapshotSampleFlag.Name),
+		Workers:     runtime.GOMAXPROCS(-1),
+	}, logger)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if path := cliCtx.String(SnapshotReportFlag.Name); path != "" {
+		if out, err = os.Create(path); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	if !report.Ok {
+		return fmt.Errorf("snapshots verification failed, see the report")
+	}
+	logger.Info("[snapshots] Verified", "files", len(report.Files))
+	return nil
+}
+
+func doPublish(cliCtx *cli.Context) error {
+	logger, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	webseedUrl := cliCtx.String(SnapshotWebseedUrlFlag.Name)
+	if webseedUrl != "" {
+		if _, err := url.ParseRequestURI(webseedUrl); err != nil {
+			return fmt.Errorf("--%s: %w", SnapshotWebseedUrlFlag.Name, err)
+		}
+	}
+	manifestsDir := cliCtx.String(SnapshotManifestsDirFlag.Name)
+	if manifestsDir == "" {
+		manifestsDir = dirs.Snap
+	}
+
+	preverified, webseeds, err := downloader.Manifests(cliCtx.Context, dirs, webseedUrl)
+	if err != nil {
+		return err
+	}
+	hashes := make(map[string]string, len(preverified))
+	for _, p := range preverified {
+		hashes[p.Name] = p.Hash
+	}
+	if err := writeManifest(filepath.Join(manifestsDir, "preverified.toml"), hashes); err != nil {
+		return err
+	}
+	if webseedUrl != "" {
+		if err := writeManifest(filepath.Join(manifestsDir, "webseed.toml"), webseeds); err != nil {
+			return err
+		}
+	}
+	logger.Info("[snapshots] Published", "files", len(preverified), "manifests", manifestsDir)
+	return nil
+}
+
+// writeManifest writes `'name' = 'value'` lines sorted by name, like the embedded preverified and webseed files
+func writeManifest(path string, entries map[string]string) error {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var buf bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&buf, "'%s' = '%s'\n", name, entries[name])
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
 func doDecompressSpeed(cliCtx *cli.Context) error {
 	logger, _, err := debug.Setup(cliCtx, true /* rootLogger */)
 	if err != nil {
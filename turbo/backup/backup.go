@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"hash"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -23,29 +24,51 @@ import (
 )
 
 func OpenPair(from, to string, label kv.Label, targetPageSize datasize.ByteSize, logger log.Logger) (kv.RoDB, kv.RwDB) {
+	src := OpenSource(from, label, logger)
+	if targetPageSize <= 0 {
+		targetPageSize = datasize.ByteSize(src.PageSize())
+	}
+	return src, OpenTarget(to, label, targetPageSize, mapSizeOf(src), logger)
+}
+
+// OpenSource - opens existing db, it may be in use by other process (running Erigon)
+func OpenSource(from string, label kv.Label, logger log.Logger) kv.RwDB {
 	const ThreadsHardLimit = 9_000
-	src := mdbx2.NewMDBX(logger).Path(from).
+	return mdbx2.NewMDBX(logger).Path(from).
 		Label(label).
 		RoTxsLimiter(semaphore.NewWeighted(ThreadsHardLimit)).
 		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(label) }).
 		Flags(func(flags uint) uint { return flags | mdbx.Accede }).
 		MustOpen()
-	if targetPageSize <= 0 {
-		targetPageSize = datasize.ByteSize(src.PageSize())
+}
+
+// mapSizeOf - upper limit of the size of `db` (0 if it's not local mdbx)
+func mapSizeOf(db kv.RoDB) datasize.ByteSize {
+	mdbxDB, ok := db.(*mdbx2.MdbxKV)
+	if !ok {
+		return 0
 	}
-	info, err := src.(*mdbx2.MdbxKV).Env().Info(nil)
+	info, err := mdbxDB.Env().Info(nil)
 	if err != nil {
 		panic(err)
 	}
-	dst := mdbx2.NewMDBX(logger).Path(to).
+	return datasize.ByteSize(info.Geo.Upper)
+}
+
+// OpenTarget - opens db to which backup is written. `mapSize` is 0 - if unknown (default of mdbx)
+func OpenTarget(to string, label kv.Label, pageSize, mapSize datasize.ByteSize, logger log.Logger) kv.RwDB {
+	opts := mdbx2.NewMDBX(logger).Path(to).
 		Label(label).
-		PageSize(targetPageSize.Bytes()).
-		MapSize(datasize.ByteSize(info.Geo.Upper)).
 		GrowthStep(8 * datasize.GB).
 		Flags(func(flags uint) uint { return flags | mdbx.WriteMap }).
-		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(label) }).
-		MustOpen()
-	return src, dst
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(label) })
+	if pageSize > 0 {
+		opts = opts.PageSize(pageSize.Bytes())
+	}
+	if mapSize > 0 {
+		opts = opts.MapSize(mapSize)
+	}
+	return opts.MustOpen()
 }
 
 func Kv2kv(ctx context.Context, src kv.RoDB, dst kv.RwDB, tables []string, readAheadThreads int, logger log.Logger) error {
@@ -73,7 +96,7 @@ func Kv2kv(ctx context.Context, src kv.RoDB, dst kv.RwDB, tables []string, readA
 		if b.IsDeprecated {
 			continue
 		}
-		if err := backupTable(ctx, src, srcTx, dst, name, readAheadThreads, logEvery, logger); err != nil {
+		if err := backupTable(ctx, src, srcTx, dst, name, readAheadThreads, nil, logEvery, logger); err != nil {
 			return err
 		}
 	}
@@ -81,7 +104,8 @@ func Kv2kv(ctx context.Context, src kv.RoDB, dst kv.RwDB, tables []string, readA
 	return nil
 }
 
-func backupTable(ctx context.Context, src kv.RoDB, srcTx kv.Tx, dst kv.RwDB, table string, readAheadThreads int, logEvery *time.Ticker, logger log.Logger) error {
+// backupTable - `h` is optional, it's fed with all copied keys and values (see hashEntry)
+func backupTable(ctx context.Context, src kv.RoDB, srcTx kv.Tx, dst kv.RwDB, table string, readAheadThreads int, h hash.Hash64, logEvery *time.Ticker, logger log.Logger) error {
 	var total uint64
 	wg := sync.WaitGroup{}
 	defer wg.Wait()
@@ -122,6 +146,9 @@ func backupTable(ctx context.Context, src kv.RoDB, srcTx kv.Tx, dst kv.RwDB, tab
 			return err
 		}
 
+		if h != nil {
+			hashEntry(h, k, v)
+		}
 		if isDupsort {
 			if err = casted.AppendDup(k, v); err != nil {
 				return err
@@ -0,0 +1,372 @@
+package backup
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc64"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/exp/maps"
+
+	"github.com/tenderly/erigon/erigon-lib/common/dir"
+	"github.com/tenderly/erigon/erigon-lib/kv"
+	mdbx2 "github.com/tenderly/erigon/erigon-lib/kv/mdbx"
+)
+
+// MarkerFileName - file in the target db dir, describing the backup made into it.
+// It's the base of the next incremental backup, and what restore/verify check the backup against
+const MarkerFileName = "erigon-backup.json"
+
+type TableMarker struct {
+	Entries uint64 `json:"entries"`
+	Hash    string `json:"hash"`   // crc64 of all keys and values, see hashEntry
+	Copied  bool   `json:"copied"` // false - table didn't change since the previous backup
+}
+
+type Marker struct {
+	Label       string                  `json:"label"`
+	TxID        uint64                  `json:"txId"` // Read transaction of the source db - backup is consistent as of it
+	Time        time.Time               `json:"time"`
+	Incremental bool                    `json:"incremental"`
+	Incomplete  bool                    `json:"incomplete,omitempty"` // backup is in progress or failed, only listed tables are complete
+	Tables      map[string]*TableMarker `json:"tables"`
+	Stages      map[string]uint64       `json:"stages,omitempty"` // Progress of the sync stages in the backup
+}
+
+// Cfg - of online backup. Zero value: full backup of all tables
+type Cfg struct {
+	Tables           []string // Empty - all tables of the db
+	Incremental      bool     // Skip tables unchanged since the previous backup in the same dir (every table of src is still read and hashed)
+	KeepDir          bool     // Never remove `to` - full backup overwrites the tables of the previous one in place
+	PageSize         datasize.ByteSize
+	ReadAheadThreads int // 0 - ReadAheadThreads
+}
+
+func ReadMarker(dbDir string) (*Marker, error) {
+	data, err := os.ReadFile(filepath.Join(dbDir, MarkerFileName))
+	if err != nil {
+		return nil, err
+	}
+	m := &Marker{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("backup marker %s: %w", dbDir, err)
+	}
+	return m, nil
+}
+
+// CheckTarget - checks that `to` can be the target of the backup of `label` for a caller which doesn't own the
+// filesystem (RPC): it must not be any of `forbidden` dirs or inside of them (datadir of the node, source db),
+// and existing non-empty dir must already contain the backup of `label`
+func CheckTarget(to string, label kv.Label, forbidden ...string) error {
+	target, err := realPath(to)
+	if err != nil {
+		return err
+	}
+	for _, f := range forbidden {
+		if f == "" {
+			continue
+		}
+		f, err := realPath(f)
+		if err != nil {
+			return err
+		}
+		if rel, err := filepath.Rel(f, target); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("backup dir %s is inside of %s", to, f)
+		}
+	}
+	entries, err := os.ReadDir(target)
+	if errors.Is(err, os.ErrNotExist) || err == nil && len(entries) == 0 {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	m, err := ReadMarker(target)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("backup dir %s is not empty and has no %s", to, MarkerFileName)
+		}
+		return err
+	}
+	if m.Label != label.String() {
+		return fmt.Errorf("%s has backup of %s, not %s", to, m.Label, label)
+	}
+	return nil
+}
+
+// realPath - absolute path with resolved symlinks, of which only the existing part of `p` can have
+func realPath(p string) (string, error) {
+	p, err := filepath.Abs(p)
+	if err != nil {
+		return "", err
+	}
+	var rest []string
+	for {
+		resolved, err := filepath.EvalSymlinks(p)
+		if err == nil {
+			return filepath.Join(append([]string{resolved}, rest...)...), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(p)
+		if parent == p {
+			return "", err
+		}
+		rest = append([]string{filepath.Base(p)}, rest...)
+		p = parent
+	}
+}
+
+func (m *Marker) write(dbDir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return dir.WriteFileWithFsync(filepath.Join(dbDir, MarkerFileName), data, 0644)
+}
+
+// hashEntry - feeds key and value to the hash of the table (with lengths - to not mix keys and values)
+func hashEntry(h hash.Hash64, k, v []byte) {
+	var numBuf [binary.MaxVarintLen64]byte
+	h.Write(numBuf[:binary.PutUvarint(numBuf[:], uint64(len(k)))])
+	h.Write(k)
+	h.Write(numBuf[:binary.PutUvarint(numBuf[:], uint64(len(v)))])
+	h.Write(v)
+}
+
+func newTableHash() hash.Hash64 { return crc64.New(crc64.MakeTable(crc64.ECMA)) }
+
+// Backup - copies tables of `src` to the db in `to` dir. Everything is read by one read transaction - so backup
+// is consistent even if `src` is db of running node. Before the first table is touched MarkerFileName in `to` is
+// marked Incomplete without the tables to copy, the complete one is written when all of them are copied. In incremental mode
+// skips tables which hash equals to the previous marker. Every table of `src` is still read and hashed in full -
+// it saves only writes of tables which didn't change at all, on chaindata of a syncing node it's close to a full
+// copy plus one more full read of `src`
+func Backup(ctx context.Context, src kv.RoDB, to string, label kv.Label, cfg Cfg, logger log.Logger) (*Marker, error) {
+	srcTx, err := src.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer srcTx.Rollback()
+	if cfg.ReadAheadThreads <= 0 {
+		cfg.ReadAheadThreads = ReadAheadThreads
+	}
+
+	if !cfg.Incremental && len(cfg.Tables) == 0 && !cfg.KeepDir { // if not partial backup - just drop target dir, to make backup more compact/fast (instead of clean tables)
+		if err := os.RemoveAll(to); err != nil {
+			return nil, fmt.Errorf("remove: %w, %s", err, to)
+		}
+	}
+	if err := os.MkdirAll(to, 0740); err != nil { //owner: rw, group: r, others: -
+		return nil, fmt.Errorf("mkdir: %w, %s", err, to)
+	}
+	prev, err := ReadMarker(to)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if prev != nil && prev.Label != label.String() {
+		return nil, fmt.Errorf("%s has backup of %s, not %s", to, prev.Label, label)
+	}
+	if cfg.Incremental && prev == nil {
+		logger.Info("[backup] no previous backup, copying all tables", "to", to)
+	}
+	if prev != nil && prev.Incomplete {
+		logger.Warn("[backup] previous backup is incomplete, copying all tables it didn't finish", "to", to)
+	}
+
+	tables := cfg.Tables
+	if len(tables) == 0 {
+		for name, b := range src.AllTables() {
+			if !b.IsDeprecated {
+				tables = append(tables, name)
+			}
+		}
+	}
+	sort.Strings(tables)
+
+	m := &Marker{Label: label.String(), TxID: srcTx.ViewID(), Time: time.Now().UTC(), Incremental: cfg.Incremental && prev != nil, Tables: map[string]*TableMarker{}}
+	if prev != nil { // tables out of partial backup are still in the target db
+		for name, t := range prev.Tables {
+			m.Tables[name] = &TableMarker{Entries: t.Entries, Hash: t.Hash}
+		}
+	}
+	// if backup fails in the middle - the next one must not trust the marker of the previous one for the tables it
+	// cleared or copied only partially
+	incomplete := &Marker{Label: m.Label, TxID: m.TxID, Time: m.Time, Incremental: m.Incremental, Incomplete: true, Tables: maps.Clone(m.Tables)}
+	for _, name := range tables {
+		delete(incomplete.Tables, name)
+	}
+	if err := incomplete.write(to); err != nil {
+		return nil, err
+	}
+
+	dst := OpenTarget(to, label, cfg.PageSize, mapSizeOf(src), logger)
+	defer dst.Close()
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+
+	var copied int
+	for _, name := range tables {
+		t := &TableMarker{Copied: true}
+		var h hash.Hash64
+		if prevT, ok := m.Tables[name]; ok && cfg.Incremental {
+			if t.Entries, t.Hash, err = hashTable(ctx, srcTx, name); err != nil {
+				return nil, err
+			}
+			if t.Entries == prevT.Entries && t.Hash == prevT.Hash {
+				prevT.Copied = false
+				continue
+			}
+		} else {
+			h = newTableHash()
+		}
+
+		if err := backupTable(ctx, src, srcTx, dst, name, cfg.ReadAheadThreads, h, logEvery, logger); err != nil {
+			return nil, fmt.Errorf("backup %s: %w", name, err)
+		}
+		if h != nil {
+			if t.Entries, err = countEntries(srcTx, name); err != nil {
+				return nil, err
+			}
+			t.Hash = hex.EncodeToString(h.Sum(nil))
+		}
+		m.Tables[name] = t
+		copied++
+	}
+	if err := dst.View(ctx, func(tx kv.Tx) error {
+		m.Stages, err = readStages(tx, m.Tables)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if err := m.write(to); err != nil {
+		return nil, err
+	}
+	logger.Info("[backup] done", "label", label, "to", to, "txId", m.TxID, "tables", len(tables), "copied", copied)
+	return m, nil
+}
+
+// readStages - progress of the stages, if backup has them (chaindata)
+func readStages(tx kv.Tx, tables map[string]*TableMarker) (map[string]uint64, error) {
+	if _, ok := tables[kv.SyncStageProgress]; !ok {
+		return nil, nil
+	}
+	stages := map[string]uint64{}
+	if err := tx.ForEach(kv.SyncStageProgress, nil, func(k, v []byte) error {
+		if len(v) == 8 {
+			stages[string(k)] = binary.BigEndian.Uint64(v)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return stages, nil
+}
+
+// hashTable - amount of entries and hash of `table`, the same as backupTable and Verify compute
+func hashTable(ctx context.Context, tx kv.Tx, table string) (entries uint64, sum string, err error) {
+	h := newTableHash()
+	if err = tx.ForEach(table, nil, func(k, v []byte) error {
+		hashEntry(h, k, v)
+		entries++
+		if entries%100_000 == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+		return nil
+	}); err != nil {
+		return 0, "", fmt.Errorf("read %s: %w", table, err)
+	}
+	return entries, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func countEntries(tx kv.Tx, table string) (uint64, error) {
+	c, err := tx.Cursor(table)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+	return c.Count()
+}
+
+// Verify - checks that tables of the backup in `dbDir` have the amount of entries and hashes of its marker
+func Verify(ctx context.Context, dbDir string, logger log.Logger) (*Marker, error) {
+	m, err := ReadMarker(dbDir)
+	if err != nil {
+		return nil, err
+	}
+	if m.Incomplete {
+		return m, fmt.Errorf("backup %s is incomplete", dbDir)
+	}
+	label := kv.UnmarshalLabel(m.Label)
+	db, err := mdbx2.NewMDBX(logger).Path(dbDir).Label(label).Readonly().
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(label) }).
+		Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var issues []string
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		names := maps.Keys(m.Tables)
+		sort.Strings(names)
+		for _, name := range names {
+			t := m.Tables[name]
+			entries, sum, err := hashTable(ctx, tx, name)
+			if err != nil {
+				return err
+			}
+			if entries != t.Entries {
+				issues = append(issues, fmt.Sprintf("%s: %d entries, expected %d", name, entries, t.Entries))
+			} else if sum != t.Hash {
+				issues = append(issues, fmt.Sprintf("%s: hash %s, expected %s", name, sum, t.Hash))
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(issues) > 0 {
+		return m, fmt.Errorf("backup %s is corrupted: %s", dbDir, strings.Join(issues, "; "))
+	}
+	logger.Info("[backup] verified", "dir", dbDir, "label", m.Label, "txId", m.TxID, "tables", len(m.Tables))
+	return m, nil
+}
+
+// Restore - verifies backup in `from`, and copies it to the new db in `to`
+func Restore(ctx context.Context, from, to string, readAheadThreads int, logger log.Logger) error {
+	m, err := Verify(ctx, from, logger)
+	if err != nil {
+		return err
+	}
+	if dir.FileExist(filepath.Join(to, "mdbx.dat")) {
+		return fmt.Errorf("db already exists: %s", to)
+	}
+	if err := os.MkdirAll(to, 0740); err != nil { //owner: rw, group: r, others: -
+		return fmt.Errorf("mkdir: %w, %s", err, to)
+	}
+	if readAheadThreads <= 0 {
+		readAheadThreads = ReadAheadThreads
+	}
+	label := kv.UnmarshalLabel(m.Label)
+	src, dst := OpenPair(from, to, label, 0, logger)
+	defer src.Close()
+	defer dst.Close()
+	return Kv2kv(ctx, src, dst, maps.Keys(m.Tables), readAheadThreads, logger)
+}
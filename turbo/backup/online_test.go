@@ -0,0 +1,124 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tenderly/erigon/erigon-lib/kv"
+)
+
+func TestOnlineBackup(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+	tmp := t.TempDir()
+	from, to, restored := filepath.Join(tmp, "src"), filepath.Join(tmp, "backup"), filepath.Join(tmp, "restored")
+
+	src := OpenTarget(from, kv.TxPoolDB, 0, 0, logger)
+	defer src.Close()
+	put := func(table string, k, v string) {
+		require.NoError(t, src.Update(ctx, func(tx kv.RwTx) error { return tx.Put(table, []byte(k), []byte(v)) }))
+	}
+	put(kv.PoolTransaction, "tx1", "rlp1")
+	put(kv.PoolTransaction, "tx2", "rlp2")
+	put(kv.RecentLocalTransaction, "seq1", "tx1")
+
+	m, err := Backup(ctx, src, to, kv.TxPoolDB, Cfg{}, logger)
+	require.NoError(t, err)
+	require.False(t, m.Incremental)
+	require.Equal(t, uint64(2), m.Tables[kv.PoolTransaction].Entries)
+	require.True(t, m.Tables[kv.RecentLocalTransaction].Copied)
+	_, err = Verify(ctx, to, logger)
+	require.NoError(t, err)
+
+	// only modified table is copied by incremental backup
+	put(kv.PoolTransaction, "tx3", "rlp3")
+	m, err = Backup(ctx, src, to, kv.TxPoolDB, Cfg{Incremental: true}, logger)
+	require.NoError(t, err)
+	require.True(t, m.Incremental)
+	require.True(t, m.Tables[kv.PoolTransaction].Copied)
+	require.Equal(t, uint64(3), m.Tables[kv.PoolTransaction].Entries)
+	require.False(t, m.Tables[kv.RecentLocalTransaction].Copied)
+	require.Equal(t, uint64(1), m.Tables[kv.RecentLocalTransaction].Entries)
+	_, err = Verify(ctx, to, logger)
+	require.NoError(t, err)
+
+	// failed backup leaves the tables it touched out of the marker, the next one copies them again
+	put(kv.PoolTransaction, "tx4", "rlp4")
+	_, err = Backup(ctx, src, to, kv.TxPoolDB, Cfg{Incremental: true, Tables: []string{"Missing", kv.PoolTransaction}}, logger)
+	require.Error(t, err)
+	m, err = ReadMarker(to)
+	require.NoError(t, err)
+	require.True(t, m.Incomplete)
+	require.NotContains(t, m.Tables, kv.PoolTransaction)
+	require.Contains(t, m.Tables, kv.RecentLocalTransaction)
+	_, err = Verify(ctx, to, logger)
+	require.ErrorContains(t, err, "incomplete")
+	m, err = Backup(ctx, src, to, kv.TxPoolDB, Cfg{Incremental: true}, logger)
+	require.NoError(t, err)
+	require.False(t, m.Incomplete)
+	require.True(t, m.Tables[kv.PoolTransaction].Copied)
+	require.Equal(t, uint64(4), m.Tables[kv.PoolTransaction].Entries)
+	require.False(t, m.Tables[kv.RecentLocalTransaction].Copied)
+	_, err = Verify(ctx, to, logger)
+	require.NoError(t, err)
+
+	require.NoError(t, Restore(ctx, to, restored, 0, logger))
+	require.ErrorContains(t, Restore(ctx, to, restored, 0, logger), "already exists")
+	db := OpenTarget(restored, kv.TxPoolDB, 0, 0, logger)
+	require.NoError(t, db.View(ctx, func(tx kv.Tx) error {
+		v, err := tx.GetOne(kv.PoolTransaction, []byte("tx3"))
+		require.Equal(t, "rlp3", string(v))
+		return err
+	}))
+	db.Close()
+
+	// corrupted marker is detected
+	m.Tables[kv.PoolTransaction].Entries++
+	require.NoError(t, m.write(to))
+	_, err = Verify(ctx, to, logger)
+	require.ErrorContains(t, err, kv.PoolTransaction)
+
+	_, err = Backup(ctx, src, to, kv.ChainDB, Cfg{Incremental: true}, logger)
+	require.ErrorContains(t, err, "not chaindata")
+	_, err = os.Stat(filepath.Join(to, MarkerFileName))
+	require.NoError(t, err)
+}
+
+func TestCheckTarget(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+	tmp := t.TempDir()
+	datadir, to := filepath.Join(tmp, "datadir"), filepath.Join(tmp, "backup")
+	require.NoError(t, os.MkdirAll(filepath.Join(datadir, "chaindata"), 0755))
+
+	require.NoError(t, CheckTarget(to, kv.TxPoolDB, datadir))
+	require.ErrorContains(t, CheckTarget(datadir, kv.TxPoolDB, datadir), "inside of")
+	require.ErrorContains(t, CheckTarget(filepath.Join(datadir, "chaindata", "new"), kv.TxPoolDB, datadir), "inside of")
+	require.NoError(t, os.Symlink(datadir, filepath.Join(tmp, "link")))
+	require.ErrorContains(t, CheckTarget(filepath.Join(tmp, "link", "backup"), kv.TxPoolDB, datadir), "inside of")
+	require.NoError(t, CheckTarget(datadir+"2", kv.TxPoolDB, datadir))
+
+	// non-empty dir without backup is refused
+	require.NoError(t, os.MkdirAll(to, 0755))
+	require.NoError(t, CheckTarget(to, kv.TxPoolDB, datadir))
+	require.NoError(t, os.WriteFile(filepath.Join(to, "precious"), nil, 0644))
+	require.ErrorContains(t, CheckTarget(to, kv.TxPoolDB, datadir), "not empty")
+
+	// previous backup is overwritten in place, files next to it are kept
+	src := OpenTarget(filepath.Join(tmp, "src"), kv.TxPoolDB, 0, 0, logger)
+	defer src.Close()
+	require.NoError(t, src.Update(ctx, func(tx kv.RwTx) error { return tx.Put(kv.PoolTransaction, []byte("tx1"), []byte("rlp1")) }))
+	_, err := Backup(ctx, src, to, kv.TxPoolDB, Cfg{KeepDir: true}, logger)
+	require.NoError(t, err)
+	require.NoError(t, CheckTarget(to, kv.TxPoolDB, datadir))
+	require.ErrorContains(t, CheckTarget(to, kv.ChainDB, datadir), "has backup of")
+	_, err = Backup(ctx, src, to, kv.TxPoolDB, Cfg{KeepDir: true}, logger)
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(to, "precious"))
+	require.NoError(t, err)
+	_, err = Verify(ctx, to, logger)
+	require.NoError(t, err)
+}
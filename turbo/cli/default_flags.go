@@ -78,6 +78,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.RpcReturnDataLimit,
 	&utils.AllowUnprotectedTxs,
 	&utils.RpcMaxGetProofRewindBlockCount,
+	&utils.RpcTraceDirFlag,
 	&utils.RPCGlobalTxFeeCapFlag,
 	&utils.TxpoolApiAddrFlag,
 	&utils.TraceMaxtracesFlag,
@@ -137,10 +138,15 @@ var DefaultFlags = []cli.Flag{
 	&utils.MinerEtherbaseFlag,
 	&utils.MinerExtraDataFlag,
 	&utils.MinerNoVerfiyFlag,
+	&utils.MinerOrderingFlag,
+	&utils.MinerOrderingBudgetFlag,
+	&utils.MinerOrderingGainFlag,
 	&utils.MinerSigningKeyFileFlag,
 	&utils.SentryAddrFlag,
 	&utils.SentryLogPeerInfoFlag,
 	&utils.DownloaderAddrFlag,
+	&utils.DownloaderMirrorFlag,
+	&utils.DownloaderPreverifiedFlag,
 	&utils.DisableIPV4,
 	&utils.DisableIPV6,
 	&utils.NoDownloaderFlag,
@@ -0,0 +1,72 @@
+package engineapi
+
+import (
+	"github.com/tenderly/erigon/cl/clparams"
+	"github.com/tenderly/erigon/rpc"
+)
+
+// engineMethod is a version of an engine API method. The versions of a method share one implementation, which gets
+// the engineMethod to know the payload version and to check the fork of the request with checkFork.
+type engineMethod struct {
+	name    string
+	version clparams.StateVersion // payload version the method works with
+	// since and until are the first and the last fork, by the timestamp of the request, in which the method can be
+	// used; zero until means no upper bound
+	since, until clparams.StateVersion
+}
+
+var (
+	engineNewPayloadV1                      = advertise(&engineMethod{name: "engine_newPayloadV1", version: clparams.BellatrixVersion, until: clparams.CapellaVersion})
+	engineNewPayloadV2                      = advertise(&engineMethod{name: "engine_newPayloadV2", version: clparams.CapellaVersion, until: clparams.CapellaVersion})
+	engineNewPayloadV3                      = advertise(&engineMethod{name: "engine_newPayloadV3", version: clparams.DenebVersion, since: clparams.DenebVersion})
+	engineForkchoiceUpdatedV1               = advertise(&engineMethod{name: "engine_forkchoiceUpdatedV1", version: clparams.BellatrixVersion, until: clparams.CapellaVersion})
+	engineForkchoiceUpdatedV2               = advertise(&engineMethod{name: "engine_forkchoiceUpdatedV2", version: clparams.CapellaVersion, until: clparams.CapellaVersion})
+	engineForkchoiceUpdatedV3               = advertise(&engineMethod{name: "engine_forkchoiceUpdatedV3", version: clparams.DenebVersion, since: clparams.DenebVersion})
+	engineGetPayloadV1                      = advertise(&engineMethod{name: "engine_getPayloadV1", version: clparams.BellatrixVersion, until: clparams.CapellaVersion})
+	engineGetPayloadV2                      = advertise(&engineMethod{name: "engine_getPayloadV2", version: clparams.CapellaVersion, until: clparams.CapellaVersion})
+	engineGetPayloadV3                      = advertise(&engineMethod{name: "engine_getPayloadV3", version: clparams.DenebVersion, since: clparams.DenebVersion})
+	engineExchangeTransitionConfigurationV1 = advertise(&engineMethod{name: "engine_exchangeTransitionConfigurationV1", version: clparams.BellatrixVersion})
+	engineGetPayloadBodiesByHashV1          = advertise(&engineMethod{name: "engine_getPayloadBodiesByHashV1", version: clparams.CapellaVersion})
+	engineGetPayloadBodiesByRangeV1         = advertise(&engineMethod{name: "engine_getPayloadBodiesByRangeV1", version: clparams.CapellaVersion})
+	engineGetPayloadBodiesByHashV2          = advertise(&engineMethod{name: "engine_getPayloadBodiesByHashV2", version: clparams.DenebVersion})
+	engineGetPayloadBodiesByRangeV2         = advertise(&engineMethod{name: "engine_getPayloadBodiesByRangeV2", version: clparams.DenebVersion})
+	engineGetBlobsV1                        = advertise(&engineMethod{name: "engine_getBlobsV1", version: clparams.DenebVersion})
+)
+
+// engineMethods are advertised by ExchangeCapabilities, every method declared with advertise is added
+var engineMethods []*engineMethod
+
+func advertise(m *engineMethod) *engineMethod {
+	engineMethods = append(engineMethods, m)
+	return m
+}
+
+// ourCapabilities are the names of engineMethods
+func ourCapabilities() []string {
+	res := make([]string, len(engineMethods))
+	for i, m := range engineMethods {
+		res[i] = m.name
+	}
+	return res
+}
+
+// forkVersion is the payload version of the fork active at the timestamp
+func (s *EngineServer) forkVersion(time uint64) clparams.StateVersion {
+	switch {
+	case s.config.IsCancun(time):
+		return clparams.DenebVersion
+	case s.config.IsShanghai(time):
+		return clparams.CapellaVersion
+	default:
+		return clparams.BellatrixVersion
+	}
+}
+
+// checkFork tells whether the method can be used for a payload with the timestamp
+func (s *EngineServer) checkFork(m *engineMethod, time uint64) error {
+	fork := s.forkVersion(time)
+	if fork < m.since || (m.until != 0 && fork > m.until) {
+		return &rpc.UnsupportedForkError{Message: "Unsupported fork"}
+	}
+	return nil
+}
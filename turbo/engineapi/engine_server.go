@@ -21,6 +21,7 @@ import (
 	"github.com/tenderly/erigon/erigon-lib/gointerfaces"
 	"github.com/tenderly/erigon/erigon-lib/gointerfaces/execution"
 	"github.com/tenderly/erigon/erigon-lib/gointerfaces/txpool"
+	types2 "github.com/tenderly/erigon/erigon-lib/gointerfaces/types"
 	"github.com/tenderly/erigon/erigon-lib/kv"
 	"github.com/tenderly/erigon/erigon-lib/kv/kvcache"
 	libstate "github.com/tenderly/erigon/erigon-lib/state"
@@ -51,6 +52,7 @@ type EngineServer struct {
 	proposing        bool
 	test             bool
 	executionService execution.ExecutionClient
+	txPool           txpool.TxpoolClient // set by Start, serves engine_getBlobsV1
 
 	chainRW eth1_chain_reader.ChainReaderWriterEth1
 	ctx     context.Context
@@ -79,6 +81,7 @@ func NewEngineServer(ctx context.Context, logger log.Logger, config *chain.Confi
 func (e *EngineServer) Start(httpConfig *httpcfg.HttpCfg, db kv.RoDB, blockReader services.FullBlockReader,
 	filters *rpchelper.Filters, stateCache kvcache.Cache, agg *libstate.AggregatorV3, engineReader consensus.EngineReader,
 	eth rpchelper.ApiBackend, txPool txpool.TxpoolClient, mining txpool.MiningClient) {
+	e.txPool = txPool
 	base := jsonrpc.NewBaseApi(filters, stateCache, blockReader, agg, httpConfig.WithDatadir, httpConfig.EvmCallTimeout, engineReader, httpConfig.Dirs)
 
 	ethImpl := jsonrpc.NewEthAPI(base, db, eth, txPool, mining, httpConfig.Gascap, httpConfig.ReturnDataLimit, httpConfig.AllowUnprotectedTxs, httpConfig.MaxGetProofRewindBlockCount, e.logger)
@@ -148,8 +151,9 @@ func (s *EngineServer) validatePayloadBlobs(req *engine_types.ExecutionPayload,
 
 // EngineNewPayload validates and possibly executes payload
 func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.ExecutionPayload,
-	expectedBlobHashes []libcommon.Hash, parentBeaconBlockRoot *libcommon.Hash, version clparams.StateVersion,
+	expectedBlobHashes []libcommon.Hash, parentBeaconBlockRoot *libcommon.Hash, m *engineMethod,
 ) (*engine_types.PayloadStatus, error) {
+	version := m.version
 	var bloom types.Bloom
 	copy(bloom[:], req.LogsBloom)
 
@@ -199,9 +203,8 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 		header.ParentBeaconBlockRoot = parentBeaconBlockRoot
 	}
 
-	if (!s.config.IsCancun(header.Time) && version >= clparams.DenebVersion) ||
-		(s.config.IsCancun(header.Time) && version < clparams.DenebVersion) {
-		return nil, &rpc.UnsupportedForkError{Message: "Unsupported fork"}
+	if err := s.checkFork(m, header.Time); err != nil {
+		return nil, err
 	}
 
 	blockHash := req.BlockHash
@@ -383,7 +386,7 @@ func (s *EngineServer) getQuickPayloadStatusIfPossible(blockHash libcommon.Hash,
 }
 
 // EngineGetPayload retrieves previously assembled payload (Validators only)
-func (s *EngineServer) getPayload(ctx context.Context, payloadId uint64, version clparams.StateVersion) (*engine_types.GetPayloadResponse, error) {
+func (s *EngineServer) getPayload(ctx context.Context, payloadId uint64, m *engineMethod) (*engine_types.GetPayloadResponse, error) {
 	if !s.proposing {
 		return nil, fmt.Errorf("execution layer not running as a proposer. enable proposer by taking out the --proposer.disable flag on startup")
 	}
@@ -414,10 +417,8 @@ func (s *EngineServer) getPayload(ctx context.Context, payloadId uint64, version
 	}
 	data := resp.Data
 
-	ts := data.ExecutionPayload.Timestamp
-	if (!s.config.IsCancun(ts) && version >= clparams.DenebVersion) ||
-		(s.config.IsCancun(ts) && version < clparams.DenebVersion) {
-		return nil, &rpc.UnsupportedForkError{Message: "Unsupported fork"}
+	if err := s.checkFork(m, data.ExecutionPayload.Timestamp); err != nil {
+		return nil, err
 	}
 
 	return &engine_types.GetPayloadResponse{
@@ -428,8 +429,9 @@ func (s *EngineServer) getPayload(ctx context.Context, payloadId uint64, version
 }
 
 // engineForkChoiceUpdated either states new block head or request the assembling of a new block
-func (s *EngineServer) forkchoiceUpdated(ctx context.Context, forkchoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes, version clparams.StateVersion,
+func (s *EngineServer) forkchoiceUpdated(ctx context.Context, forkchoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes, m *engineMethod,
 ) (*engine_types.ForkChoiceUpdatedResponse, error) {
+	version := m.version
 	status, err := s.getQuickPayloadStatusIfPossible(forkchoiceState.HeadHash, 0, libcommon.Hash{}, forkchoiceState, false)
 	if err != nil {
 		return nil, err
@@ -452,24 +454,15 @@ func (s *EngineServer) forkchoiceUpdated(ctx context.Context, forkchoiceState *e
 	}
 
 	if payloadAttributes != nil {
-		timestamp := uint64(payloadAttributes.Timestamp)
-		if !s.config.IsCancun(timestamp) && version >= clparams.DenebVersion { // V3 before cancun
-			if payloadAttributes.ParentBeaconBlockRoot == nil {
-				return nil, &rpc.InvalidParamsError{Message: "Beacon Root missing"}
-			}
-			return nil, &rpc.UnsupportedForkError{Message: "Unsupported fork"}
+		// the beacon root is checked before the fork, even if the method is not supported in the fork
+		if version >= clparams.DenebVersion && payloadAttributes.ParentBeaconBlockRoot == nil {
+			return nil, &rpc.InvalidParamsError{Message: "Beacon Root missing"}
 		}
-		if s.config.IsCancun(timestamp) && version < clparams.DenebVersion { // Not V3 after cancun
-			if payloadAttributes.ParentBeaconBlockRoot != nil {
+		if err := s.checkFork(m, uint64(payloadAttributes.Timestamp)); err != nil {
+			if version < clparams.DenebVersion && payloadAttributes.ParentBeaconBlockRoot != nil {
 				return nil, &rpc.InvalidParamsError{Message: "Unexpected Beacon Root"}
 			}
-			return nil, &rpc.UnsupportedForkError{Message: "Unsupported fork"}
-		}
-
-		if s.config.IsCancun(timestamp) && version >= clparams.DenebVersion {
-			if payloadAttributes.ParentBeaconBlockRoot == nil {
-				return nil, &rpc.InvalidParamsError{Message: "Beacon Root missing"}
-			}
+			return nil, err
 		}
 	}
 
@@ -533,7 +526,7 @@ func (s *EngineServer) forkchoiceUpdated(ctx context.Context, forkchoiceState *e
 	}, nil
 }
 
-func (s *EngineServer) getPayloadBodiesByHash(ctx context.Context, request []libcommon.Hash, _ clparams.StateVersion) ([]*engine_types.ExecutionPayloadBodyV1, error) {
+func (s *EngineServer) getPayloadBodiesByHash(ctx context.Context, request []libcommon.Hash, _ *engineMethod) ([]*engine_types.ExecutionPayloadBodyV1, error) {
 	bodies, err := s.chainRW.GetBodiesByHashes(request)
 	if err != nil {
 		return nil, err
@@ -559,7 +552,19 @@ func extractPayloadBodyFromBody(body *types.RawBody) *engine_types.ExecutionPayl
 	return &engine_types.ExecutionPayloadBodyV1{Transactions: bdTxs, Withdrawals: body.Withdrawals}
 }
 
-func (s *EngineServer) getPayloadBodiesByRange(ctx context.Context, start, count uint64, _ clparams.StateVersion) ([]*engine_types.ExecutionPayloadBodyV1, error) {
+// payloadBodiesV2 adds the requests to the bodies. The blocks don't carry the requests before Prague, which isn't
+// supported yet, so they are always null.
+func payloadBodiesV2(bodies []*engine_types.ExecutionPayloadBodyV1) []*engine_types.ExecutionPayloadBodyV2 {
+	resp := make([]*engine_types.ExecutionPayloadBodyV2, len(bodies))
+	for idx, body := range bodies {
+		if body != nil {
+			resp[idx] = &engine_types.ExecutionPayloadBodyV2{Transactions: body.Transactions, Withdrawals: body.Withdrawals}
+		}
+	}
+	return resp
+}
+
+func (s *EngineServer) getPayloadBodiesByRange(ctx context.Context, start, count uint64, _ *engineMethod) ([]*engine_types.ExecutionPayloadBodyV1, error) {
 	bodies, err := s.chainRW.GetBodiesByRange(start, count)
 	if err != nil {
 		return nil, err
@@ -579,7 +584,7 @@ func (e *EngineServer) GetPayloadV1(ctx context.Context, payloadId hexutility.By
 	decodedPayloadId := binary.BigEndian.Uint64(payloadId)
 	e.logger.Info("Received GetPayloadV1", "payloadId", decodedPayloadId)
 
-	response, err := e.getPayload(ctx, decodedPayloadId, clparams.BellatrixVersion)
+	response, err := e.getPayload(ctx, decodedPayloadId, engineGetPayloadV1)
 	if err != nil {
 		return nil, err
 	}
@@ -592,7 +597,7 @@ func (e *EngineServer) GetPayloadV1(ctx context.Context, payloadId hexutility.By
 func (e *EngineServer) GetPayloadV2(ctx context.Context, payloadID hexutility.Bytes) (*engine_types.GetPayloadResponse, error) {
 	decodedPayloadId := binary.BigEndian.Uint64(payloadID)
 	e.logger.Info("Received GetPayloadV2", "payloadId", decodedPayloadId)
-	return e.getPayload(ctx, decodedPayloadId, clparams.CapellaVersion)
+	return e.getPayload(ctx, decodedPayloadId, engineGetPayloadV2)
 }
 
 // Same as [GetPayloadV2], with addition of blobsBundle containing valid blobs, commitments, proofs
@@ -600,7 +605,7 @@ func (e *EngineServer) GetPayloadV2(ctx context.Context, payloadID hexutility.By
 func (e *EngineServer) GetPayloadV3(ctx context.Context, payloadID hexutility.Bytes) (*engine_types.GetPayloadResponse, error) {
 	decodedPayloadId := binary.BigEndian.Uint64(payloadID)
 	e.logger.Info("Received GetPayloadV3", "payloadId", decodedPayloadId)
-	return e.getPayload(ctx, decodedPayloadId, clparams.DenebVersion)
+	return e.getPayload(ctx, decodedPayloadId, engineGetPayloadV3)
 }
 
 // Updates the forkchoice state after validating the headBlockHash
@@ -608,38 +613,38 @@ func (e *EngineServer) GetPayloadV3(ctx context.Context, payloadID hexutility.By
 // (asynchronously updated with transactions), if payloadAttributes is not nil and passes validation
 // See https://github.com/ethereum/execution-apis/blob/main/src/engine/paris.md#engine_forkchoiceupdatedv1
 func (e *EngineServer) ForkchoiceUpdatedV1(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error) {
-	return e.forkchoiceUpdated(ctx, forkChoiceState, payloadAttributes, clparams.BellatrixVersion)
+	return e.forkchoiceUpdated(ctx, forkChoiceState, payloadAttributes, engineForkchoiceUpdatedV1)
 }
 
 // Same as, and a replacement for, [ForkchoiceUpdatedV1], post Shanghai
 // See https://github.com/ethereum/execution-apis/blob/main/src/engine/shanghai.md#engine_forkchoiceupdatedv2
 func (e *EngineServer) ForkchoiceUpdatedV2(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error) {
-	return e.forkchoiceUpdated(ctx, forkChoiceState, payloadAttributes, clparams.CapellaVersion)
+	return e.forkchoiceUpdated(ctx, forkChoiceState, payloadAttributes, engineForkchoiceUpdatedV2)
 }
 
 // Successor of [ForkchoiceUpdatedV2] post Cancun, with stricter check on params
 // See https://github.com/ethereum/execution-apis/blob/main/src/engine/cancun.md#engine_forkchoiceupdatedv3
 func (e *EngineServer) ForkchoiceUpdatedV3(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error) {
-	return e.forkchoiceUpdated(ctx, forkChoiceState, payloadAttributes, clparams.DenebVersion)
+	return e.forkchoiceUpdated(ctx, forkChoiceState, payloadAttributes, engineForkchoiceUpdatedV3)
 }
 
 // NewPayloadV1 processes new payloads (blocks) from the beacon chain without withdrawals.
 // See https://github.com/ethereum/execution-apis/blob/main/src/engine/paris.md#engine_newpayloadv1
 func (e *EngineServer) NewPayloadV1(ctx context.Context, payload *engine_types.ExecutionPayload) (*engine_types.PayloadStatus, error) {
-	return e.newPayload(ctx, payload, nil, nil, clparams.BellatrixVersion)
+	return e.newPayload(ctx, payload, nil, nil, engineNewPayloadV1)
 }
 
 // NewPayloadV2 processes new payloads (blocks) from the beacon chain with withdrawals.
 // See https://github.com/ethereum/execution-apis/blob/main/src/engine/shanghai.md#engine_newpayloadv2
 func (e *EngineServer) NewPayloadV2(ctx context.Context, payload *engine_types.ExecutionPayload) (*engine_types.PayloadStatus, error) {
-	return e.newPayload(ctx, payload, nil, nil, clparams.CapellaVersion)
+	return e.newPayload(ctx, payload, nil, nil, engineNewPayloadV2)
 }
 
 // NewPayloadV3 processes new payloads (blocks) from the beacon chain with withdrawals & blob gas.
 // See https://github.com/ethereum/execution-apis/blob/main/src/engine/cancun.md#engine_newpayloadv3
 func (e *EngineServer) NewPayloadV3(ctx context.Context, payload *engine_types.ExecutionPayload,
 	expectedBlobHashes []libcommon.Hash, parentBeaconBlockRoot *libcommon.Hash) (*engine_types.PayloadStatus, error) {
-	return e.newPayload(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, clparams.DenebVersion)
+	return e.newPayload(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, engineNewPayloadV3)
 }
 
 // Receives consensus layer's transition configuration and checks if the execution layer has the correct configuration.
@@ -670,7 +675,7 @@ func (e *EngineServer) GetPayloadBodiesByHashV1(ctx context.Context, hashes []li
 		return nil, &engine_helpers.TooLargeRequestErr
 	}
 
-	return e.getPayloadBodiesByHash(ctx, hashes, clparams.DenebVersion)
+	return e.getPayloadBodiesByHash(ctx, hashes, engineGetPayloadBodiesByHashV1)
 }
 
 // Returns an ordered (as per canonical chain) array of execution payload bodies, with corresponding execution block numbers from "start", up to "count"
@@ -683,33 +688,76 @@ func (e *EngineServer) GetPayloadBodiesByRangeV1(ctx context.Context, start, cou
 		return nil, &engine_helpers.TooLargeRequestErr
 	}
 
-	return e.getPayloadBodiesByRange(ctx, uint64(start), uint64(count), clparams.CapellaVersion)
+	return e.getPayloadBodiesByRange(ctx, uint64(start), uint64(count), engineGetPayloadBodiesByRangeV1)
+}
+
+// Same as [GetPayloadBodiesByHashV1] with addition of the deposit and withdrawal requests of the blocks
+// See https://github.com/ethereum/execution-apis/blob/main/src/engine/prague.md#engine_getpayloadbodiesbyhashv2
+func (e *EngineServer) GetPayloadBodiesByHashV2(ctx context.Context, hashes []libcommon.Hash) ([]*engine_types.ExecutionPayloadBodyV2, error) {
+	if len(hashes) > 1024 {
+		return nil, &engine_helpers.TooLargeRequestErr
+	}
+
+	bodies, err := e.getPayloadBodiesByHash(ctx, hashes, engineGetPayloadBodiesByHashV2)
+	if err != nil {
+		return nil, err
+	}
+	return payloadBodiesV2(bodies), nil
+}
+
+// Same as [GetPayloadBodiesByRangeV1] with addition of the deposit and withdrawal requests of the blocks
+// See https://github.com/ethereum/execution-apis/blob/main/src/engine/prague.md#engine_getpayloadbodiesbyrangev2
+func (e *EngineServer) GetPayloadBodiesByRangeV2(ctx context.Context, start, count hexutil.Uint64) ([]*engine_types.ExecutionPayloadBodyV2, error) {
+	if start == 0 || count == 0 {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("invalid start or count, start: %v count: %v", start, count)}
+	}
+	if count > 1024 {
+		return nil, &engine_helpers.TooLargeRequestErr
+	}
+
+	bodies, err := e.getPayloadBodiesByRange(ctx, uint64(start), uint64(count), engineGetPayloadBodiesByRangeV2)
+	if err != nil {
+		return nil, err
+	}
+	return payloadBodiesV2(bodies), nil
 }
 
-var ourCapabilities = []string{
-	"engine_forkchoiceUpdatedV1",
-	"engine_forkchoiceUpdatedV2",
-	"engine_forkchoiceUpdatedV3",
-	"engine_newPayloadV1",
-	"engine_newPayloadV2",
-	"engine_newPayloadV3",
-	"engine_getPayloadV1",
-	"engine_getPayloadV2",
-	"engine_getPayloadV3",
-	"engine_exchangeTransitionConfigurationV1",
-	"engine_getPayloadBodiesByHashV1",
-	"engine_getPayloadBodiesByRangeV1",
+// Returns the blobs and their proofs of the pooled transactions by the versioned hashes, null for the unknown ones
+// See https://github.com/ethereum/execution-apis/blob/main/src/engine/cancun.md#engine_getblobsv1
+func (e *EngineServer) GetBlobsV1(ctx context.Context, blobHashes []libcommon.Hash) ([]*engine_types.BlobAndProofV1, error) {
+	if len(blobHashes) > 128 {
+		return nil, &engine_helpers.TooLargeRequestErr
+	}
+	if e.txPool == nil {
+		return nil, fmt.Errorf("%s: txpool is not available", engineGetBlobsV1.name)
+	}
+	req := &txpool.GetBlobsRequest{BlobHashes: make([]*types2.H256, len(blobHashes))}
+	for i, hash := range blobHashes {
+		req.BlobHashes[i] = gointerfaces.ConvertHashToH256(hash)
+	}
+	reply, err := e.txPool.GetBlobs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*engine_types.BlobAndProofV1, len(blobHashes))
+	for i, blob := range reply.BlobsAndProofs {
+		if i < len(res) && len(blob.Blob) > 0 {
+			res[i] = &engine_types.BlobAndProofV1{Blob: blob.Blob, Proof: blob.Proof}
+		}
+	}
+	return res, nil
 }
 
 func (e *EngineServer) ExchangeCapabilities(fromCl []string) []string {
-	missingOurs := compareCapabilities(fromCl, ourCapabilities)
-	missingCl := compareCapabilities(ourCapabilities, fromCl)
+	ours := ourCapabilities()
+	missingOurs := compareCapabilities(fromCl, ours)
+	missingCl := compareCapabilities(ours, fromCl)
 
 	if len(missingCl) > 0 || len(missingOurs) > 0 {
 		e.logger.Debug("ExchangeCapabilities mismatches", "cl_unsupported", missingCl, "erigon_unsupported", missingOurs)
 	}
 
-	return ourCapabilities
+	return ours
 }
 
 func compareCapabilities(from []string, to []string) []string {
@@ -0,0 +1,78 @@
+package engineapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutility"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces/txpool"
+
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/turbo/engineapi/engine_helpers"
+	"github.com/tenderly/erigon/turbo/engineapi/engine_types"
+)
+
+// blobsTxPool serves GetBlobs from the blobs by versioned hash, the other methods are not implemented
+type blobsTxPool struct {
+	txpool.TxpoolClient
+	blobs map[libcommon.Hash]*txpool.BlobAndProof
+}
+
+func (p *blobsTxPool) GetBlobs(_ context.Context, in *txpool.GetBlobsRequest, _ ...grpc.CallOption) (*txpool.GetBlobsReply, error) {
+	reply := &txpool.GetBlobsReply{BlobsAndProofs: make([]*txpool.BlobAndProof, len(in.BlobHashes))}
+	for i, hash := range in.BlobHashes {
+		reply.BlobsAndProofs[i] = &txpool.BlobAndProof{}
+		if blob, ok := p.blobs[gointerfaces.ConvertH256ToHash(hash)]; ok {
+			reply.BlobsAndProofs[i] = blob
+		}
+	}
+	return reply, nil
+}
+
+func TestGetBlobsV1(t *testing.T) {
+	ctx, require := context.Background(), require.New(t)
+	blob1, blob2 := &txpool.BlobAndProof{Blob: []byte{1}, Proof: []byte{11}}, &txpool.BlobAndProof{Blob: []byte{2}, Proof: []byte{22}}
+	e := &EngineServer{logger: log.New()}
+
+	_, err := e.GetBlobsV1(ctx, []libcommon.Hash{{1}})
+	require.ErrorContains(err, "txpool is not available")
+
+	e.txPool = &blobsTxPool{blobs: map[libcommon.Hash]*txpool.BlobAndProof{{1}: blob1, {2}: blob2}}
+	res, err := e.GetBlobsV1(ctx, []libcommon.Hash{{2}, {3}, {1}})
+	require.NoError(err)
+	require.Equal([]*engine_types.BlobAndProofV1{{Blob: blob2.Blob, Proof: blob2.Proof}, nil, {Blob: blob1.Blob, Proof: blob1.Proof}}, res)
+
+	res, err = e.GetBlobsV1(ctx, nil)
+	require.NoError(err)
+	require.Empty(res)
+
+	_, err = e.GetBlobsV1(ctx, make([]libcommon.Hash, 129))
+	require.Equal(&engine_helpers.TooLargeRequestErr, err)
+
+	require.Contains(e.ExchangeCapabilities(nil), engineGetBlobsV1.name)
+}
+
+func TestGetPayloadBodiesV2(t *testing.T) {
+	ctx, require := context.Background(), require.New(t)
+	e := &EngineServer{logger: log.New()}
+
+	body := &engine_types.ExecutionPayloadBodyV1{Transactions: []hexutility.Bytes{{1}}, Withdrawals: []*types.Withdrawal{{Index: 1}}}
+	require.Equal([]*engine_types.ExecutionPayloadBodyV2{nil, {Transactions: body.Transactions, Withdrawals: body.Withdrawals}},
+		payloadBodiesV2([]*engine_types.ExecutionPayloadBodyV1{nil, body}))
+
+	_, err := e.GetPayloadBodiesByHashV2(ctx, make([]libcommon.Hash, 1025))
+	require.Equal(&engine_helpers.TooLargeRequestErr, err)
+	_, err = e.GetPayloadBodiesByRangeV2(ctx, 1, 1025)
+	require.Equal(&engine_helpers.TooLargeRequestErr, err)
+	_, err = e.GetPayloadBodiesByRangeV2(ctx, 0, 1)
+	require.ErrorContains(err, "invalid start or count")
+
+	require.Contains(e.ExchangeCapabilities(nil), engineGetPayloadBodiesByHashV2.name)
+	require.Contains(e.ExchangeCapabilities(nil), engineGetPayloadBodiesByRangeV2.name)
+}
@@ -66,11 +66,42 @@ type BlobsBundleV1 struct {
 	Blobs       []hexutility.Bytes `json:"blobs"       gencodec:"required"`
 }
 
+// BlobAndProofV1 is a blob of a pooled transaction with its KZG proof
+type BlobAndProofV1 struct {
+	Blob  hexutility.Bytes `json:"blob"  gencodec:"required"`
+	Proof hexutility.Bytes `json:"proof" gencodec:"required"`
+}
+
 type ExecutionPayloadBodyV1 struct {
 	Transactions []hexutility.Bytes  `json:"transactions" gencodec:"required"`
 	Withdrawals  []*types.Withdrawal `json:"withdrawals"  gencodec:"required"`
 }
 
+// ExecutionPayloadBodyV2 is ExecutionPayloadBodyV1 with the EIP-6110 deposit and EIP-7002 withdrawal requests of the
+// block, which are null for the blocks before Prague
+type ExecutionPayloadBodyV2 struct {
+	Transactions       []hexutility.Bytes     `json:"transactions"       gencodec:"required"`
+	Withdrawals        []*types.Withdrawal    `json:"withdrawals"        gencodec:"required"`
+	DepositRequests    []*DepositRequestV1    `json:"depositRequests"    gencodec:"required"`
+	WithdrawalRequests []*WithdrawalRequestV1 `json:"withdrawalRequests" gencodec:"required"`
+}
+
+// DepositRequestV1 is a deposit to the deposit contract processed by the block (EIP-6110)
+type DepositRequestV1 struct {
+	Pubkey                hexutility.Bytes `json:"pubkey"                gencodec:"required"`
+	WithdrawalCredentials common.Hash      `json:"withdrawalCredentials" gencodec:"required"`
+	Amount                hexutil.Uint64   `json:"amount"                gencodec:"required"`
+	Signature             hexutility.Bytes `json:"signature"             gencodec:"required"`
+	Index                 hexutil.Uint64   `json:"index"                 gencodec:"required"`
+}
+
+// WithdrawalRequestV1 is a withdrawal triggered from the execution layer (EIP-7002)
+type WithdrawalRequestV1 struct {
+	SourceAddress      common.Address   `json:"sourceAddress"      gencodec:"required"`
+	ValidatorPublicKey hexutility.Bytes `json:"validatorPublicKey" gencodec:"required"`
+	Amount             hexutil.Uint64   `json:"amount"             gencodec:"required"`
+}
+
 type PayloadStatus struct {
 	Status          EngineStatus      `json:"status" gencodec:"required"`
 	ValidationError *StringifiedError `json:"validationError"`
@@ -193,6 +193,19 @@ func (e *EthereumExecutionModule) ValidateChain(ctx context.Context, req *execut
 		validationStatus = execution.ExecutionStatus_MissingSegment
 	}
 	isInvalidChain := status == engine_types.InvalidStatus || status == engine_types.InvalidBlockHashStatus || validationError != nil
+	if isInvalidChain {
+		reason := "invalid block"
+		if validationError != nil {
+			reason = validationError.Error()
+		}
+		block, err := e.firstInvalidBlock(ctx, tx, header, body, lvh)
+		if err != nil {
+			return nil, err
+		}
+		if err = rawdb.WriteBadBlock(tx, block, reason); err != nil {
+			return nil, err
+		}
+	}
 	if isInvalidChain && (lvh != libcommon.Hash{}) && lvh != blockHash {
 		if err := e.purgeBadChain(ctx, tx, lvh, blockHash); err != nil {
 			return nil, err
@@ -208,6 +221,41 @@ func (e *EthereumExecutionModule) ValidateChain(ctx context.Context, req *execut
 	}, tx.Commit()
 }
 
+// firstInvalidBlock returns the block right after latestValidHash on the chain of head, which is the one that failed
+// the validation. It's head itself if latestValidHash isn't one of its known ancestors.
+func (e *EthereumExecutionModule) firstInvalidBlock(ctx context.Context, tx kv.Tx, head *types.Header, headBody *types.Body, latestValidHash libcommon.Hash) (*types.Block, error) {
+	headBlock := types.NewBlockFromStorage(head.Hash(), head, headBody.Transactions, headBody.Uncles, headBody.Withdrawals)
+	if (latestValidHash == libcommon.Hash{}) || latestValidHash == head.Hash() {
+		return headBlock, nil
+	}
+	current := head
+	for current.ParentHash != latestValidHash {
+		if current.Number.Uint64() == 0 {
+			return headBlock, nil
+		}
+		parent, err := e.getHeader(ctx, tx, current.ParentHash, current.Number.Uint64()-1)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return headBlock, nil
+		}
+		current = parent
+	}
+	if current == head {
+		return headBlock, nil
+	}
+	hash := current.Hash()
+	body, err := e.blockReader.BodyWithTransactions(ctx, tx, hash, current.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return headBlock, nil
+	}
+	return types.NewBlockFromStorage(hash, current, body.Transactions, body.Uncles, body.Withdrawals), nil
+}
+
 func (e *EthereumExecutionModule) purgeBadChain(ctx context.Context, tx kv.RwTx, latestValidHash, headHash libcommon.Hash) error {
 	tip := rawdb.ReadHeaderNumber(tx, headHash)
 
@@ -4,10 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"sync/atomic"
 
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/tenderly/erigon/erigon-lib/common/datadir"
 	"github.com/tenderly/erigon/erigon-lib/gointerfaces/remote"
+	"github.com/tenderly/erigon/erigon-lib/kv"
 	"github.com/tenderly/erigon/p2p"
 
+	"github.com/tenderly/erigon/turbo/backup"
 	"github.com/tenderly/erigon/turbo/rpchelper"
 )
 
@@ -22,17 +29,30 @@ type AdminAPI interface {
 
 	// AddPeer requests connecting to a remote node.
 	AddPeer(ctx context.Context, url string) (bool, error)
+
+	// Backup copies chaindata of the running node to the db in `dir` (absolute path on the filesystem of the node
+	// serving RPC), within one read transaction. `dir` must be outside of the datadir, and if it's not empty - hold
+	// the previous backup of chaindata, which is overwritten. With `incremental` - tables unchanged since the previous
+	// backup are not written (but still read in full).
+	Backup(ctx context.Context, dir string, incremental bool) (*backup.Marker, error)
 }
 
 // AdminAPIImpl data structure to store things needed for admin_* commands.
 type AdminAPIImpl struct {
 	ethBackend rpchelper.ApiBackend
+	db         kv.RoDB
+	dirs       datadir.Dirs
+	backingUp  atomic.Bool
+	logger     log.Logger
 }
 
 // NewAdminAPI returns AdminAPIImpl instance.
-func NewAdminAPI(eth rpchelper.ApiBackend) *AdminAPIImpl {
+func NewAdminAPI(eth rpchelper.ApiBackend, db kv.RoDB, dirs datadir.Dirs, logger log.Logger) *AdminAPIImpl {
 	return &AdminAPIImpl{
 		ethBackend: eth,
+		db:         db,
+		dirs:       dirs,
+		logger:     logger,
 	}
 }
 
@@ -63,3 +83,17 @@ func (api *AdminAPIImpl) AddPeer(ctx context.Context, url string) (bool, error)
 	}
 	return result.Success, nil
 }
+
+func (api *AdminAPIImpl) Backup(ctx context.Context, dir string, incremental bool) (*backup.Marker, error) {
+	if !filepath.IsAbs(dir) {
+		return nil, fmt.Errorf("backup dir must be absolute path: %s", dir)
+	}
+	if !api.backingUp.CompareAndSwap(false, true) {
+		return nil, errors.New("backup is already in progress")
+	}
+	defer api.backingUp.Store(false)
+	if err := backup.CheckTarget(dir, kv.ChainDB, api.dirs.DataDir, api.dirs.Chaindata); err != nil {
+		return nil, err
+	}
+	return backup.Backup(ctx, api.db, dir, kv.ChainDB, backup.Cfg{Incremental: incremental, KeepDir: true}, api.logger)
+}
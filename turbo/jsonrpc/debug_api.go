@@ -1,8 +1,10 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/tenderly/erigon/common/changeset"
 	"github.com/tenderly/erigon/core/rawdb"
 	"github.com/tenderly/erigon/core/state"
+	"github.com/tenderly/erigon/core/types"
 	"github.com/tenderly/erigon/core/types/accounts"
 	"github.com/tenderly/erigon/eth/stagedsync/stages"
 	"github.com/tenderly/erigon/eth/tracers"
@@ -35,6 +38,7 @@ type PrivateDebugAPI interface {
 	TraceTransaction(ctx context.Context, hash common.Hash, config *tracers.TraceConfig, stream *jsoniter.Stream) error
 	TraceBlockByHash(ctx context.Context, hash common.Hash, config *tracers.TraceConfig, stream *jsoniter.Stream) error
 	TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber, config *tracers.TraceConfig, stream *jsoniter.Stream) error
+	TraceChain(ctx context.Context, start, end rpc.BlockNumber, config *tracers.TraceChainConfig) (*rpc.Subscription, error)
 	AccountRange(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, start []byte, maxResults int, nocode, nostorage bool) (state.IteratorDump, error)
 	GetModifiedAccountsByNumber(ctx context.Context, startNum rpc.BlockNumber, endNum *rpc.BlockNumber) ([]common.Address, error)
 	GetModifiedAccountsByHash(_ context.Context, startHash common.Hash, endHash *common.Hash) ([]common.Address, error)
@@ -42,22 +46,37 @@ type PrivateDebugAPI interface {
 	AccountAt(ctx context.Context, blockHash common.Hash, txIndex uint64, account common.Address) (*AccountResult, error)
 	GetRawHeader(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (hexutility.Bytes, error)
 	GetRawBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (hexutility.Bytes, error)
+	GetRawReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]hexutility.Bytes, error)
+	GetRawTransaction(ctx context.Context, txnHash common.Hash) (hexutility.Bytes, error)
+	IntermediateRoots(ctx context.Context, blockHash common.Hash, config *IntermediateRootsConfig) ([]common.Hash, error)
+	GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, error)
+	TraceBadBlock(ctx context.Context, hash common.Hash, config *tracers.TraceConfig, stream *jsoniter.Stream) error
+	StandardTraceBlockToFile(ctx context.Context, hash common.Hash, config *tracers.StdTraceConfig) ([]string, error)
+	StandardTraceBadBlockToFile(ctx context.Context, hash common.Hash, config *tracers.StdTraceConfig) ([]string, error)
 }
 
 // PrivateDebugAPIImpl is implementation of the PrivateDebugAPI interface based on remote Db access
 type PrivateDebugAPIImpl struct {
 	*BaseAPI
-	db     kv.RoDB
-	GasCap uint64
+	db                  kv.RoDB
+	GasCap              uint64
+	traceDir            string // directory of the trace files, empty if there is no datadir
+	maxRewindBlockCount int    // how far back from the head debug_intermediateRoots can rewind the hashed state
 }
 
-// NewPrivateDebugAPI returns PrivateDebugAPIImpl instance
-func NewPrivateDebugAPI(base *BaseAPI, db kv.RoDB, gascap uint64) *PrivateDebugAPIImpl {
-	return &PrivateDebugAPIImpl{
-		BaseAPI: base,
-		db:      db,
-		GasCap:  gascap,
+// NewPrivateDebugAPI returns PrivateDebugAPIImpl instance. traceDir is relative to the datadir.
+func NewPrivateDebugAPI(base *BaseAPI, db kv.RoDB, gascap uint64, traceDir string, maxRewindBlockCount int) *PrivateDebugAPIImpl {
+	api := &PrivateDebugAPIImpl{
+		BaseAPI:             base,
+		db:                  db,
+		GasCap:              gascap,
+		maxRewindBlockCount: maxRewindBlockCount,
 	}
+	if base.dirs.DataDir != "" {
+		// cleaning the path as an absolute one keeps it inside the datadir
+		api.traceDir = filepath.Join(base.dirs.DataDir, filepath.Join("/", traceDir))
+	}
+	return api
 }
 
 // storageRangeAt implements debug_storageRangeAt. Returns information about a range of storage locations (if any) for the given address.
@@ -370,6 +389,9 @@ func (api *PrivateDebugAPIImpl) GetRawHeader(ctx context.Context, blockNrOrHash
 		return nil, err
 	}
 	defer tx.Rollback()
+	if block := api.rawPendingBlock(blockNrOrHash); block != nil {
+		return rlp.EncodeToBytes(block.HeaderNoCopy())
+	}
 	n, h, _, err := rpchelper.GetBlockNumber(blockNrOrHash, tx, api.filters)
 	if err != nil {
 		return nil, err
@@ -390,6 +412,9 @@ func (api *PrivateDebugAPIImpl) GetRawBlock(ctx context.Context, blockNrOrHash r
 		return nil, err
 	}
 	defer tx.Rollback()
+	if block := api.rawPendingBlock(blockNrOrHash); block != nil {
+		return rlp.EncodeToBytes(block)
+	}
 	n, h, _, err := rpchelper.GetBlockNumber(blockNrOrHash, tx, api.filters)
 	if err != nil {
 		return nil, err
@@ -403,3 +428,119 @@ func (api *PrivateDebugAPIImpl) GetRawBlock(ctx context.Context, blockNrOrHash r
 	}
 	return rlp.EncodeToBytes(block)
 }
+
+// rawPendingBlock returns the pending block if it is requested and known, it is not stored in the database
+func (api *PrivateDebugAPIImpl) rawPendingBlock(blockNrOrHash rpc.BlockNumberOrHash) *types.Block {
+	if number, ok := blockNrOrHash.Number(); ok && number == rpc.PendingBlockNumber {
+		return api.pendingBlock()
+	}
+	return nil
+}
+
+// GetRawReceipts implements debug_getRawReceipts. Returns the consensus encoding of the receipts of the block,
+// typed receipts are wrapped into their EIP-2718 envelopes just like for the receipt root.
+func (api *PrivateDebugAPIImpl) GetRawReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]hexutility.Bytes, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	n, h, _, err := rpchelper.GetBlockNumber(blockNrOrHash, tx, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.blockWithSenders(tx, h, n)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block not found")
+	}
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := api.getReceipts(ctx, tx, chainConfig, block, block.Body().SendersFromTxs())
+	if err != nil {
+		return nil, fmt.Errorf("getReceipts error: %w", err)
+	}
+	result := make([]hexutility.Bytes, len(receipts))
+	for i, receipt := range receipts {
+		// the bloom is not persisted together with the receipts
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		var buf bytes.Buffer
+		receipts.EncodeIndex(i, &buf)
+		result[i] = buf.Bytes()
+	}
+	return result, nil
+}
+
+// GetRawTransaction implements debug_getRawTransaction. Returns the canonical binary encoding of an included transaction.
+func (api *PrivateDebugAPIImpl) GetRawTransaction(ctx context.Context, txnHash common.Hash) (hexutility.Bytes, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	blockNum, ok, err := api.txnLookup(tx, txnHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	block, err := api.blockByNumberWithSenders(tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, nil
+	}
+	for _, txn := range block.Transactions() {
+		if txn.Hash() == txnHash {
+			var buf bytes.Buffer
+			err = txn.MarshalBinary(&buf)
+			return buf.Bytes(), err
+		}
+	}
+	return nil, nil
+}
+
+// BadBlockArgs represents the entries in the list returned by debug_getBadBlocks
+type BadBlockArgs struct {
+	Hash   common.Hash            `json:"hash"`
+	Block  map[string]interface{} `json:"block"`
+	RLP    hexutility.Bytes       `json:"rlp"`
+	Reason string                 `json:"reason"`
+}
+
+// GetBadBlocks implements debug_getBadBlocks. Returns the last blocks rejected by the block validation, the highest block number first.
+func (api *PrivateDebugAPIImpl) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	badBlocks, err := rawdb.ReadAllBadBlocks(tx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*BadBlockArgs, 0, len(badBlocks))
+	for _, badBlock := range badBlocks {
+		blockRlp, err := rlp.EncodeToBytes(badBlock.Block)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := ethapi.RPCMarshalBlock(badBlock.Block, true, true, nil)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &BadBlockArgs{
+			Hash:   badBlock.Block.Hash(),
+			Block:  fields,
+			RLP:    blockRlp,
+			Reason: badBlock.Reason,
+		})
+	}
+	return results, nil
+}
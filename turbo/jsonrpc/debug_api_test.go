@@ -3,7 +3,12 @@ package jsonrpc
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
@@ -11,16 +16,23 @@ import (
 	"github.com/ledgerwatch/log/v3"
 	"github.com/stretchr/testify/require"
 	"github.com/tenderly/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/tenderly/erigon/core/rawdb"
 	"github.com/tenderly/erigon/core/types"
 	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutility"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces/txpool"
 	"github.com/tenderly/erigon/erigon-lib/kv"
 	"github.com/tenderly/erigon/erigon-lib/kv/iter"
 	"github.com/tenderly/erigon/erigon-lib/kv/kvcache"
 	"github.com/tenderly/erigon/erigon-lib/kv/order"
 	"github.com/tenderly/erigon/eth/tracers"
+	"github.com/tenderly/erigon/rlp"
 	"github.com/tenderly/erigon/rpc"
 	"github.com/tenderly/erigon/rpc/rpccfg"
 	"github.com/tenderly/erigon/turbo/adapter/ethapi"
+	"github.com/tenderly/erigon/turbo/rpchelper"
+	"github.com/tenderly/erigon/turbo/stages/mock"
 )
 
 var dumper = spew.ConfigState{Indent: "    "}
@@ -53,7 +65,7 @@ func TestTraceBlockByNumber(t *testing.T) {
 	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
 	baseApi := NewBaseApi(nil, stateCache, m.BlockReader, agg, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs)
 	ethApi := NewEthAPI(baseApi, m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
-	api := NewPrivateDebugAPI(baseApi, m.DB, 0)
+	api := NewPrivateDebugAPI(baseApi, m.DB, 0, "", 0)
 	for _, tt := range debugTraceTransactionTests {
 		var buf bytes.Buffer
 		stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
@@ -98,7 +110,7 @@ func TestTraceBlockByNumber(t *testing.T) {
 func TestTraceBlockByHash(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	ethApi := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
-	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "", 0)
 	for _, tt := range debugTraceTransactionTests {
 		var buf bytes.Buffer
 		stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
@@ -129,7 +141,7 @@ func TestTraceBlockByHash(t *testing.T) {
 
 func TestTraceTransaction(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
-	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "", 0)
 	for _, tt := range debugTraceTransactionTests {
 		var buf bytes.Buffer
 		stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
@@ -158,7 +170,7 @@ func TestTraceTransaction(t *testing.T) {
 
 func TestTraceTransactionNoRefund(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
-	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "", 0)
 	for _, tt := range debugTraceTransactionNoRefundTests {
 		var buf bytes.Buffer
 		stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
@@ -188,7 +200,7 @@ func TestTraceTransactionNoRefund(t *testing.T) {
 
 func TestStorageRangeAt(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
-	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "", 0)
 	t.Run("invalid addr", func(t *testing.T) {
 		var block4 *types.Block
 		var err error
@@ -282,7 +294,7 @@ func TestStorageRangeAt(t *testing.T) {
 
 func TestAccountRange(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
-	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "", 0)
 
 	t.Run("valid account", func(t *testing.T) {
 		addr := common.HexToAddress("0x537e697c7ab75a26f9ecf0ce810e3154dfcaaf55")
@@ -341,7 +353,7 @@ func TestAccountRange(t *testing.T) {
 
 func TestGetModifiedAccountsByNumber(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
-	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "", 0)
 
 	t.Run("correct input", func(t *testing.T) {
 		n, n2 := rpc.BlockNumber(1), rpc.BlockNumber(2)
@@ -443,7 +455,7 @@ func TestMapTxNum2BlockNum(t *testing.T) {
 
 func TestAccountAt(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
-	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "", 0)
 
 	var blockHash0, blockHash1, blockHash3, blockHash10, blockHash12 common.Hash
 	_ = m.DB.View(m.Ctx, func(tx kv.Tx) error {
@@ -503,3 +515,209 @@ func TestAccountAt(t *testing.T) {
 		require.Equal(0, int(results.Nonce))
 	})
 }
+
+func TestTraceChain(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "", 0)
+
+	var latest uint64
+	err := m.DB.View(m.Ctx, func(tx kv.Tx) (err error) {
+		latest, err = rpchelper.GetLatestBlockNumber(tx)
+		return err
+	})
+	require.NoError(t, err)
+	var results []*blockTraceResult
+	err = api.traceChain(m.Ctx, 1, latest, 3, &tracers.TraceConfig{}, func(res *blockTraceResult) error {
+		results = append(results, res)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, int(latest))
+
+	for i, res := range results {
+		require.Equal(t, uint64(i+1), uint64(res.Block))
+		require.Empty(t, res.Error)
+
+		var buf bytes.Buffer
+		stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
+		require.NoError(t, api.TraceBlockByHash(m.Ctx, res.Hash, &tracers.TraceConfig{}, stream))
+		require.NoError(t, stream.Flush())
+		require.JSONEq(t, buf.String(), string(res.Traces))
+	}
+
+	// a failing notification stops the tracing
+	var sent int
+	err = api.traceChain(m.Ctx, 1, latest, 2, &tracers.TraceConfig{}, func(res *blockTraceResult) error {
+		sent++
+		return errors.New("client gone")
+	})
+	require.ErrorContains(t, err, "client gone")
+	require.Equal(t, 1, sent)
+}
+
+func TestBadBlocks(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "", 0)
+
+	// any block with transactions will do, its traces must be the same as of the canonical one
+	var block *types.Block
+	err := m.DB.Update(m.Ctx, func(tx kv.RwTx) error {
+		for n := uint64(1); block == nil || len(block.Transactions()) == 0; n++ {
+			hash, err := rawdb.ReadCanonicalHash(tx, n)
+			if err != nil {
+				return err
+			}
+			block = rawdb.ReadBlock(tx, hash, n)
+		}
+		return rawdb.WriteBadBlock(tx, block, "invalid receipt root")
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, block.Transactions())
+
+	badBlocks, err := api.GetBadBlocks(m.Ctx)
+	require.NoError(t, err)
+	require.Len(t, badBlocks, 1)
+	require.Equal(t, block.Hash(), badBlocks[0].Hash)
+	require.Equal(t, "invalid receipt root", badBlocks[0].Reason)
+	blockRlp, err := rlp.EncodeToBytes(block)
+	require.NoError(t, err)
+	require.Equal(t, hexutility.Bytes(blockRlp), badBlocks[0].RLP)
+
+	var want, have bytes.Buffer
+	stream := jsoniter.NewStream(jsoniter.ConfigDefault, &want, 4096)
+	require.NoError(t, api.TraceBlockByHash(m.Ctx, block.Hash(), &tracers.TraceConfig{}, stream))
+	require.NoError(t, stream.Flush())
+	stream = jsoniter.NewStream(jsoniter.ConfigDefault, &have, 4096)
+	require.NoError(t, api.TraceBadBlock(m.Ctx, block.Hash(), &tracers.TraceConfig{}, stream))
+	require.NoError(t, stream.Flush())
+	require.JSONEq(t, want.String(), have.String())
+
+	stream = jsoniter.NewStream(jsoniter.ConfigDefault, &have, 4096)
+	require.ErrorContains(t, api.TraceBadBlock(m.Ctx, common.Hash{1}, &tracers.TraceConfig{}, stream), "not found")
+}
+
+func TestStandardTraceBlockToFile(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "traces", 0)
+	require.Equal(t, filepath.Join(m.Dirs.DataDir, "traces"), api.traceDir)
+
+	var block *types.Block
+	err := m.DB.Update(m.Ctx, func(tx kv.RwTx) error {
+		for n := uint64(1); block == nil || len(block.Transactions()) == 0; n++ {
+			hash, err := rawdb.ReadCanonicalHash(tx, n)
+			if err != nil {
+				return err
+			}
+			block = rawdb.ReadBlock(tx, hash, n)
+		}
+		return rawdb.WriteBadBlock(tx, block, "invalid receipt root")
+	})
+	require.NoError(t, err)
+
+	files, err := api.StandardTraceBlockToFile(m.Ctx, block.Hash(), nil)
+	require.NoError(t, err)
+	require.Len(t, files, len(block.Transactions()))
+	for _, file := range files {
+		require.Equal(t, api.traceDir, filepath.Dir(file))
+		blob, err := os.ReadFile(file)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(blob)), "\n")
+		require.NotEmpty(t, lines)
+		for _, line := range lines {
+			require.True(t, json.Valid([]byte(line)), line)
+		}
+		var summary struct {
+			GasUsed *hexutil.Uint64 `json:"gasUsed"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &summary))
+		require.NotNil(t, summary.GasUsed)
+	}
+
+	txHash := block.Transactions()[0].Hash()
+	files, err = api.StandardTraceBlockToFile(m.Ctx, block.Hash(), &tracers.StdTraceConfig{TxHash: &txHash})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	files, err = api.StandardTraceBadBlockToFile(m.Ctx, block.Hash(), nil)
+	require.NoError(t, err)
+	require.Len(t, files, len(block.Transactions()))
+
+	_, err = api.StandardTraceBlockToFile(m.Ctx, block.Hash(), &tracers.StdTraceConfig{TxHash: &common.Hash{1}})
+	require.ErrorContains(t, err, "not found")
+}
+
+// rawList computes the trie root of already encoded list items
+type rawList []hexutility.Bytes
+
+func (l rawList) Len() int                           { return len(l) }
+func (l rawList) EncodeIndex(i int, w *bytes.Buffer) { w.Write(l[i]) }
+
+func TestGetRawReceiptsAndTransaction(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "", 0)
+
+	tx, err := m.DB.BeginRo(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	head, err := rpchelper.GetLatestBlockNumber(tx)
+	require.NoError(t, err)
+	for n := uint64(0); n <= head; n++ {
+		hash, err := rawdb.ReadCanonicalHash(tx, n)
+		require.NoError(t, err)
+		block := rawdb.ReadBlock(tx, hash, n)
+		require.NotNil(t, block)
+
+		receipts, err := api.GetRawReceipts(m.Ctx, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(n)))
+		require.NoError(t, err)
+		require.Len(t, receipts, len(block.Transactions()))
+		require.Equal(t, block.ReceiptHash(), types.DeriveSha(rawList(receipts)), "block %d", n)
+
+		txns := make(rawList, 0, len(block.Transactions()))
+		for _, txn := range block.Transactions() {
+			raw, err := api.GetRawTransaction(m.Ctx, txn.Hash())
+			require.NoError(t, err)
+			txns = append(txns, raw)
+		}
+		require.Equal(t, block.TxHash(), types.DeriveSha(txns), "block %d", n)
+	}
+
+	raw, err := api.GetRawTransaction(m.Ctx, common.Hash{1})
+	require.NoError(t, err)
+	require.Nil(t, raw)
+
+	rawBlock, err := api.GetRawBlock(m.Ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	require.NoError(t, err)
+	var block types.Block
+	require.NoError(t, rlp.DecodeBytes(rawBlock, &block))
+	require.Equal(t, head, block.NumberU64())
+}
+
+func TestGetRawBlockPending(t *testing.T) {
+	m := mock.MockWithTxPool(t)
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, m)
+	ff := rpchelper.New(ctx, nil, txpool.NewTxpoolClient(conn), txpool.NewMiningClient(conn), func() {}, m.Log)
+	baseApi := NewBaseApi(ff, kvcache.New(kvcache.DefaultCoherentConfig), m.BlockReader, m.HistoryV3Components(), false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs)
+	api := NewPrivateDebugAPI(baseApi, m.DB, 0, "", 0)
+	pending := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+
+	// without the pending block the latest one is served
+	rawBlock, err := api.GetRawBlock(m.Ctx, pending)
+	require.NoError(t, err)
+	var block types.Block
+	require.NoError(t, rlp.DecodeBytes(rawBlock, &block))
+	require.Equal(t, uint64(0), block.NumberU64())
+
+	expected := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1), Extra: []byte("pending")})
+	rlpBlock, err := rlp.EncodeToBytes(expected)
+	require.NoError(t, err)
+	ff.HandlePendingBlock(&txpool.OnPendingBlockReply{RplBlock: rlpBlock})
+
+	rawBlock, err = api.GetRawBlock(m.Ctx, pending)
+	require.NoError(t, err)
+	require.Equal(t, hexutility.Bytes(rlpBlock), rawBlock)
+	rawHeader, err := api.GetRawHeader(m.Ctx, pending)
+	require.NoError(t, err)
+	expectedHeader, err := rlp.EncodeToBytes(expected.HeaderNoCopy())
+	require.NoError(t, err)
+	require.Equal(t, hexutility.Bytes(expectedHeader), rawHeader)
+}
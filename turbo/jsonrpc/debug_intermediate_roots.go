@@ -0,0 +1,239 @@
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
+	"github.com/tenderly/erigon/erigon-lib/etl"
+	"github.com/tenderly/erigon/erigon-lib/kv"
+	"github.com/tenderly/erigon/erigon-lib/kv/dbutils"
+	"github.com/tenderly/erigon/erigon-lib/kv/membatchwithdb"
+
+	"github.com/tenderly/erigon/consensus"
+	"github.com/tenderly/erigon/core"
+	"github.com/tenderly/erigon/core/state"
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/types/accounts"
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/core/vm/evmtypes"
+	"github.com/tenderly/erigon/eth/stagedsync"
+	"github.com/tenderly/erigon/turbo/rpchelper"
+	"github.com/tenderly/erigon/turbo/trie"
+)
+
+// IntermediateRootsConfig holds extra parameters to debug_intermediateRoots
+type IntermediateRootsConfig struct {
+	TxIndex *hexutil.Uint64 `json:"txIndex"` // Stop after the transaction with this index, defaults to the whole block
+}
+
+// IntermediateRoots implements debug_intermediateRoots. Re-executes the block on top of its parent state and returns
+// the state root after each transaction. The roots do not include the block rewards and withdrawals, which are only
+// applied after the last transaction. Like eth_getProof, the parent must be within --rpc.maxgetproofrewindblockcount.limit
+// blocks of the head, the hashed state is rewound to it.
+func (api *PrivateDebugAPIImpl) IntermediateRoots(ctx context.Context, blockHash common.Hash, config *IntermediateRootsConfig) ([]common.Hash, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if api.historyV3(tx) {
+		return nil, fmt.Errorf("not supported by Erigon3")
+	}
+
+	block, err := api.blockByHashWithSenders(tx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %x not found", blockHash)
+	}
+	if block.NumberU64() == 0 {
+		return nil, fmt.Errorf("genesis is not traceable")
+	}
+	if err = api.BaseAPI.checkPruneHistory(tx, block.NumberU64()); err != nil {
+		return nil, err
+	}
+	txns := block.Transactions()
+	last := len(txns) - 1
+	if config != nil && config.TxIndex != nil {
+		if int(*config.TxIndex) > last {
+			return nil, fmt.Errorf("transaction index %d out of range for block %x", *config.TxIndex, blockHash)
+		}
+		last = int(*config.TxIndex)
+	}
+
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+	latestBlock, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	if latestBlock < block.NumberU64() {
+		return nil, fmt.Errorf("block is not executed yet latest=%d requested=%d", latestBlock, block.NumberU64())
+	}
+	if latestBlock-(block.NumberU64()-1) > uint64(api.maxRewindBlockCount) {
+		return nil, fmt.Errorf("requested block is too old, its parent must be within %d blocks of the head block number (currently %d)", api.maxRewindBlockCount, latestBlock)
+	}
+
+	// Bring the hashed state back to the parent block, the intermediate hashes are kept
+	// and the keys which changed since the parent block are excluded from them instead
+	batch := membatchwithdb.NewMemoryBatch(tx, api.dirs.Tmp)
+	defer batch.Rollback()
+	logger := log.New("rpc", "debug_intermediateRoots")
+	unwindState := &stagedsync.UnwindState{UnwindPoint: block.NumberU64() - 1}
+	stageState := &stagedsync.StageState{BlockNumber: latestBlock}
+	hashStageCfg := stagedsync.StageHashStateCfg(nil, api.dirs, false)
+	if err = stagedsync.UnwindHashStateStage(unwindState, stageState, batch, hashStageCfg, ctx, logger); err != nil {
+		return nil, err
+	}
+	writer := newHashedStateWriter(batch)
+	promoter := stagedsync.NewHashPromoter(batch, api.dirs.Tmp, ctx.Done(), "debug_intermediateRoots", logger)
+	collect := func(k, v []byte, _ etl.CurrentTableReader, _ etl.LoadNextFunc) error {
+		writer.changed[string(k)] = len(v) == 0
+		return nil
+	}
+	if err = promoter.Unwind("debug_intermediateRoots", stageState, unwindState, false /* storage */, collect); err != nil {
+		return nil, err
+	}
+	if err = promoter.Unwind("debug_intermediateRoots", stageState, unwindState, true /* storage */, collect); err != nil {
+		return nil, err
+	}
+
+	reader, err := rpchelper.CreateHistoryStateReader(tx, block.NumberU64(), 0, false, chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+	ibs := state.New(reader)
+
+	header := block.HeaderNoCopy()
+	engine := api.engine()
+	getHeader := func(hash common.Hash, n uint64) *types.Header {
+		h, _ := api._blockReader.HeaderByNumber(ctx, tx, n)
+		return h
+	}
+	blockCtx := core.NewEVMBlockContext(header, core.GetHashFn(header, getHeader), engine, nil)
+	evm := vm.NewEVM(blockCtx, evmtypes.TxContext{}, ibs, chainConfig, vm.Config{})
+	rules := evm.ChainRules()
+	signer := types.MakeSigner(chainConfig, block.NumberU64(), block.Time())
+
+	// System calls made before the first transaction are part of its root, so they are
+	// written to the hashed state as well, unlike in core.InitializeBlockExecution
+	chainReader := stagedsync.NewChainReaderImpl(chainConfig, tx, nil, nil)
+	engine.(consensus.Engine).Initialize(chainConfig, chainReader, header, ibs, func(contract common.Address, data []byte, ibState *state.IntraBlockState, header *types.Header, constCall bool) ([]byte, error) {
+		return core.SysCallContract(contract, data, chainConfig, ibState, header, engine, constCall)
+	}, logger)
+	if err = ibs.FinalizeTx(rules, writer); err != nil {
+		return nil, err
+	}
+
+	roots := make([]common.Hash, 0, last+1)
+	for idx, txn := range txns[:last+1] {
+		select {
+		default:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		ibs.SetTxContext(txn.Hash(), block.Hash(), idx)
+		msg, _ := txn.AsMessage(*signer, block.BaseFee(), rules)
+		if msg.FeeCap().IsZero() && engine != nil {
+			syscall := func(contract common.Address, data []byte) ([]byte, error) {
+				return core.SysCallContract(contract, data, chainConfig, ibs, header, engine, true /* constCall */)
+			}
+			msg.SetIsFree(engine.IsServiceTransaction(msg.From(), syscall))
+		}
+		evm.Reset(core.NewEVMTxContext(msg), ibs)
+		if _, err = core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(txn.GetGas()).AddBlobGas(txn.GetBlobGas()), true /* refunds */, false /* gasBailout */); err != nil {
+			return nil, fmt.Errorf("transaction %x failed: %w", txn.Hash(), err)
+		}
+		if err = ibs.FinalizeTx(rules, writer); err != nil {
+			return nil, err
+		}
+		root, err := writer.root(ctx)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+	}
+	return roots, nil
+}
+
+// hashedStateWriter applies the changes of every transaction to the hashed state and remembers the changed
+// keys, so that the state root can be recalculated with the intermediate hashes which are still valid
+type hashedStateWriter struct {
+	tx      kv.RwTx
+	changed map[string]bool // hashed key -> deleted
+}
+
+func newHashedStateWriter(tx kv.RwTx) *hashedStateWriter {
+	return &hashedStateWriter{tx: tx, changed: map[string]bool{}}
+}
+
+func (w *hashedStateWriter) root(ctx context.Context) (common.Hash, error) {
+	rl := trie.NewRetainList(0)
+	for k, deleted := range w.changed {
+		rl.AddKeyWithMarker([]byte(k), deleted)
+	}
+	loader := trie.NewFlatDBTrieLoader("debug_intermediateRoots", rl, nil, nil, false)
+	return loader.CalcTrieRoot(w.tx, ctx.Done())
+}
+
+func (w *hashedStateWriter) UpdateAccountData(address common.Address, original, account *accounts.Account) error {
+	addrHash, err := common.HashData(address[:])
+	if err != nil {
+		return err
+	}
+	value := make([]byte, account.EncodingLengthForStorage())
+	account.EncodeForStorage(value)
+	w.changed[string(addrHash[:])] = false
+	return w.tx.Put(kv.HashedAccounts, addrHash[:], value)
+}
+
+func (w *hashedStateWriter) UpdateAccountCode(address common.Address, incarnation uint64, codeHash common.Hash, code []byte) error {
+	// the code hash is part of the account data, the code itself doesn't affect the root
+	return nil
+}
+
+func (w *hashedStateWriter) DeleteAccount(address common.Address, original *accounts.Account) error {
+	addrHash, err := common.HashData(address[:])
+	if err != nil {
+		return err
+	}
+	w.changed[string(addrHash[:])] = true
+	if err := w.tx.Delete(kv.HashedAccounts, addrHash[:]); err != nil {
+		return err
+	}
+	// intermediate hashes of the storage of the deleted account are not valid anymore
+	return w.tx.ForPrefix(kv.TrieOfStorage, addrHash[:], func(k, _ []byte) error {
+		return w.tx.Delete(kv.TrieOfStorage, k)
+	})
+}
+
+func (w *hashedStateWriter) WriteAccountStorage(address common.Address, incarnation uint64, key *common.Hash, original, value *uint256.Int) error {
+	// original is the value at the beginning of the block, so it can't be used to skip the write
+	seckey, err := common.HashData(key[:])
+	if err != nil {
+		return err
+	}
+	addrHash, err := common.HashData(address[:])
+	if err != nil {
+		return err
+	}
+	compositeKey := dbutils.GenerateCompositeStorageKey(addrHash, incarnation, seckey)
+	v := value.Bytes()
+	w.changed[string(compositeKey)] = len(v) == 0
+	if len(v) == 0 {
+		return w.tx.Delete(kv.HashedStorage, compositeKey)
+	}
+	return w.tx.Put(kv.HashedStorage, compositeKey, v)
+}
+
+func (w *hashedStateWriter) CreateContract(address common.Address) error {
+	return nil
+}
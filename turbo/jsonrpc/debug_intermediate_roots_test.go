@@ -0,0 +1,111 @@
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
+
+	"github.com/tenderly/erigon/core"
+	"github.com/tenderly/erigon/core/state"
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/crypto"
+	"github.com/tenderly/erigon/params"
+	"github.com/tenderly/erigon/turbo/stages/mock"
+	"github.com/tenderly/erigon/turbo/transactions"
+)
+
+func TestIntermediateRoots(t *testing.T) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address  = crypto.PubkeyToAddress(key.PublicKey)
+		contract = hexutil.MustDecode(contractHexString)
+		signer   = types.LatestSignerForChainID(nil)
+		gspec    = &types.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{address: {Balance: big.NewInt(params.Ether)}},
+		}
+	)
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	if m.HistoryV3 {
+		t.Skip("not supported by Erigon3")
+	}
+
+	var contractAddr libcommon.Address
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 3, func(i int, b *core.BlockGen) {
+		switch i {
+		case 0:
+			txn, err := types.SignTx(types.NewContractCreation(b.TxNonce(address), new(uint256.Int), 1e6, new(uint256.Int), contract), *signer, key)
+			require.NoError(t, err)
+			b.AddTx(txn)
+			contractAddr = crypto.CreateAddress(address, txn.GetNonce())
+		default:
+			for j := 0; j < 3; j++ {
+				txn, err := types.SignTx(types.NewTransaction(b.TxNonce(address), contractAddr, new(uint256.Int), 900000, new(uint256.Int), contractInvocationData(byte(i*3+j))), *signer, key)
+				require.NoError(t, err)
+				b.AddTx(txn)
+			}
+		}
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chain))
+
+	// expectedRoot recalculates the root after the given transaction from scratch, on top of a
+	// separate database which only contains the parent blocks
+	expectedRoot := func(blockIdx, txIndex int) libcommon.Hash {
+		block := chain.Blocks[blockIdx]
+		tx, err := m.DB.BeginRo(m.Ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		_, _, _, ibs, _, err := transactions.ComputeTxEnv(m.Ctx, m.Engine, block, m.ChainConfig, m.BlockReader, tx, txIndex+1, false)
+		require.NoError(t, err)
+
+		parent := mock.MockWithGenesis(t, gspec, key, false)
+		if blockIdx > 0 {
+			require.NoError(t, parent.InsertChain(chain.Slice(0, blockIdx)))
+		}
+		rwTx, err := parent.DB.BeginRw(parent.Ctx)
+		require.NoError(t, err)
+		defer rwTx.Rollback()
+		rules := m.ChainConfig.Rules(block.NumberU64(), block.Time())
+		require.NoError(t, ibs.CommitBlock(rules, state.NewPlainStateWriter(rwTx, nil, block.NumberU64())))
+		root, err := core.CalcHashRootForTests(rwTx, block.Header(), false)
+		require.NoError(t, err)
+		return root
+	}
+
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, "", 100_000)
+	for blockIdx, block := range chain.Blocks {
+		roots, err := api.IntermediateRoots(context.Background(), block.Hash(), nil)
+		require.NoError(t, err)
+		require.Len(t, roots, len(block.Transactions()))
+		for txIndex := range roots {
+			require.Equal(t, expectedRoot(blockIdx, txIndex), roots[txIndex], "block %d tx %d", block.NumberU64(), txIndex)
+		}
+	}
+
+	block := chain.Blocks[1]
+	roots, err := api.IntermediateRoots(context.Background(), block.Hash(), nil)
+	require.NoError(t, err)
+	require.NotEqual(t, roots[0], roots[1])
+	txIndex := hexutil.Uint64(1)
+	partial, err := api.IntermediateRoots(context.Background(), block.Hash(), &IntermediateRootsConfig{TxIndex: &txIndex})
+	require.NoError(t, err)
+	require.Equal(t, roots[:2], partial)
+
+	txIndex = 3
+	_, err = api.IntermediateRoots(context.Background(), block.Hash(), &IntermediateRootsConfig{TxIndex: &txIndex})
+	require.ErrorContains(t, err, "out of range")
+
+	// the parent of block 2 is 2 blocks behind the head
+	api.maxRewindBlockCount = 1
+	_, err = api.IntermediateRoots(context.Background(), block.Hash(), nil)
+	require.ErrorContains(t, err, "too old")
+	_, err = api.IntermediateRoots(context.Background(), chain.Blocks[2].Hash(), nil)
+	require.NoError(t, err)
+}
@@ -0,0 +1,119 @@
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutility"
+	"github.com/tenderly/erigon/erigon-lib/kv"
+
+	"github.com/tenderly/erigon/core/rawdb"
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/rpc"
+	"github.com/tenderly/erigon/turbo/rpchelper"
+)
+
+// BlobSidecar is a blob of a mined transaction together with its KZG commitment and proof
+type BlobSidecar struct {
+	BlockHash     common.Hash      `json:"blockHash"`
+	BlockNumber   hexutil.Uint64   `json:"blockNumber"`
+	TxHash        common.Hash      `json:"transactionHash"`
+	TxIndex       hexutil.Uint64   `json:"transactionIndex"`
+	Index         hexutil.Uint64   `json:"index"` // position of the blob in the block, as in the beacon API
+	VersionedHash common.Hash      `json:"versionedHash"`
+	Blob          hexutility.Bytes `json:"blob"`
+	KZGCommitment hexutility.Bytes `json:"kzgCommitment"`
+	KZGProof      hexutility.Bytes `json:"kzgProof"`
+}
+
+// GetBlobSidecars implements eth_getBlobSidecars. Returns the blobs of the block in the retention window.
+// The blobs which the node didn't receive before the block was mined are not available and left out.
+func (api *APIImpl) GetBlobSidecars(ctx context.Context, numberOrHash rpc.BlockNumberOrHash) ([]*BlobSidecar, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, blockHash, _, err := rpchelper.GetBlockNumber(numberOrHash, tx, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.blockWithSenders(tx, blockHash, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, nil // not error, see https://github.com/tenderly/erigon/issues/1645
+	}
+	return api.blockBlobSidecars(tx, block, nil)
+}
+
+// GetBlobSidecarByVersionedHash implements eth_getBlobSidecarByVersionedHash. Returns the blob with the given versioned
+// hash if it was mined in the retention window.
+func (api *APIImpl) GetBlobSidecarByVersionedHash(ctx context.Context, versionedHash common.Hash) (*BlobSidecar, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, _, ok, err := rawdb.ReadBlobSidecarLookup(tx, versionedHash)
+	if err != nil || !ok {
+		return nil, err
+	}
+	block, err := api.blockByNumberWithSenders(tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, nil
+	}
+	sidecars, err := api.blockBlobSidecars(tx, block, &versionedHash)
+	if err != nil || len(sidecars) == 0 {
+		return nil, err
+	}
+	return sidecars[0], nil
+}
+
+// blockBlobSidecars collects the stored blobs of the block, only the one with the given versioned hash if it is set
+func (api *APIImpl) blockBlobSidecars(tx kv.Tx, block *types.Block, versionedHash *common.Hash) ([]*BlobSidecar, error) {
+	var (
+		result []*BlobSidecar
+		index  uint64
+	)
+	for txIndex, txn := range block.Transactions() {
+		blobHashes := txn.GetBlobHashes()
+		if len(blobHashes) == 0 {
+			continue
+		}
+		sidecar, err := rawdb.ReadBlobSidecar(tx, block.NumberU64(), txn.Hash())
+		if err != nil {
+			return nil, err
+		}
+		for i, blobHash := range blobHashes {
+			blobIndex := index
+			index++
+			if sidecar == nil || i >= len(sidecar.Blobs) || (versionedHash != nil && *versionedHash != blobHash) {
+				continue
+			}
+			if sidecar.Commitments[i].ComputeVersionedHash() != blobHash {
+				return nil, fmt.Errorf("blob %d of transaction %x doesn't match its versioned hash", i, txn.Hash())
+			}
+			result = append(result, &BlobSidecar{
+				BlockHash:     block.Hash(),
+				BlockNumber:   hexutil.Uint64(block.NumberU64()),
+				TxHash:        txn.Hash(),
+				TxIndex:       hexutil.Uint64(txIndex),
+				Index:         hexutil.Uint64(blobIndex),
+				VersionedHash: blobHash,
+				Blob:          sidecar.Blobs[i][:],
+				KZGCommitment: sidecar.Commitments[i][:],
+				KZGProof:      sidecar.Proofs[i][:],
+			})
+		}
+	}
+	return result, nil
+}
@@ -10,7 +10,6 @@ import (
 
 	"github.com/ledgerwatch/log/v3"
 	"github.com/tenderly/erigon/erigon-lib/common"
-	"github.com/tenderly/erigon/erigon-lib/common/hexutility"
 	"github.com/tenderly/erigon/erigon-lib/kv"
 
 	"github.com/tenderly/erigon/common/math"
@@ -19,7 +18,6 @@ import (
 	"github.com/tenderly/erigon/core/state"
 	"github.com/tenderly/erigon/core/types"
 	"github.com/tenderly/erigon/core/vm"
-	"github.com/tenderly/erigon/crypto/cryptopool"
 	"github.com/tenderly/erigon/rpc"
 	"github.com/tenderly/erigon/turbo/adapter/ethapi"
 	"github.com/tenderly/erigon/turbo/rpchelper"
@@ -121,8 +119,9 @@ func (api *APIImpl) CallBundle(ctx context.Context, txHashes []common.Hash, stat
 
 	blockCtx := transactions.NewEVMBlockContext(engine, header, stateBlockNumberOrHash.RequireCanonical, tx, api._blockReader)
 	txCtx := core.NewEVMTxContext(firstMsg)
-	// Get a new instance of the EVM
-	evm := vm.NewEVM(blockCtx, txCtx, ibs, chainConfig, vm.Config{Debug: false})
+	// Get a new instance of the EVM, the nonces are not checked
+	vmConfig := vm.Config{StatelessExec: true, NoReceipts: true}
+	evm := vm.NewEVM(blockCtx, txCtx, ibs, chainConfig, vmConfig)
 
 	timeoutMilliSeconds := int64(5000)
 	if timeoutMilliSecondsPtr != nil {
@@ -148,41 +147,33 @@ func (api *APIImpl) CallBundle(ctx context.Context, txHashes []common.Hash, stat
 		evm.Cancel()
 	}()
 
+	// If the timer caused an abort, return an appropriate error message
+	aborted := func() error {
+		if evm.Cancelled() {
+			return fmt.Errorf("execution aborted (timeout = %v)", timeout)
+		}
+		return nil
+	}
+
 	// Setup the gas pool (also for unmetered requests)
-	// and apply the message.
+	// and apply the bundle, its transactions are all allowed to revert.
 	gp := new(core.GasPool).AddGas(math.MaxUint64).AddBlobGas(math.MaxUint64)
+	var usedGas, usedBlobGas uint64
+	bundleResults, err := core.ApplyBundle(chainConfig, engine, gp, ibs, state.NewNoopWriter(), header, txs, 0, nil, aborted, &usedGas, &usedBlobGas, evm, vmConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	results := []map[string]interface{}{}
-
-	bundleHash := cryptopool.NewLegacyKeccak256()
-	defer cryptopool.ReturnToPoolKeccak256(bundleHash)
-
-	for _, txn := range txs {
-		msg, err := txn.AsMessage(*signer, nil, rules)
-		msg.SetCheckNonce(false)
-		if err != nil {
-			return nil, err
-		}
-		// Execute the transaction message
-		result, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */)
-		if err != nil {
-			return nil, err
-		}
-		// If the timer caused an abort, return an appropriate error message
-		if evm.Cancelled() {
-			return nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
-		}
-
-		txHash := txn.Hash().String()
+	for i, result := range bundleResults {
 		jsonResult := map[string]interface{}{
-			"txHash":  txHash,
-			"gasUsed": result.UsedGas,
+			"txHash":  txs[i].Hash().String(),
+			"gasUsed": result.Result.UsedGas,
 		}
-		bundleHash.Write(txn.Hash().Bytes())
-		if result.Err != nil {
-			jsonResult["error"] = result.Err.Error()
+		if result.Result.Err != nil {
+			jsonResult["error"] = result.Result.Err.Error()
 		} else {
-			jsonResult["value"] = common.BytesToHash(result.Return())
+			jsonResult["value"] = common.BytesToHash(result.Result.Return())
 		}
 
 		results = append(results, jsonResult)
@@ -190,7 +181,7 @@ func (api *APIImpl) CallBundle(ctx context.Context, txHashes []common.Hash, stat
 
 	ret := map[string]interface{}{}
 	ret["results"] = results
-	ret["bundleHash"] = hexutility.Encode(bundleHash.Sum(nil))
+	ret["bundleHash"] = core.BundleHash(txs).Hex()
 	return ret, nil
 }
 
@@ -0,0 +1,532 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/tenderly/erigon/erigon-lib/chain"
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutility"
+
+	"github.com/tenderly/erigon/consensus"
+	"github.com/tenderly/erigon/consensus/misc"
+	"github.com/tenderly/erigon/core"
+	"github.com/tenderly/erigon/core/state"
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/core/vm/evmtypes"
+	"github.com/tenderly/erigon/crypto"
+	"github.com/tenderly/erigon/rpc"
+	ethapi2 "github.com/tenderly/erigon/turbo/adapter/ethapi"
+	"github.com/tenderly/erigon/turbo/rpchelper"
+	"github.com/tenderly/erigon/turbo/transactions"
+)
+
+const (
+	// maxSimulateBlocks limits the number of blocks (including the empty ones used to fill gaps) that a single
+	// eth_simulateV1 request may produce
+	maxSimulateBlocks = 256
+	// simulateBlockTime is the default time distance between two consecutive simulated blocks
+	simulateBlockTime = 12
+
+	simulateErrCodeReverted = 3
+	simulateErrCodeVMError  = -32015
+)
+
+var (
+	// transferLogAddress is the pseudo-address used as emitter of the synthetic ETH transfer logs
+	transferLogAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+	// transferTopic is the ERC-20 Transfer(address,address,uint256) event signature
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+)
+
+// SimulationOpts is the request object of eth_simulateV1
+type SimulationOpts struct {
+	BlockStateCalls        []SimulatedBlock `json:"blockStateCalls"`
+	TraceTransfers         bool             `json:"traceTransfers"`
+	Validation             bool             `json:"validation"`
+	ReturnFullTransactions bool             `json:"returnFullTransactions"`
+	ReturnReceipts         bool             `json:"returnReceipts"`
+}
+
+// SimulatedBlock describes one block of the simulated chain: the overrides applied to its header,
+// the state overrides applied before its first call and the calls it contains
+type SimulatedBlock struct {
+	BlockOverrides *SimulationBlockOverrides `json:"blockOverrides"`
+	StateOverrides *ethapi2.StateOverrides   `json:"stateOverrides"`
+	Calls          []ethapi2.CallArgs        `json:"calls"`
+}
+
+// SimulationBlockOverrides are the header fields which may be overridden for a simulated block
+type SimulationBlockOverrides struct {
+	Number        *hexutil.Big    `json:"number"`
+	Time          *hexutil.Uint64 `json:"time"`
+	GasLimit      *hexutil.Uint64 `json:"gasLimit"`
+	FeeRecipient  *common.Address `json:"feeRecipient"`
+	PrevRandao    *common.Hash    `json:"prevRandao"`
+	BaseFeePerGas *hexutil.Big    `json:"baseFeePerGas"`
+}
+
+// SimulatedCallResult is the outcome of a single call of a simulated block
+type SimulatedCallResult struct {
+	ReturnData hexutility.Bytes    `json:"returnData"`
+	Logs       []*types.Log        `json:"logs"`
+	GasUsed    hexutil.Uint64      `json:"gasUsed"`
+	Status     hexutil.Uint64      `json:"status"`
+	Error      *SimulatedCallError `json:"error,omitempty"`
+}
+
+// SimulatedCallError is reported for calls which reverted or failed inside the EVM
+type SimulatedCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// SimulateV1 implements eth_simulateV1. Executes a sequence of blocks on top of the given block, each with its own
+// block overrides, state overrides and calls. State changes are chained from one call and one block to the next.
+// The state root of the simulated blocks is not recomputed and is inherited from the base block.
+func (api *APIImpl) SimulateV1(ctx context.Context, opts SimulationOpts, blockNrOrHash *rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	if len(opts.BlockStateCalls) == 0 {
+		return nil, fmt.Errorf("empty input")
+	}
+	if blockNrOrHash == nil {
+		blockNrOrHash = &latestNumOrHash
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+	engine := api.engine()
+
+	blockNumber, hash, _, err := rpchelper.GetCanonicalBlockNumber(*blockNrOrHash, tx, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.blockWithSenders(tx, hash, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d(%x) not found", blockNumber, hash)
+	}
+
+	plan, err := planSimulatedBlocks(block.HeaderNoCopy(), opts.BlockStateCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	stateReader, err := rpchelper.CreateStateReader(ctx, tx, *blockNrOrHash, 0, api.filters, api.stateCache, api.historyV3(tx), chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(start time.Time) { log.Trace("Executing EVM simulateV1 finished", "runtime", time.Since(start)) }(time.Now())
+
+	var cancel context.CancelFunc
+	if api.evmCallTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, api.evmCallTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	sim := &simulator{
+		api:         api,
+		ctx:         ctx,
+		chainConfig: chainConfig,
+		engine:      engine,
+		opts:        opts,
+		ibs:         state.New(stateReader),
+		hashes:      make(map[uint64]common.Hash, len(plan)),
+		canonical:   transactions.MakeHeaderGetter(blockNrOrHash.RequireCanonical, tx, api._blockReader),
+	}
+
+	results := make([]map[string]interface{}, 0, len(plan))
+	parent := block.HeaderNoCopy()
+	for _, planned := range plan {
+		header := simulatedHeader(chainConfig, parent, planned, opts.Validation)
+		fields, err := sim.simulateBlock(header, planned.block)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, fields)
+		parent = header
+	}
+	return results, nil
+}
+
+// plannedBlock is the position of a simulated block in the simulated chain
+type plannedBlock struct {
+	number, time uint64
+	overrides    *SimulationBlockOverrides
+	block        SimulatedBlock
+}
+
+// planSimulatedBlocks assigns numbers and timestamps to the simulated blocks. Blocks whose number override leaves
+// a gap to the previous block are preceded by empty blocks, so that BLOCKHASH and NUMBER stay consistent.
+func planSimulatedBlocks(base *types.Header, blocks []SimulatedBlock) ([]plannedBlock, error) {
+	plan := make([]plannedBlock, 0, len(blocks))
+	prevNumber, prevTime := base.Number.Uint64(), base.Time
+	for _, simBlock := range blocks {
+		overrides := simBlock.BlockOverrides
+		if overrides == nil {
+			overrides = &SimulationBlockOverrides{}
+		}
+
+		number := prevNumber + 1
+		if overrides.Number != nil {
+			if !overrides.Number.ToInt().IsUint64() {
+				return nil, fmt.Errorf("block number %s out of range", overrides.Number.ToInt())
+			}
+			number = overrides.Number.ToInt().Uint64()
+			if number <= prevNumber {
+				return nil, fmt.Errorf("block numbers must be in order: %d <= %d", number, prevNumber)
+			}
+		}
+		if number-base.Number.Uint64() > maxSimulateBlocks {
+			return nil, fmt.Errorf("too many blocks to simulate: max %d", maxSimulateBlocks)
+		}
+		// fill the gap with empty blocks
+		for n := prevNumber + 1; n < number; n++ {
+			prevTime += simulateBlockTime
+			plan = append(plan, plannedBlock{number: n, time: prevTime, overrides: &SimulationBlockOverrides{}})
+		}
+
+		timestamp := prevTime + simulateBlockTime
+		if overrides.Time != nil {
+			timestamp = uint64(*overrides.Time)
+			if timestamp <= prevTime {
+				return nil, fmt.Errorf("block timestamps must be in order: %d <= %d", timestamp, prevTime)
+			}
+		}
+		plan = append(plan, plannedBlock{number: number, time: timestamp, overrides: overrides, block: simBlock})
+		prevNumber, prevTime = number, timestamp
+	}
+	return plan, nil
+}
+
+// simulatedHeader builds the header of a simulated block on top of its already executed parent
+func simulatedHeader(chainConfig *chain.Config, parent *types.Header, planned plannedBlock, validation bool) *types.Header {
+	number, timestamp, overrides := planned.number, planned.time, planned.overrides
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).SetUint64(number),
+		Time:       timestamp,
+		GasLimit:   parent.GasLimit,
+		Coinbase:   parent.Coinbase,
+		Difficulty: new(big.Int).Set(parent.Difficulty),
+		MixDigest:  parent.MixDigest,
+		Root:       parent.Root,
+		UncleHash:  types.EmptyUncleHash,
+	}
+	if overrides.GasLimit != nil {
+		header.GasLimit = uint64(*overrides.GasLimit)
+	}
+	if overrides.FeeRecipient != nil {
+		header.Coinbase = *overrides.FeeRecipient
+	}
+	if overrides.PrevRandao != nil {
+		header.MixDigest = *overrides.PrevRandao
+	}
+	if chainConfig.IsLondon(number) {
+		switch {
+		case overrides.BaseFeePerGas != nil:
+			header.BaseFee = new(big.Int).Set(overrides.BaseFeePerGas.ToInt())
+		case validation:
+			header.BaseFee = misc.CalcBaseFee(chainConfig, parent)
+		default:
+			// without validation calls are free by default, like eth_call
+			header.BaseFee = new(big.Int)
+		}
+	}
+	if chainConfig.IsCancun(timestamp) {
+		excessBlobGas := misc.CalcExcessBlobGas(chainConfig, parent)
+		header.ExcessBlobGas = &excessBlobGas
+		header.ParentBeaconBlockRoot = &common.Hash{}
+	}
+	return header
+}
+
+// simulator holds the state shared between the blocks of one eth_simulateV1 request
+type simulator struct {
+	api         *APIImpl
+	ctx         context.Context
+	chainConfig *chain.Config
+	engine      consensus.EngineReader
+	opts        SimulationOpts
+	ibs         *state.IntraBlockState
+	hashes      map[uint64]common.Hash // hashes of the already simulated blocks
+	canonical   func(uint64) common.Hash
+}
+
+func (s *simulator) getHash(n uint64) common.Hash {
+	if hash, ok := s.hashes[n]; ok {
+		return hash
+	}
+	return s.canonical(n)
+}
+
+func (s *simulator) simulateBlock(header *types.Header, simBlock SimulatedBlock) (map[string]interface{}, error) {
+	if simBlock.StateOverrides != nil {
+		if err := simBlock.StateOverrides.Override(s.ibs); err != nil {
+			return nil, err
+		}
+	}
+
+	blockNumber := header.Number.Uint64()
+	rules := s.chainConfig.Rules(blockNumber, header.Time)
+	blockCtx := core.NewEVMBlockContext(header, s.getHash, s.engine, &header.Coinbase)
+	if header.Difficulty.Sign() == 0 {
+		// post-merge: expose prevRandao even if the base block was pre-merge
+		blockCtx.PrevRanDao = &header.MixDigest
+	}
+
+	var tracer *transferTracer
+	vmConfig := vm.Config{NoBaseFee: !s.opts.Validation}
+	if s.opts.TraceTransfers {
+		tracer = &transferTracer{}
+		vmConfig.Debug, vmConfig.Tracer = true, tracer
+	}
+
+	var (
+		txs      = make(types.Transactions, 0, len(simBlock.Calls))
+		receipts = make(types.Receipts, 0, len(simBlock.Calls))
+		calls    = make([]SimulatedCallResult, 0, len(simBlock.Calls))
+		gp       = new(core.GasPool).AddGas(header.GasLimit).AddBlobGas(s.chainConfig.GetMaxBlobGasPerBlock())
+		gasUsed  uint64
+		blobGas  uint64
+	)
+	for i, args := range simBlock.Calls {
+		if err := s.ctx.Err(); err != nil {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", s.api.evmCallTimeout)
+		}
+
+		txn, msg, err := s.prepareCall(args, header, gp.Gas())
+		if err != nil {
+			return nil, fmt.Errorf("block %d, call %d: %w", blockNumber, i, err)
+		}
+		s.ibs.SetTxContext(txn.Hash(), common.Hash{}, i)
+
+		evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), s.ibs, s.chainConfig, vmConfig)
+		done := make(chan struct{})
+		go func() {
+			select {
+			case <-s.ctx.Done():
+				evm.Cancel()
+			case <-done:
+			}
+		}()
+		result, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */)
+		close(done)
+		if err != nil {
+			return nil, fmt.Errorf("block %d, call %d: %w", blockNumber, i, err)
+		}
+		if evm.Cancelled() {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", s.api.evmCallTimeout)
+		}
+		if err = s.ibs.FinalizeTx(rules, state.NewNoopWriter()); err != nil {
+			return nil, err
+		}
+
+		gasUsed += result.UsedGas
+		blobGas += msg.BlobGas()
+		receipt := &types.Receipt{
+			Type:              txn.Type(),
+			CumulativeGasUsed: gasUsed,
+			Logs:              s.ibs.GetLogs(txn.Hash()),
+			TxHash:            txn.Hash(),
+			GasUsed:           result.UsedGas,
+			BlockNumber:       new(big.Int).Set(header.Number),
+			TransactionIndex:  uint(i),
+		}
+		if result.Failed() {
+			receipt.Status = types.ReceiptStatusFailed
+		} else {
+			receipt.Status = types.ReceiptStatusSuccessful
+		}
+		if msg.To() == nil {
+			receipt.ContractAddress = crypto.CreateAddress(msg.From(), txn.GetNonce())
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+		txs = append(txs, txn)
+		receipts = append(receipts, receipt)
+		calls = append(calls, newSimulatedCallResult(result, receipt))
+	}
+
+	header.GasUsed = gasUsed
+	if header.ExcessBlobGas != nil {
+		header.BlobGasUsed = &blobGas
+	}
+	var withdrawals []*types.Withdrawal
+	if rules.IsShanghai {
+		withdrawals = []*types.Withdrawal{}
+	}
+	block := types.NewBlock(header, txs, nil, receipts, withdrawals)
+	blockHash := block.Hash()
+	s.hashes[blockNumber] = blockHash
+	*header = *block.HeaderNoCopy()
+
+	for _, receipt := range receipts {
+		receipt.BlockHash = blockHash
+		for _, l := range receipt.Logs {
+			l.BlockHash = blockHash
+			l.BlockNumber = blockNumber
+		}
+	}
+
+	additional := map[string]interface{}{"calls": calls}
+	if s.opts.ReturnReceipts {
+		marshalled := make([]map[string]interface{}, len(receipts))
+		for i, receipt := range receipts {
+			marshalled[i] = marshalReceipt(receipt, txs[i], s.chainConfig, block.HeaderNoCopy(), receipt.TxHash, true)
+		}
+		additional["receipts"] = marshalled
+	}
+	return ethapi2.RPCMarshalBlock(block, true, s.opts.ReturnFullTransactions, additional)
+}
+
+// prepareCall fills in the defaults of the call arguments (nonce, gas, fees) and converts them into a sender-annotated
+// transaction, used for the block body, and the message which is actually executed
+func (s *simulator) prepareCall(args ethapi2.CallArgs, header *types.Header, remainingGas uint64) (types.Transaction, types.Message, error) {
+	var from common.Address
+	if args.From != nil {
+		from = *args.From
+	}
+	if args.Gas == nil || uint64(*args.Gas) == 0 {
+		gas := remainingGas
+		if s.api.GasCap < gas {
+			gas = s.api.GasCap
+		}
+		args.Gas = (*hexutil.Uint64)(&gas)
+	}
+	nonce := s.ibs.GetNonce(from)
+	if args.Nonce != nil {
+		nonce = uint64(*args.Nonce)
+	}
+
+	var baseFee *uint256.Int
+	if header.BaseFee != nil {
+		var overflow bool
+		baseFee, overflow = uint256.FromBig(header.BaseFee)
+		if overflow {
+			return nil, types.Message{}, fmt.Errorf("header.BaseFee uint256 overflow")
+		}
+	}
+	callMsg, err := args.ToMessage(s.api.GasCap, baseFee)
+	if err != nil {
+		return nil, types.Message{}, err
+	}
+	// without validation the nonce is not checked, but still reported in the simulated transaction
+	msg := types.NewMessage(callMsg.From(), callMsg.To(), nonce, callMsg.Value(), callMsg.Gas(), callMsg.GasPrice(),
+		callMsg.FeeCap(), callMsg.Tip(), callMsg.Data(), callMsg.AccessList(), s.opts.Validation /* checkNonce */, false /* isFree */, callMsg.MaxFeePerBlobGas())
+
+	var chainID *uint256.Int
+	if args.ChainID != nil {
+		chainID, _ = uint256.FromBig(args.ChainID.ToInt())
+	} else {
+		chainID, _ = uint256.FromBig(s.chainConfig.ChainID)
+	}
+	var txn types.Transaction
+	if baseFee != nil && args.GasPrice == nil {
+		txn = &types.DynamicFeeTransaction{
+			CommonTx: types.CommonTx{
+				Nonce: nonce,
+				Gas:   msg.Gas(),
+				To:    msg.To(),
+				Value: msg.Value(),
+				Data:  msg.Data(),
+			},
+			ChainID:    chainID,
+			Tip:        msg.Tip(),
+			FeeCap:     msg.FeeCap(),
+			AccessList: msg.AccessList(),
+		}
+	} else {
+		txn = &types.LegacyTx{
+			CommonTx: types.CommonTx{
+				Nonce: nonce,
+				Gas:   msg.Gas(),
+				To:    msg.To(),
+				Value: msg.Value(),
+				Data:  msg.Data(),
+			},
+			GasPrice: msg.GasPrice(),
+		}
+	}
+	txn.SetSender(from)
+	return txn, msg, nil
+}
+
+func newSimulatedCallResult(result *core.ExecutionResult, receipt *types.Receipt) SimulatedCallResult {
+	callResult := SimulatedCallResult{
+		ReturnData: result.Return(),
+		Logs:       receipt.Logs,
+		GasUsed:    hexutil.Uint64(result.UsedGas),
+		Status:     hexutil.Uint64(receipt.Status),
+	}
+	if callResult.Logs == nil {
+		callResult.Logs = []*types.Log{}
+	}
+	if result.Err == nil {
+		return callResult
+	}
+	if errors.Is(result.Err, vm.ErrExecutionReverted) {
+		revertErr := ethapi2.NewRevertError(result)
+		callResult.ReturnData = result.Revert()
+		callResult.Error = &SimulatedCallError{Code: simulateErrCodeReverted, Message: revertErr.Error(), Data: hexutility.Encode(result.Revert())}
+	} else {
+		callResult.Error = &SimulatedCallError{Code: simulateErrCodeVMError, Message: result.Err.Error()}
+	}
+	return callResult
+}
+
+// transferTracer adds an ERC-20 like Transfer log for every call frame which moves ether.
+// Logs are added to the IntraBlockState, so they follow the journal: the logs of reverted frames are dropped
+// and the ordering relative to the logs emitted by contracts is preserved.
+type transferTracer struct {
+	ibs evmtypes.IntraBlockState
+}
+
+func (t *transferTracer) captureTransfer(typ vm.OpCode, from, to common.Address, value *uint256.Int) {
+	if value == nil || value.IsZero() || typ == vm.DELEGATECALL || typ == vm.STATICCALL {
+		return
+	}
+	amount := value.Bytes32()
+	t.ibs.AddLog(&types.Log{
+		Address: transferLogAddress,
+		Topics:  []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    amount[:],
+	})
+}
+
+func (t *transferTracer) CaptureTxStart(gasLimit uint64) {}
+func (t *transferTracer) CaptureTxEnd(restGas uint64)    {}
+func (t *transferTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.ibs = env.IntraBlockState()
+	t.captureTransfer(vm.CALL, from, to, value)
+}
+func (t *transferTracer) CaptureEnd(output []byte, usedGas uint64, err error) {}
+func (t *transferTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.captureTransfer(typ, from, to, value)
+}
+func (t *transferTracer) CaptureExit(output []byte, usedGas uint64, err error) {}
+func (t *transferTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+func (t *transferTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
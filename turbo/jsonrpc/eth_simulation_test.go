@@ -0,0 +1,96 @@
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
+
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/crypto"
+	"github.com/tenderly/erigon/params"
+	"github.com/tenderly/erigon/rpc"
+	"github.com/tenderly/erigon/turbo/adapter/ethapi"
+	"github.com/tenderly/erigon/turbo/stages/mock"
+)
+
+func TestSimulateV1(t *testing.T) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address  = crypto.PubkeyToAddress(key.PublicKey)
+		receiver = libcommon.HexToAddress("0x0d3ab14bbad3d99f4203bd7a11acb94882050e7e")
+		rich     = libcommon.HexToAddress("0x71562b71999873db5b286df957af199ec94617f7")
+		gspec    = &types.Genesis{
+			Config:   params.TestChainConfig,
+			Alloc:    types.GenesisAlloc{address: {Balance: big.NewInt(params.Ether)}},
+			GasLimit: 10_000_000,
+		}
+	)
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
+
+	value := (*hexutil.Big)(big.NewInt(1000))
+	richBalance := (*hexutil.Big)(big.NewInt(params.Ether))
+	number := (*hexutil.Big)(big.NewInt(5))
+	opts := SimulationOpts{
+		TraceTransfers: true,
+		BlockStateCalls: []SimulatedBlock{
+			{
+				StateOverrides: &ethapi.StateOverrides{rich: {Balance: &richBalance}},
+				Calls: []ethapi.CallArgs{
+					{From: &address, To: &receiver, Value: value},
+					{From: &rich, To: &receiver, Value: value},
+				},
+			},
+			{
+				BlockOverrides: &SimulationBlockOverrides{Number: number},
+				Calls:          []ethapi.CallArgs{{From: &address, To: &receiver, Value: value}},
+			},
+		},
+	}
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	res, err := api.SimulateV1(context.Background(), opts, &latest)
+	require.NoError(t, err)
+	// blocks 2,3,4 are inserted to fill the gap up to block 5
+	require.Len(t, res, 5)
+
+	for i, block := range res {
+		require.Equal(t, uint64(i+1), block["number"].(*hexutil.Big).ToInt().Uint64())
+		if i > 0 {
+			require.Equal(t, res[i-1]["hash"], block["parentHash"])
+		}
+	}
+
+	first := res[0]["calls"].([]SimulatedCallResult)
+	require.Len(t, first, 2)
+	for _, call := range first {
+		require.Nil(t, call.Error)
+		require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), call.Status)
+		require.Equal(t, hexutil.Uint64(params.TxGas), call.GasUsed)
+		require.Len(t, call.Logs, 1)
+		require.Equal(t, transferLogAddress, call.Logs[0].Address)
+		require.Equal(t, libcommon.BytesToHash(receiver.Bytes()), call.Logs[0].Topics[2])
+	}
+	require.Equal(t, libcommon.BytesToHash(rich.Bytes()), first[1].Logs[0].Topics[1])
+	require.Equal(t, res[0]["hash"], first[0].Logs[0].BlockHash)
+
+	require.Empty(t, res[1]["calls"])
+	last := res[4]["calls"].([]SimulatedCallResult)
+	require.Len(t, last, 1)
+	require.Nil(t, last[0].Error)
+
+	// with validation, the nonce of the sender must match the chained state
+	nonce := hexutil.Uint64(1)
+	_, err = api.SimulateV1(context.Background(), SimulationOpts{
+		Validation: true,
+		BlockStateCalls: []SimulatedBlock{{
+			Calls: []ethapi.CallArgs{{From: &address, To: &receiver, Value: value, Nonce: &nonce}},
+		}},
+	}, &latest)
+	require.ErrorContains(t, err, "nonce too high")
+}
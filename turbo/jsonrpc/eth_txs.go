@@ -278,3 +278,28 @@ func (api *APIImpl) GetRawTransactionByBlockNumberAndIndex(ctx context.Context,
 
 	return newRPCRawTransactionFromBlockIndex(block, uint64(index))
 }
+
+// TransactionPoolStatus is the state of a transaction in the pool
+type TransactionPoolStatus struct {
+	Status   string        `json:"status"` // pending, baseFee, queued, discarded or unknown
+	Discards []*RPCDiscard `json:"discards"`
+}
+
+// GetTransactionPoolStatus implements eth_getTransactionPoolStatus. Returns the sub-pool of a pooled transaction,
+// and the latest discards and replacements of the transaction, which tell why it left the pool.
+func (api *APIImpl) GetTransactionPoolStatus(ctx context.Context, hash common.Hash) (*TransactionPoolStatus, error) {
+	reply, err := api.txPool.Discards(ctx, &txpool.DiscardsRequest{Hash: gointerfaces.ConvertHashToH256(hash)})
+	if err != nil {
+		return nil, err
+	}
+	status := &TransactionPoolStatus{Status: "unknown", Discards: make([]*RPCDiscard, 0, len(reply.Discards))}
+	for _, e := range reply.Discards {
+		status.Discards = append(status.Discards, newRPCDiscard(e))
+	}
+	if reply.TxnType != nil {
+		status.Status = subPoolNames[*reply.TxnType]
+	} else if len(reply.Discards) > 0 {
+		status.Status = "discarded"
+	}
+	return status, nil
+}
@@ -6,31 +6,35 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/tenderly/erigon/erigon-lib/chain"
 	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
 	"github.com/tenderly/erigon/erigon-lib/common/hexutility"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces"
 	txPoolProto "github.com/tenderly/erigon/erigon-lib/gointerfaces/txpool"
 
 	"github.com/tenderly/erigon/core/types"
 	"github.com/tenderly/erigon/eth/ethconfig"
 	"github.com/tenderly/erigon/params"
+	"github.com/tenderly/erigon/turbo/rpchelper"
 )
 
+// defaultPrivateTxBlocks is how many blocks a private transaction stays in the pool when no max block number is given
+const defaultPrivateTxBlocks = 25
+
 // SendRawTransaction implements eth_sendRawTransaction. Creates new message call transaction or a contract creation for previously-signed transactions.
 func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (common.Hash, error) {
-	txn, err := types.DecodeWrappedTransaction(encodedTx)
-	if err != nil {
-		return common.Hash{}, err
-	}
+	return api.sendRawTransaction(ctx, encodedTx, false /* private */, nil)
+}
 
-	// If the transaction fee cap is already specified, ensure the
-	// fee of the given transaction is _reasonable_.
-	if err := checkTxFee(txn.GetPrice().ToBig(), txn.GetGas(), ethconfig.Defaults.RPCTxFeeCap); err != nil {
-		return common.Hash{}, err
-	}
-	if !txn.Protected() && !api.AllowUnprotectedTxs {
-		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
-	}
+// SendPrivateRawTransaction implements eth_sendPrivateRawTransaction. Adds a previously-signed transaction to the txpool
+// without announcing it to peers, so only the own block builder can include it. The transaction is dropped from the pool
+// when it isn't included until maxBlockNumber, which defaults to 25 blocks after the latest one.
+func (api *APIImpl) SendPrivateRawTransaction(ctx context.Context, encodedTx hexutility.Bytes, maxBlockNumber *hexutil.Uint64) (common.Hash, error) {
+	return api.sendRawTransaction(ctx, encodedTx, true /* private */, maxBlockNumber)
+}
 
+func (api *APIImpl) sendRawTransaction(ctx context.Context, encodedTx hexutility.Bytes, private bool, maxBlockNumber *hexutil.Uint64) (common.Hash, error) {
 	// this has been moved to prior to adding of transactions to capture the
 	// pre state of the db - which is used for logging in the messages below
 	tx, err := api.db.BeginRo(ctx)
@@ -45,16 +49,28 @@ func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutility
 		return common.Hash{}, err
 	}
 
-	if txn.Protected() {
-		txnChainId := txn.GetChainID()
-		chainId := cc.ChainID
-		if chainId.Cmp(txnChainId.ToBig()) != 0 {
-			return common.Hash{}, fmt.Errorf("invalid chain id, expected: %d got: %d", chainId, *txnChainId)
+	txn, err := api.decodeRawTransaction(encodedTx, cc)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	req := &txPoolProto.AddRequest{RlpTxs: [][]byte{encodedTx}}
+	if private {
+		latest, err := rpchelper.GetLatestBlockNumber(tx)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		req.Private, req.MaxBlockNumber = true, latest+defaultPrivateTxBlocks
+		if maxBlockNumber != nil {
+			req.MaxBlockNumber = uint64(*maxBlockNumber)
+		}
+		if req.MaxBlockNumber <= latest {
+			return common.Hash{}, fmt.Errorf("max block number %d must be after the latest block %d", req.MaxBlockNumber, latest)
 		}
 	}
 
 	hash := txn.Hash()
-	res, err := api.txPool.Add(ctx, &txPoolProto.AddRequest{RlpTxs: [][]byte{encodedTx}})
+	res, err := api.txPool.Add(ctx, req)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -66,6 +82,94 @@ func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutility
 	return txn.Hash(), nil
 }
 
+// decodeRawTransaction decodes a transaction submitted over RPC and checks its fee and replay protection
+func (api *APIImpl) decodeRawTransaction(encodedTx hexutility.Bytes, cc *chain.Config) (types.Transaction, error) {
+	txn, err := types.DecodeWrappedTransaction(encodedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the transaction fee cap is already specified, ensure the
+	// fee of the given transaction is _reasonable_.
+	if err := checkTxFee(txn.GetPrice().ToBig(), txn.GetGas(), ethconfig.Defaults.RPCTxFeeCap); err != nil {
+		return nil, err
+	}
+	if !txn.Protected() && !api.AllowUnprotectedTxs {
+		return nil, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+	}
+
+	if txn.Protected() {
+		txnChainId := txn.GetChainID()
+		chainId := cc.ChainID
+		if chainId.Cmp(txnChainId.ToBig()) != 0 {
+			return nil, fmt.Errorf("invalid chain id, expected: %d got: %d", chainId, *txnChainId)
+		}
+	}
+	return txn, nil
+}
+
+// SendBundleArgs are the arguments of eth_sendBundle
+type SendBundleArgs struct {
+	Txs               []hexutility.Bytes `json:"txs"`
+	BlockNumber       hexutil.Uint64     `json:"blockNumber"`       // the only block the bundle can be included in
+	MinTimestamp      uint64             `json:"minTimestamp"`      // optional lower bound of the block timestamp
+	MaxTimestamp      uint64             `json:"maxTimestamp"`      // optional upper bound of the block timestamp
+	RevertingTxHashes []common.Hash      `json:"revertingTxHashes"` // transactions allowed to fail without dropping the bundle
+}
+
+// SendBundleResult is the reply of eth_sendBundle
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// SendBundle implements eth_sendBundle. Submits a bundle of previously-signed transactions which the own block builder
+// includes in the target block all together, in order and ahead of the pool transactions, or not at all. The bundle
+// hash is the same as eth_callBundle returns for the transactions.
+func (api *APIImpl) SendBundle(ctx context.Context, args SendBundleArgs) (*SendBundleResult, error) {
+	if len(args.Txs) == 0 {
+		return nil, errors.New("bundle missing txs")
+	}
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	cc, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(args.BlockNumber) <= latest {
+		return nil, fmt.Errorf("bundle block number %d must be after the latest block %d", args.BlockNumber, latest)
+	}
+
+	req := &txPoolProto.AddBundleRequest{
+		RlpTxs:       make([][]byte, len(args.Txs)),
+		BlockNumber:  uint64(args.BlockNumber),
+		MinTimestamp: args.MinTimestamp,
+		MaxTimestamp: args.MaxTimestamp,
+	}
+	for i, encodedTx := range args.Txs {
+		if _, err := api.decodeRawTransaction(encodedTx, cc); err != nil {
+			return nil, fmt.Errorf("bundle transaction %d: %w", i, err)
+		}
+		req.RlpTxs[i] = encodedTx
+	}
+	for _, hash := range args.RevertingTxHashes {
+		req.RevertingTxHashes = append(req.RevertingTxHashes, gointerfaces.ConvertHashToH256(hash))
+	}
+
+	res, err := api.txPool.AddBundle(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &SendBundleResult{BundleHash: gointerfaces.ConvertH256ToHash(res.BundleHash)}, nil
+}
+
 // SendTransaction implements eth_sendTransaction. Creates new message call transaction or a contract creation if the data field contains code.
 func (api *APIImpl) SendTransaction(_ context.Context, txObject interface{}) (common.Hash, error) {
 	return common.Hash{0}, fmt.Errorf(NotImplemented, "eth_sendTransaction")
@@ -9,6 +9,7 @@ import (
 
 	"github.com/holiman/uint256"
 	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
 	"github.com/tenderly/erigon/erigon-lib/txpool/txpoolcfg"
 
 	"github.com/stretchr/testify/require"
@@ -166,6 +167,78 @@ func TestSendRawTransactionUnprotected(t *testing.T) {
 	}
 }
 
+func TestSendPrivateRawTransaction(t *testing.T) {
+	mockSentry, require := mock.MockWithTxPool(t), require.New(t)
+	logger := log.New()
+
+	oneBlockStep(mockSentry, require, t)
+
+	txn, err := types.SignTx(types.NewTransaction(0, common.Address{1}, uint256.NewInt(1234), params.TxGas, uint256.NewInt(10*params.GWei), nil), *types.LatestSignerForChainID(mockSentry.ChainConfig.ChainID), mockSentry.Key)
+	require.NoError(err)
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, mockSentry)
+	txPool := txpool.NewTxpoolClient(conn)
+	api := jsonrpc.NewEthAPI(newBaseApiForTest(mockSentry), mockSentry.DB, nil, txPool, nil, 5000000, 100_000, false, 100_000, logger)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(txn.MarshalBinary(buf))
+
+	latest := hexutil.Uint64(1)
+	_, err = api.SendPrivateRawTransaction(ctx, buf.Bytes(), &latest)
+	require.ErrorContains(err, "must be after the latest block")
+
+	txHash, err := api.SendPrivateRawTransaction(ctx, buf.Bytes(), nil)
+	require.NoError(err)
+	require.Equal(txn.Hash(), txHash)
+	// private transactions are not served by hash
+	jsonTx, err := api.GetTransactionByHash(ctx, txHash)
+	require.NoError(err)
+	require.Nil(jsonTx)
+}
+
+func TestSendBundle(t *testing.T) {
+	mockSentry, require := mock.MockWithTxPool(t), require.New(t)
+	logger := log.New()
+
+	oneBlockStep(mockSentry, require, t)
+
+	signer := types.LatestSignerForChainID(mockSentry.ChainConfig.ChainID)
+	var txs types.Transactions
+	var args jsonrpc.SendBundleArgs
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		txn, err := types.SignTx(types.NewTransaction(nonce, common.Address{1}, uint256.NewInt(1234), params.TxGas, uint256.NewInt(10*params.GWei), nil), *signer, mockSentry.Key)
+		require.NoError(err)
+		buf := bytes.NewBuffer(nil)
+		require.NoError(txn.MarshalBinary(buf))
+		txs = append(txs, txn)
+		args.Txs = append(args.Txs, buf.Bytes())
+	}
+	args.RevertingTxHashes = []common.Hash{txs[1].Hash()}
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, mockSentry)
+	txPool := txpool.NewTxpoolClient(conn)
+	api := jsonrpc.NewEthAPI(newBaseApiForTest(mockSentry), mockSentry.DB, nil, txPool, nil, 5000000, 100_000, false, 100_000, logger)
+
+	args.BlockNumber = 1
+	_, err := api.SendBundle(ctx, args)
+	require.ErrorContains(err, "must be after the latest block")
+
+	args.BlockNumber = 2
+	res, err := api.SendBundle(ctx, args)
+	require.NoError(err)
+	require.Equal(core.BundleHash(txs), res.BundleHash)
+
+	bundles := mockSentry.TxPool.BundlesFor(2, 0)
+	require.Len(bundles, 1)
+	require.Equal([32]byte(res.BundleHash), bundles[0].Hash)
+	require.True(bundles[0].CanRevert(txs[1].Hash()))
+	require.False(bundles[0].CanRevert(txs[0].Hash()))
+	// bundle transactions don't enter the pool
+	jsonTx, err := api.GetTransactionByHash(ctx, txs[0].Hash())
+	require.NoError(err)
+	require.Nil(jsonTx)
+}
+
 func transaction(nonce uint64, gaslimit uint64, key *ecdsa.PrivateKey) types.Transaction {
 	return pricedTransaction(nonce, gaslimit, u256.Num1, key)
 }
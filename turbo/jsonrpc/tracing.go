@@ -17,6 +17,7 @@ import (
 	"github.com/tenderly/erigon/core/vm/evmtypes"
 	"github.com/tenderly/erigon/erigon-lib/common"
 	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
+	"github.com/tenderly/erigon/erigon-lib/kv"
 	"github.com/tenderly/erigon/eth/tracers"
 	"github.com/tenderly/erigon/rpc"
 	"github.com/tenderly/erigon/turbo/adapter/ethapi"
@@ -78,6 +79,57 @@ func (api *PrivateDebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rp
 		return err
 	}
 
+	borTx := rawdb.ReadBorTransactionForBlock(tx, block.NumberU64())
+	return api.traceBlockTxs(ctx, tx, block, borTx, config, stream)
+}
+
+// TraceBadBlock implements debug_traceBadBlock. Returns Geth style traces of a block rejected by the block validation,
+// re-executed on top of the state of its parent.
+func (api *PrivateDebugAPIImpl) TraceBadBlock(ctx context.Context, hash common.Hash, config *tracers.TraceConfig, stream *jsoniter.Stream) error {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		stream.WriteNil()
+		return err
+	}
+	defer tx.Rollback()
+	block, err := api.readBadBlock(ctx, tx, hash)
+	if err != nil {
+		stream.WriteNil()
+		return err
+	}
+	return api.traceBlockTxs(ctx, tx, block, nil, config, stream)
+}
+
+// readBadBlock returns the stored bad block if the state of its parent is still available
+func (api *PrivateDebugAPIImpl) readBadBlock(ctx context.Context, tx kv.Tx, hash common.Hash) (*types.Block, error) {
+	badBlock, err := rawdb.ReadBadBlock(tx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if badBlock == nil {
+		return nil, fmt.Errorf("bad block %x not found", hash)
+	}
+	block := badBlock.Block
+	if block.NumberU64() == 0 {
+		return nil, fmt.Errorf("genesis is not traceable")
+	}
+	// the state is only available for the canonical chain
+	parentHash, err := api._blockReader.CanonicalHash(ctx, tx, block.NumberU64()-1)
+	if err != nil {
+		return nil, err
+	}
+	if parentHash != block.ParentHash() {
+		return nil, fmt.Errorf("parent %x of bad block %x is not canonical", block.ParentHash(), hash)
+	}
+	if err = api.BaseAPI.checkPruneHistory(tx, block.NumberU64()); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// traceBlockTxs traces all the transactions of the block on top of the state of its parent. borTx is
+// the state sync transaction of the block, if any.
+func (api *PrivateDebugAPIImpl) traceBlockTxs(ctx context.Context, tx kv.Tx, block *types.Block, borTx types.Transaction, config *tracers.TraceConfig, stream *jsoniter.Stream) error {
 	if config == nil {
 		config = &tracers.TraceConfig{}
 	}
@@ -103,7 +155,6 @@ func (api *PrivateDebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rp
 	rules := chainConfig.Rules(block.NumberU64(), block.Time())
 	stream.WriteArrayStart()
 
-	borTx := rawdb.ReadBorTransactionForBlock(tx, block.NumberU64())
 	txns := block.Transactions()
 	if borTx != nil && *config.BorTraceEnabled {
 		txns = append(txns, borTx)
@@ -140,7 +191,11 @@ func (api *PrivateDebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rp
 			}
 		}
 
-		err = transactions.TraceTx(ctx, msg, blockCtx, txCtx, ibs, config, chainConfig, stream, api.evmCallTimeout)
+		tracerCtx := &tracers.Context{BlockHash: block.Hash(), BlockNumber: block.Number(), TxIndex: idx, TxHash: txn.Hash()}
+		if idx == len(block.Transactions())-1 && chainConfig.Aura == nil { // AuRa withdrawals are paid by the system contract
+			tracerCtx.Withdrawals = block.Withdrawals()
+		}
+		err = transactions.TraceTx(ctx, msg, blockCtx, txCtx, tracerCtx, ibs, config, chainConfig, stream, api.evmCallTimeout)
 		if err == nil {
 			err = ibs.FinalizeTx(rules, state.NewNoopWriter())
 		}
@@ -249,8 +304,9 @@ func (api *PrivateDebugAPIImpl) TraceTransaction(ctx context.Context, hash commo
 		stream.WriteNil()
 		return err
 	}
+	tracerCtx := &tracers.Context{BlockHash: block.Hash(), BlockNumber: block.Number(), TxIndex: int(txnIndex), TxHash: hash}
 	// Trace the transaction and return
-	return transactions.TraceTx(ctx, msg, blockCtx, txCtx, ibs, config, chainConfig, stream, api.evmCallTimeout)
+	return transactions.TraceTx(ctx, msg, blockCtx, txCtx, tracerCtx, ibs, config, chainConfig, stream, api.evmCallTimeout)
 }
 
 func (api *PrivateDebugAPIImpl) TraceCall(ctx context.Context, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *tracers.TraceConfig, stream *jsoniter.Stream) error {
@@ -316,7 +372,7 @@ func (api *PrivateDebugAPIImpl) TraceCall(ctx context.Context, args ethapi.CallA
 	blockCtx := transactions.NewEVMBlockContext(engine, header, blockNrOrHash.RequireCanonical, dbtx, api._blockReader)
 	txCtx := core.NewEVMTxContext(msg)
 	// Trace the transaction and return
-	return transactions.TraceTx(ctx, msg, blockCtx, txCtx, ibs, config, chainConfig, stream, api.evmCallTimeout)
+	return transactions.TraceTx(ctx, msg, blockCtx, txCtx, nil, ibs, config, chainConfig, stream, api.evmCallTimeout)
 }
 
 func (api *PrivateDebugAPIImpl) TraceCallMany(ctx context.Context, bundles []Bundle, simulateContext StateContext, config *tracers.TraceConfig, stream *jsoniter.Stream) error {
@@ -490,7 +546,7 @@ func (api *PrivateDebugAPIImpl) TraceCallMany(ctx context.Context, bundles []Bun
 			txCtx = core.NewEVMTxContext(msg)
 			ibs := evm.IntraBlockState().(*state.IntraBlockState)
 			ibs.SetTxContext(common.Hash{}, parent.Hash(), txn_index)
-			err = transactions.TraceTx(ctx, msg, blockCtx, txCtx, evm.IntraBlockState(), config, chainConfig, stream, api.evmCallTimeout)
+			err = transactions.TraceTx(ctx, msg, blockCtx, txCtx, nil, evm.IntraBlockState(), config, chainConfig, stream, api.evmCallTimeout)
 
 			if err != nil {
 				stream.WriteNil()
@@ -0,0 +1,204 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
+
+	"github.com/tenderly/erigon/common/debug"
+	"github.com/tenderly/erigon/eth/tracers"
+	"github.com/tenderly/erigon/rpc"
+	"github.com/tenderly/erigon/turbo/rpchelper"
+)
+
+// blockTraceResult is the notification sent by debug_traceChain for every traced block
+type blockTraceResult struct {
+	Block  hexutil.Uint64  `json:"block"`
+	Hash   common.Hash     `json:"hash"`
+	Traces json.RawMessage `json:"traces"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// TraceChain implements debug_traceChain. Returns a subscription which replays all blocks in (start, end] and
+// streams their Geth style traces, one notification per block and in block order. Blocks are traced by several
+// workers in parallel, but at most a bounded number of results is buffered while the client is lagging behind.
+func (api *PrivateDebugAPIImpl) TraceChain(ctx context.Context, start, end rpc.BlockNumber, config *tracers.TraceChainConfig) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from, _, _, err := rpchelper.GetCanonicalBlockNumber(rpc.BlockNumberOrHashWithNumber(start), tx, api.filters)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	to, _, _, err := rpchelper.GetCanonicalBlockNumber(rpc.BlockNumberOrHashWithNumber(end), tx, api.filters)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = api.BaseAPI.checkPruneHistory(tx, from)
+	tx.Rollback()
+	if err != nil {
+		return nil, err
+	}
+	if from >= to {
+		return nil, fmt.Errorf("end block (#%d) needs to come after start block (#%d)", to, from)
+	}
+
+	if config == nil {
+		config = &tracers.TraceChainConfig{}
+	}
+	threads := uint64(runtime.NumCPU())
+	if config.Threads != nil && *config.Threads > 0 {
+		threads = *config.Threads
+	}
+	if blocks := to - from; threads > blocks {
+		threads = blocks
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	// the request context is cancelled as soon as the subscription is created, so tracing has its own one
+	traceCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-rpcSub.Err():
+		case <-notifier.Closed():
+		case <-traceCtx.Done(): // all blocks are traced
+		}
+		cancel()
+	}()
+
+	go func() {
+		defer debug.LogPanic()
+		defer cancel()
+		notify := func(res *blockTraceResult) error {
+			return notifier.Notify(rpcSub.ID, res)
+		}
+		if err := api.traceChain(traceCtx, from+1, to, int(threads), &config.TraceConfig, notify); err != nil && traceCtx.Err() == nil {
+			log.Warn("[rpc] debug_traceChain stopped", "err", err)
+		}
+	}()
+	return rpcSub, nil
+}
+
+// traceChain traces the blocks in [from, to] with the given number of workers and hands the results
+// to notify in block order. It returns on the first notification error or when ctx is cancelled.
+func (api *PrivateDebugAPIImpl) traceChain(ctx context.Context, from, to uint64, threads int, config *tracers.TraceConfig, notify func(*blockTraceResult) error) error {
+	var (
+		g, gctx = errgroup.WithContext(ctx)
+		blocks  = make(chan uint64)
+		// slots bounds the number of blocks being traced or waiting to be sent to the client
+		slots   = make(chan struct{}, 2*threads)
+		mu      sync.Mutex
+		cond    = sync.NewCond(&mu)
+		results = make(map[uint64]*blockTraceResult, 2*threads)
+	)
+	// wake up the sender when the context is cancelled, so it doesn't wait forever for a result
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-gctx.Done():
+			mu.Lock()
+			cond.Broadcast()
+			mu.Unlock()
+		case <-stop:
+		}
+	}()
+
+	g.Go(func() error {
+		defer close(blocks)
+		for n := from; n <= to; n++ {
+			select {
+			case slots <- struct{}{}:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+			select {
+			case blocks <- n:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		return nil
+	})
+	for i := 0; i < threads; i++ {
+		g.Go(func() error {
+			for n := range blocks {
+				res := api.traceChainBlock(gctx, n, config)
+				mu.Lock()
+				results[n] = res
+				cond.Broadcast()
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	g.Go(func() error {
+		for n := from; n <= to; n++ {
+			mu.Lock()
+			for results[n] == nil && gctx.Err() == nil {
+				cond.Wait()
+			}
+			res := results[n]
+			delete(results, n)
+			mu.Unlock()
+			if res == nil {
+				return gctx.Err()
+			}
+			if err := notify(res); err != nil {
+				return err
+			}
+			<-slots
+		}
+		return nil
+	})
+	return g.Wait()
+}
+
+// traceChainBlock traces a single block with debug_traceBlockByNumber semantics and captures the output
+func (api *PrivateDebugAPIImpl) traceChainBlock(ctx context.Context, number uint64, config *tracers.TraceConfig) *blockTraceResult {
+	res := &blockTraceResult{Block: hexutil.Uint64(number)}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Hash, err = api._blockReader.CanonicalHash(ctx, tx, number)
+	tx.Rollback()
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	// traceBlock modifies the config, so every block gets its own copy
+	cfg := *config
+	var buf bytes.Buffer
+	stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
+	if err = api.traceBlock(ctx, rpc.BlockNumberOrHashWithHash(res.Hash, true), &cfg, stream); err == nil {
+		err = stream.Flush()
+	}
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Traces = buf.Bytes()
+	return res
+}
@@ -0,0 +1,146 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/kv"
+
+	"github.com/tenderly/erigon/core"
+	"github.com/tenderly/erigon/core/state"
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/core/vm"
+	"github.com/tenderly/erigon/eth/tracers"
+	"github.com/tenderly/erigon/eth/tracers/logger"
+	"github.com/tenderly/erigon/turbo/transactions"
+)
+
+// StandardTraceBlockToFile implements debug_standardTraceBlockToFile. Re-executes the block and writes the EIP-3155
+// traces of its transactions into one JSON-lines file per transaction. Returns the names of the files.
+func (api *PrivateDebugAPIImpl) StandardTraceBlockToFile(ctx context.Context, hash common.Hash, config *tracers.StdTraceConfig) ([]string, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	block, err := api.blockByHashWithSenders(tx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %x not found", hash)
+	}
+	if err = api.BaseAPI.checkPruneHistory(tx, block.NumberU64()); err != nil {
+		return nil, err
+	}
+	return api.standardTraceBlockToFile(ctx, tx, block, config)
+}
+
+// StandardTraceBadBlockToFile implements debug_standardTraceBadBlockToFile. Same as debug_standardTraceBlockToFile,
+// but for a block rejected by the block validation.
+func (api *PrivateDebugAPIImpl) StandardTraceBadBlockToFile(ctx context.Context, hash common.Hash, config *tracers.StdTraceConfig) ([]string, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	block, err := api.readBadBlock(ctx, tx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return api.standardTraceBlockToFile(ctx, tx, block, config)
+}
+
+func (api *PrivateDebugAPIImpl) standardTraceBlockToFile(ctx context.Context, tx kv.Tx, block *types.Block, config *tracers.StdTraceConfig) ([]string, error) {
+	if api.traceDir == "" {
+		return nil, errors.New("trace files can only be written when the datadir is known, start rpcdaemon with --datadir")
+	}
+	if config == nil {
+		config = &tracers.StdTraceConfig{}
+	}
+	txns := block.Transactions()
+	if config.TxHash != nil {
+		found := false
+		for _, txn := range txns {
+			if txn.Hash() == *config.TxHash {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("transaction %x not found in block %x", *config.TxHash, block.Hash())
+		}
+	}
+	if err := os.MkdirAll(api.traceDir, 0755); err != nil {
+		return nil, err
+	}
+
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+	engine := api.engine()
+	_, blockCtx, _, ibs, _, err := transactions.ComputeTxEnv(ctx, engine, block, chainConfig, api._blockReader, tx, 0, api.historyV3(tx))
+	if err != nil {
+		return nil, err
+	}
+	signer := types.MakeSigner(chainConfig, block.NumberU64(), block.Time())
+	rules := chainConfig.Rules(block.NumberU64(), block.Time())
+
+	var files []string
+	for idx, txn := range txns {
+		select {
+		default:
+		case <-ctx.Done():
+			return files, ctx.Err()
+		}
+		ibs.SetTxContext(txn.Hash(), block.Hash(), idx)
+		msg, _ := txn.AsMessage(*signer, block.BaseFee(), rules)
+		if msg.FeeCap().IsZero() && engine != nil {
+			syscall := func(contract common.Address, data []byte) ([]byte, error) {
+				return core.SysCallContract(contract, data, chainConfig, ibs, block.Header(), engine, true /* constCall */)
+			}
+			msg.SetIsFree(engine.IsServiceTransaction(msg.From(), syscall))
+		}
+
+		var (
+			dump     *os.File
+			writer   *bufio.Writer
+			vmConfig vm.Config
+		)
+		traced := config.TxHash == nil || *config.TxHash == txn.Hash()
+		if traced {
+			prefix := fmt.Sprintf("block_%#x-%d-%#x-*.jsonl", block.Hash().Bytes()[:4], idx, txn.Hash().Bytes()[:4])
+			if dump, err = os.CreateTemp(api.traceDir, prefix); err != nil {
+				return files, err
+			}
+			files = append(files, dump.Name())
+			writer = bufio.NewWriter(dump)
+			vmConfig = vm.Config{Debug: true, Tracer: logger.NewJSONLogger(&config.LogConfig, writer)}
+		}
+		evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), ibs, chainConfig, vmConfig)
+		_, err = core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.Gas()).AddBlobGas(msg.BlobGas()), true /* refunds */, false /* gasBailout */)
+		if traced {
+			if flushErr := writer.Flush(); err == nil {
+				err = flushErr
+			}
+			if closeErr := dump.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		if err != nil {
+			return files, fmt.Errorf("transaction %x failed: %w", txn.Hash(), err)
+		}
+		if traced && config.TxHash != nil {
+			break
+		}
+		if err = ibs.FinalizeTx(rules, state.NewNoopWriter()); err != nil {
+			return files, err
+		}
+	}
+	return files, nil
+}
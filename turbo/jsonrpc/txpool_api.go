@@ -3,20 +3,32 @@ package jsonrpc
 import (
 	"context"
 	"fmt"
-	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
+	"math"
+
+	"github.com/ledgerwatch/log/v3"
 
+	"github.com/tenderly/erigon/erigon-lib/chain"
 	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/common/hexutil"
 	"github.com/tenderly/erigon/erigon-lib/gointerfaces"
 	proto_txpool "github.com/tenderly/erigon/erigon-lib/gointerfaces/txpool"
 	"github.com/tenderly/erigon/erigon-lib/kv"
+	"github.com/tenderly/erigon/erigon-lib/txpool/txpoolcfg"
 
+	"github.com/tenderly/erigon/common/debug"
 	"github.com/tenderly/erigon/core/rawdb"
 	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/rpc"
 )
 
 // NetAPI the interface for the net_ RPC commands
 type TxPoolAPI interface {
 	Content(ctx context.Context) (map[string]map[string]map[string]*RPCTransaction, error)
+	ContentFrom(ctx context.Context, addr libcommon.Address) (map[string]map[string]*RPCTransaction, error)
+	ContentPage(ctx context.Context, subPool string, offset, limit uint64) (*TxPoolContentPage, error)
+	Inspect(ctx context.Context) (map[string]map[string]map[string]string, error)
+	DiscardsFrom(ctx context.Context, addr libcommon.Address) ([]*RPCDiscard, error)
+	Discards(ctx context.Context) (*rpc.Subscription, error)
 }
 
 // TxPoolAPIImpl data structure to store things needed for net_ commands
@@ -129,40 +141,209 @@ func (api *TxPoolAPIImpl) Status(ctx context.Context) (map[string]hexutil.Uint,
 	}, nil
 }
 
-/*
+// pendingHeader returns the chain config and the current header, used to fill in the gas price of the pending transactions
+func (api *TxPoolAPIImpl) pendingHeader(ctx context.Context) (*chain.Config, *types.Header, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+	cc, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cc, rawdb.ReadCurrentHeader(tx), nil
+}
+
+var subPoolNames = map[proto_txpool.AllReply_TxnType]string{
+	proto_txpool.AllReply_PENDING:  "pending",
+	proto_txpool.AllReply_BASE_FEE: "baseFee",
+	proto_txpool.AllReply_QUEUED:   "queued",
+}
+
+// ContentFrom returns the transactions of the sender, grouped by sub-pool and keyed by nonce.
+func (api *TxPoolAPIImpl) ContentFrom(ctx context.Context, addr libcommon.Address) (map[string]map[string]*RPCTransaction, error) {
+	reply, err := api.pool.ContentFrom(ctx, &proto_txpool.ContentFromRequest{Sender: gointerfaces.ConvertAddressToH160(addr)})
+	if err != nil {
+		return nil, err
+	}
+	cc, curHeader, err := api.pendingHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if curHeader == nil {
+		return nil, nil
+	}
+	content := map[string]map[string]*RPCTransaction{
+		"pending": make(map[string]*RPCTransaction),
+		"baseFee": make(map[string]*RPCTransaction),
+		"queued":  make(map[string]*RPCTransaction),
+	}
+	for i := range reply.Txs {
+		txn, err := types.DecodeWrappedTransaction(reply.Txs[i].RlpTx)
+		if err != nil {
+			return nil, fmt.Errorf("decoding transaction from: %x: %w", reply.Txs[i].RlpTx, err)
+		}
+		if name, ok := subPoolNames[reply.Txs[i].TxnType]; ok {
+			content[name][fmt.Sprintf("%d", txn.GetNonce())] = newRPCPendingTransaction(txn, curHeader, cc)
+		}
+	}
+	return content, nil
+}
+
+// TxPoolContentPage is a page of the transactions of a sub-pool
+type TxPoolContentPage struct {
+	Transactions []*RPCTransaction `json:"transactions"`
+	Total        hexutil.Uint64    `json:"total"` // number of all the transactions in the sub-pool
+}
+
+// ContentPage returns the transactions of the sub-pool (pending, baseFee or queued) ordered by sender and nonce,
+// skipping the first offset of them and returning at most limit of them, or all the rest if limit is 0.
+func (api *TxPoolAPIImpl) ContentPage(ctx context.Context, subPool string, offset, limit uint64) (*TxPoolContentPage, error) {
+	txnType := proto_txpool.AllReply_TxnType(-1)
+	for t, name := range subPoolNames {
+		if name == subPool {
+			txnType = t
+		}
+	}
+	if txnType < 0 {
+		return nil, fmt.Errorf("unknown sub-pool %q, expected pending, baseFee or queued", subPool)
+	}
+	reply, err := api.pool.Content(ctx, &proto_txpool.ContentRequest{TxnType: txnType, Offset: offset, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	cc, curHeader, err := api.pendingHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if curHeader == nil {
+		return nil, nil
+	}
+	page := &TxPoolContentPage{Transactions: make([]*RPCTransaction, 0, len(reply.Txs)), Total: hexutil.Uint64(reply.Total)}
+	for i := range reply.Txs {
+		txn, err := types.DecodeWrappedTransaction(reply.Txs[i].RlpTx)
+		if err != nil {
+			return nil, fmt.Errorf("decoding transaction from: %x: %w", reply.Txs[i].RlpTx, err)
+		}
+		page.Transactions = append(page.Transactions, newRPCPendingTransaction(txn, curHeader, cc))
+	}
+	return page, nil
+}
 
 // Inspect retrieves the content of the transaction pool and flattens it into an
 // easily inspectable list.
-func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
+func (api *TxPoolAPIImpl) Inspect(ctx context.Context) (map[string]map[string]map[string]string, error) {
+	reply, err := api.pool.Inspect(ctx, &proto_txpool.InspectRequest{})
+	if err != nil {
+		return nil, err
+	}
 	content := map[string]map[string]map[string]string{
 		"pending": make(map[string]map[string]string),
+		"baseFee": make(map[string]map[string]string),
 		"queued":  make(map[string]map[string]string),
 	}
-	pending, queue := s.b.TxPoolContent()
-
-	// Define a formatter to flatten a transaction into a string
-	var format = func(tx *types.Transaction) string {
-		if to := tx.To(); to != nil {
-			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To().Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+	for _, txn := range reply.Txs {
+		name, ok := subPoolNames[txn.TxnType]
+		if !ok {
+			continue
 		}
-		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
-	}
-	// Flatten the pending transactions
-	for account, txs := range pending {
-		dump := make(map[string]string)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+		value := gointerfaces.ConvertH256ToUint256Int(txn.Value)
+		feeCap := gointerfaces.ConvertH256ToUint256Int(txn.FeeCap)
+		var summary string
+		if txn.To != nil {
+			summary = fmt.Sprintf("%s: %v wei + %v gas × %v wei", libcommon.Address(gointerfaces.ConvertH160toAddress(txn.To)).Hex(), value.ToBig(), txn.Gas, feeCap.ToBig())
+		} else {
+			summary = fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", value.ToBig(), txn.Gas, feeCap.ToBig())
 		}
-		content["pending"][account.Hex()] = dump
+		sender := libcommon.Address(gointerfaces.ConvertH160toAddress(txn.Sender)).Hex()
+		if _, ok := content[name][sender]; !ok {
+			content[name][sender] = make(map[string]string)
+		}
+		content[name][sender][fmt.Sprintf("%d", txn.Nonce)] = summary
 	}
-	// Flatten the queued transactions
-	for account, txs := range queue {
-		dump := make(map[string]string)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+	return content, nil
+}
+
+// RPCDiscard is a transaction which left the pool for another reason than being mined
+type RPCDiscard struct {
+	Hash       libcommon.Hash    `json:"hash"`
+	From       libcommon.Address `json:"from"`
+	Nonce      hexutil.Uint64    `json:"nonce"`
+	Reason     string            `json:"reason"`
+	ReplacedBy *libcommon.Hash   `json:"replacedBy,omitempty"`
+	Timestamp  hexutil.Uint64    `json:"timestamp"`
+}
+
+func newRPCDiscard(e *proto_txpool.DiscardEntry) *RPCDiscard {
+	d := &RPCDiscard{
+		Hash:      gointerfaces.ConvertH256ToHash(e.Hash),
+		From:      gointerfaces.ConvertH160toAddress(e.Sender),
+		Nonce:     hexutil.Uint64(e.Nonce),
+		Reason:    fmt.Sprintf("unknown (%d)", e.Reason),
+		Timestamp: hexutil.Uint64(e.Timestamp),
+	}
+	if e.Reason <= math.MaxUint8 {
+		if name, ok := txpoolcfg.DiscardReason(e.Reason).Name(); ok {
+			d.Reason = name
 		}
-		content["queued"][account.Hex()] = dump
 	}
-	return content
+	if e.ReplacedBy != nil {
+		replacedBy := libcommon.Hash(gointerfaces.ConvertH256ToHash(e.ReplacedBy))
+		d.ReplacedBy = &replacedBy
+	}
+	return d
+}
+
+// DiscardsFrom returns the latest discards and replacements of the transactions of the sender, oldest first.
+func (api *TxPoolAPIImpl) DiscardsFrom(ctx context.Context, addr libcommon.Address) ([]*RPCDiscard, error) {
+	reply, err := api.pool.Discards(ctx, &proto_txpool.DiscardsRequest{Sender: gointerfaces.ConvertAddressToH160(addr)})
+	if err != nil {
+		return nil, err
+	}
+	discards := make([]*RPCDiscard, 0, len(reply.Discards))
+	for _, e := range reply.Discards {
+		discards = append(discards, newRPCDiscard(e))
+	}
+	return discards, nil
+}
+
+// Discards sends a notification each time a transaction is discarded from the pool or replaced, except when mined.
+func (api *TxPoolAPIImpl) Discards(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	streamCtx, cancel := context.WithCancel(context.Background())
+	stream, err := api.pool.OnDiscard(streamCtx, &proto_txpool.OnDiscardRequest{})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		defer debug.LogPanic()
+		<-rpcSub.Err()
+		cancel()
+	}()
+	go func() {
+		defer debug.LogPanic()
+		defer cancel()
+		for {
+			reply, err := stream.Recv()
+			if err != nil {
+				if streamCtx.Err() == nil {
+					log.Warn("[rpc] discards stream was closed", "err", err)
+				}
+				return
+			}
+			for _, e := range reply.Discards {
+				if err := notifier.Notify(rpcSub.ID, newRPCDiscard(e)); err != nil {
+					log.Warn("[rpc] error while notifying subscription", "err", err)
+				}
+			}
+		}
+	}()
+	return rpcSub, nil
 }
-*/
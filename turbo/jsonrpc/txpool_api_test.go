@@ -9,9 +9,11 @@ import (
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 	libcommon "github.com/tenderly/erigon/erigon-lib/common"
+	"github.com/tenderly/erigon/erigon-lib/gointerfaces"
 	"github.com/tenderly/erigon/erigon-lib/gointerfaces/txpool"
 	txPoolProto "github.com/tenderly/erigon/erigon-lib/gointerfaces/txpool"
 	"github.com/tenderly/erigon/erigon-lib/kv/kvcache"
+	"github.com/tenderly/erigon/erigon-lib/txpool/txpoolcfg"
 
 	"github.com/tenderly/erigon/cmd/rpcdaemon/rpcdaemontest"
 	"github.com/tenderly/erigon/core"
@@ -63,4 +65,99 @@ func TestTxPoolContent(t *testing.T) {
 	require.Len(status, 3)
 	require.Equal(status["pending"], hexutil.Uint(1))
 	require.Equal(status["queued"], hexutil.Uint(0))
+
+	creation, err := types.SignTx(types.NewContractCreation(1, uint256.NewInt(0), 100_000, uint256.NewInt(10*params.GWei), nil), *types.LatestSignerForChainID(m.ChainConfig.ChainID), m.Key)
+	require.NoError(err)
+	buf.Reset()
+	require.NoError(creation.MarshalBinary(buf))
+	reply, err = txPool.Add(ctx, &txpool.AddRequest{RlpTxs: [][]byte{buf.Bytes()}})
+	require.NoError(err)
+	require.Equal(txPoolProto.ImportResult_SUCCESS, reply.Imported[0], fmt.Sprintf("%s", reply.Errors))
+
+	from, err := api.ContentFrom(ctx, m.Address)
+	require.NoError(err)
+	require.Len(from["pending"], 2)
+	require.Equal(txn.Hash(), from["pending"]["0"].Hash)
+	require.Equal(creation.Hash(), from["pending"]["1"].Hash)
+	from, err = api.ContentFrom(ctx, libcommon.Address{2})
+	require.NoError(err)
+	require.Empty(from["pending"])
+
+	page, err := api.ContentPage(ctx, "pending", 1, 1)
+	require.NoError(err)
+	require.Equal(hexutil.Uint64(2), page.Total)
+	require.Len(page.Transactions, 1)
+	require.Equal(creation.Hash(), page.Transactions[0].Hash)
+	page, err = api.ContentPage(ctx, "queued", 0, 0)
+	require.NoError(err)
+	require.Equal(hexutil.Uint64(0), page.Total)
+	_, err = api.ContentPage(ctx, "unknown", 0, 0)
+	require.Error(err)
+
+	inspect, err := api.Inspect(ctx)
+	require.NoError(err)
+	require.Equal(map[string]string{
+		"0": fmt.Sprintf("%s: 1234 wei + 21000 gas × %d wei", libcommon.Address{1}.Hex(), uint64(10*params.GWei)),
+		"1": fmt.Sprintf("contract creation: 0 wei + 100000 gas × %d wei", uint64(10*params.GWei)),
+	}, inspect["pending"][sender])
+}
+
+func TestTxPoolDiscards(t *testing.T) {
+	m, require := mock.MockWithTxPool(t), require.New(t)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(libcommon.Address{1})
+	})
+	require.NoError(err)
+	require.NoError(m.InsertChain(chain))
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, m)
+	txPool := txpool.NewTxpoolClient(conn)
+	ff := rpchelper.New(ctx, nil, txPool, txpool.NewMiningClient(conn), func() {}, m.Log)
+	base := NewBaseApi(ff, kvcache.New(kvcache.DefaultCoherentConfig), m.BlockReader, m.HistoryV3Components(), false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs)
+	api := NewTxPoolAPI(base, m.DB, txPool)
+	ethApi := NewEthAPI(base, m.DB, nil, txPool, nil, 5000000, 100_000, false, 100_000, m.Log)
+
+	add := func(gasPrice uint64) types.Transaction {
+		txn, err := types.SignTx(types.NewTransaction(0, libcommon.Address{1}, uint256.NewInt(1), params.TxGas, uint256.NewInt(gasPrice), nil), *types.LatestSignerForChainID(m.ChainConfig.ChainID), m.Key)
+		require.NoError(err)
+		buf := bytes.NewBuffer(nil)
+		require.NoError(txn.MarshalBinary(buf))
+		reply, err := txPool.Add(ctx, &txpool.AddRequest{RlpTxs: [][]byte{buf.Bytes()}})
+		require.NoError(err)
+		require.Equal(txPoolProto.ImportResult_SUCCESS, reply.Imported[0], fmt.Sprintf("%s", reply.Errors))
+		return txn
+	}
+	replaced := add(10 * params.GWei)
+	replacement := add(20 * params.GWei)
+
+	status, err := ethApi.GetTransactionPoolStatus(ctx, replaced.Hash())
+	require.NoError(err)
+	require.Equal("discarded", status.Status)
+	require.Len(status.Discards, 1)
+	require.Equal("replaced by transaction with higher tip", status.Discards[0].Reason)
+	require.Equal(replacement.Hash(), *status.Discards[0].ReplacedBy)
+	require.Equal(m.Address, status.Discards[0].From)
+
+	status, err = ethApi.GetTransactionPoolStatus(ctx, replacement.Hash())
+	require.NoError(err)
+	require.Equal("pending", status.Status)
+	require.Empty(status.Discards)
+
+	status, err = ethApi.GetTransactionPoolStatus(ctx, libcommon.Hash{1})
+	require.NoError(err)
+	require.Equal("unknown", status.Status)
+
+	discards, err := api.DiscardsFrom(ctx, m.Address)
+	require.NoError(err)
+	require.Len(discards, 1)
+	require.Equal(replaced.Hash(), discards[0].Hash)
+}
+
+func TestNewRPCDiscardReason(t *testing.T) {
+	reason := func(r uint32) string {
+		return newRPCDiscard(&txPoolProto.DiscardEntry{Hash: gointerfaces.ConvertHashToH256(libcommon.Hash{1}), Sender: gointerfaces.ConvertAddressToH160(libcommon.Address{1}), Reason: r}).Reason
+	}
+	require.Equal(t, "rejected by txpool policy", reason(uint32(txpoolcfg.PolicyRejected)))
+	require.Equal(t, "unknown (200)", reason(200))
+	require.Equal(t, "unknown (1000)", reason(1000))
 }
@@ -19,6 +19,9 @@ import (
 	"github.com/tenderly/erigon/params"
 )
 
+// CreateTestSegmentFile is createTestSegmentFile for the tests of the freezeblocks_test package
+var CreateTestSegmentFile = createTestSegmentFile
+
 func createTestSegmentFile(t *testing.T, from, to uint64, name snaptype.Type, dir string, logger log.Logger) {
 	c, err := compress.NewCompressor(context.Background(), "test", filepath.Join(dir, snaptype.SegmentFileName(from, to, name)), dir, 100, 1, log.LvlDebug, logger)
 	require.NoError(t, err)
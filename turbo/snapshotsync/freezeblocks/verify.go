@@ -0,0 +1,444 @@
+package freezeblocks
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/exp/slices"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tenderly/erigon/erigon-lib/chain/snapcfg"
+	"github.com/tenderly/erigon/erigon-lib/common/cmp"
+	"github.com/tenderly/erigon/erigon-lib/common/dir"
+	"github.com/tenderly/erigon/erigon-lib/common/length"
+	"github.com/tenderly/erigon/erigon-lib/compress"
+	"github.com/tenderly/erigon/erigon-lib/downloader/downloadercfg"
+	"github.com/tenderly/erigon/erigon-lib/downloader/snaptype"
+	"github.com/tenderly/erigon/erigon-lib/recsplit"
+
+	"github.com/tenderly/erigon/core/types"
+	"github.com/tenderly/erigon/rlp"
+)
+
+type VerifyCfg struct {
+	Preverified snapcfg.Preverified // info hashes the files must have, the files not listed are only hashed
+	NoHashes    bool                // skip hashing the files, which reads all of them
+	Sample      int                 // words decoded per segment, spread over the file
+	Workers     int
+}
+
+// VerifyReport is the outcome of VerifySnapshots, it is ok when no issue is found in any file or across them
+type VerifyReport struct {
+	Ok     bool            `json:"ok"`
+	Files  []*VerifiedFile `json:"files"`
+	Issues []string        `json:"issues,omitempty"` // gaps, overlaps and missing files across the types
+}
+
+type VerifiedFile struct {
+	Name        string   `json:"name"`
+	Hash        string   `json:"hash,omitempty"` // info hash of the file content
+	Preverified bool     `json:"preverified"`
+	Words       int      `json:"words,omitempty"`
+	Keys        uint64   `json:"keys,omitempty"`
+	BaseDataID  uint64   `json:"baseDataID,omitempty"`
+	Sampled     int      `json:"sampled,omitempty"`
+	Issues      []string `json:"issues,omitempty"`
+}
+
+func (f *VerifiedFile) issue(format string, args ...interface{}) {
+	f.Issues = append(f.Issues, fmt.Sprintf(format, args...))
+}
+
+// VerifySnapshots checks the block snapshots of the directory end to end:
+//   - the info hashes of the .seg and .idx files, against the preverified list and their .torrent files
+//   - every .seg has its .idx files, with one key per word and the base data id the readers expect
+//   - the block ranges of each type have no gaps nor overlaps, and all the types cover the same ranges
+//   - a sample of the words of every .seg decodes
+//
+// The problems of the files go to the report, the returned error is about what prevented the verification.
+func VerifySnapshots(ctx context.Context, snapDir string, cfg VerifyCfg, logger log.Logger) (*VerifyReport, error) {
+	parsed, err := snaptype.ParseDir(snapDir)
+	if err != nil {
+		return nil, err
+	}
+	preverified := make(map[string]string, len(cfg.Preverified))
+	for _, p := range cfg.Preverified {
+		preverified[p.Name] = p.Hash
+	}
+
+	report := &VerifyReport{}
+	var segments []snaptype.FileInfo
+	files := map[string]*VerifiedFile{}
+	for _, f := range parsed {
+		if f.Ext != ".seg" && f.Ext != ".idx" {
+			continue
+		}
+		name := filepath.Base(f.Path)
+		vf := &VerifiedFile{Name: name}
+		_, vf.Preverified = preverified[name]
+		report.Files = append(report.Files, vf)
+		files[name] = vf
+		switch f.Ext {
+		case ".seg":
+			segments = append(segments, f)
+		case ".idx":
+			if !dir.FileExist(filepath.Join(snapDir, snaptype.SegmentFileName(f.From, f.To, f.T))) {
+				vf.issue("index without segment")
+			}
+		}
+	}
+
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	// a segment is verified with its indices, and hashed apart from them, so each file is checked by one goroutine at a time
+	if err := verifyEach(ctx, "segments", segments, cfg.Workers, logEvery, logger, func(f snaptype.FileInfo) error {
+		return verifySegment(ctx, snapDir, f, cfg, files)
+	}); err != nil {
+		return nil, err
+	}
+	if !cfg.NoHashes {
+		if err := verifyEach(ctx, "hashes", report.Files, cfg.Workers, logEvery, logger, func(vf *VerifiedFile) error {
+			return verifyHash(ctx, snapDir, vf, preverified[vf.Name])
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	report.Issues = verifyRanges(segments)
+	report.Ok = len(report.Issues) == 0
+	for _, vf := range report.Files {
+		report.Ok = report.Ok && len(vf.Issues) == 0
+	}
+	return report, nil
+}
+
+func verifyEach[T any](ctx context.Context, what string, items []T, workers int, logEvery *time.Ticker, logger log.Logger, f func(T) error) error {
+	var done atomic.Int64
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(cmp.Max(workers, 1))
+	for _, item := range items {
+		item := item
+		g.Go(func() error {
+			defer done.Add(1)
+			return f(item)
+		})
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-logEvery.C:
+				logger.Info("[snapshots] Verify", "step", what, "progress", fmt.Sprintf("%d/%d", done.Load(), len(items)))
+			}
+		}
+	}()
+	return g.Wait()
+}
+
+// verifyHash compares the info hash of the file with the preverified one, when known, and the one of its .torrent file
+func verifyHash(ctx context.Context, snapDir string, vf *VerifiedFile, expected string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	fPath := filepath.Join(snapDir, vf.Name)
+	info := &metainfo.Info{PieceLength: downloadercfg.DefaultPieceSize, Name: vf.Name}
+	if err := info.BuildFromFilePath(fPath); err != nil {
+		return fmt.Errorf("hashing %s: %w", vf.Name, err)
+	}
+	info.Name = vf.Name
+	infoBytes, err := bencode.Marshal(info)
+	if err != nil {
+		return err
+	}
+	vf.Hash = metainfo.HashBytes(infoBytes).HexString()
+	if expected != "" && expected != vf.Hash {
+		vf.issue("info hash %s, preverified %s", vf.Hash, expected)
+	}
+	if dir.FileExist(fPath + ".torrent") {
+		mi, err := metainfo.LoadFromFile(fPath + ".torrent")
+		if err != nil {
+			vf.issue("invalid torrent file: %s", err)
+		} else if torrentHash := mi.HashInfoBytes().HexString(); torrentHash != vf.Hash {
+			vf.issue("info hash %s, torrent file %s", vf.Hash, torrentHash)
+		}
+	}
+	return nil
+}
+
+// verifySegment checks the words of the segment and its indices
+func verifySegment(ctx context.Context, snapDir string, f snaptype.FileInfo, cfg VerifyCfg, files map[string]*VerifiedFile) error {
+	vf := files[filepath.Base(f.Path)]
+	seg, err := compress.NewDecompressor(f.Path)
+	if err != nil {
+		vf.issue("can't open: %s", err)
+		return nil
+	}
+	defer seg.Close()
+	vf.Words = seg.Count()
+
+	var baseDataID uint64
+	expectedWords, baseKnown := -1, true
+	switch f.T {
+	case snaptype.Transactions:
+		firstBody, lastBody, err := firstLastBodies(filepath.Join(snapDir, snaptype.SegmentFileName(f.From, f.To, snaptype.Bodies)))
+		if err != nil {
+			vf.issue("can't read the bodies: %s", err)
+			baseKnown = false
+			break
+		}
+		baseDataID = firstBody.BaseTxId
+		expectedWords = int(lastBody.BaseTxId + uint64(lastBody.TxAmount) - firstBody.BaseTxId)
+	case snaptype.Headers, snaptype.Bodies, snaptype.BeaconBlocks:
+		baseDataID = f.From
+		if f.T != snaptype.BeaconBlocks {
+			expectedWords = int(f.To - f.From)
+		}
+	case snaptype.BorSpans:
+		if f.From > zerothSpanEnd {
+			baseDataID = 1 + (f.From-zerothSpanEnd-1)/spanLength
+		}
+	}
+	if expectedWords >= 0 && seg.Count() != expectedWords {
+		vf.issue("%d words, expected %d", seg.Count(), expectedWords)
+	}
+
+	decode := sampleDecoder(f)
+	every := 1
+	if cfg.Sample > 0 && seg.Count() > cfg.Sample {
+		every = seg.Count() / cfg.Sample
+	}
+	var word []byte
+	var i, keys int // keys of the bor events index, one per block
+	var lastBlockNum []byte
+	g := seg.MakeGetter()
+	for ; g.HasNext(); i++ {
+		if i%1_000_000 == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+		sample := cfg.Sample > 0 && i%every == 0 && vf.Sampled < cfg.Sample
+		if !sample && f.T != snaptype.BorEvents {
+			g.Skip()
+			continue
+		}
+		word, _ = g.Next(word[:0])
+		if f.T == snaptype.BorEvents {
+			if len(word) < length.Hash+length.BlockNum+8 {
+				vf.issue("word %d: %d bytes, too short for an event", i, len(word))
+				break
+			}
+			if i == 0 {
+				baseDataID = binary.BigEndian.Uint64(word[length.Hash+length.BlockNum:])
+			}
+			if blockNum := word[length.Hash : length.Hash+length.BlockNum]; !bytes.Equal(blockNum, lastBlockNum) {
+				keys++
+				lastBlockNum = append(lastBlockNum[:0], blockNum...)
+			}
+		}
+		if !sample {
+			continue
+		}
+		vf.Sampled++
+		if err := decode(uint64(i), word); err != nil {
+			vf.issue("word %d: %s", i, err)
+		}
+	}
+	if i != seg.Count() {
+		vf.issue("%d words read, %d in the header", i, seg.Count())
+	}
+	if f.T != snaptype.BorEvents {
+		keys = seg.Count()
+	}
+
+	idxTypes := []string{f.T.String()}
+	if f.T == snaptype.Transactions {
+		idxTypes = append(idxTypes, snaptype.Transactions2Block.String())
+	}
+	for _, idxType := range idxTypes {
+		idxName := snaptype.IdxFileName(f.From, f.To, idxType)
+		if !dir.FileExist(filepath.Join(snapDir, idxName)) {
+			vf.issue("no index %s", idxName)
+			continue
+		}
+		vidx := files[idxName]
+		idx, err := recsplit.OpenIndex(filepath.Join(snapDir, idxName))
+		if err != nil {
+			vidx.issue("can't open: %s", err)
+			continue
+		}
+		vidx.Keys, vidx.BaseDataID = idx.KeyCount(), idx.BaseDataID()
+		idx.Close()
+		if vidx.Keys != uint64(keys) {
+			vidx.issue("%d keys, expected %d", vidx.Keys, keys)
+		}
+		expectedBase := baseDataID
+		if idxType == snaptype.Transactions2Block.String() {
+			expectedBase = f.From
+		}
+		if keys > 0 && (baseKnown || idxType == snaptype.Transactions2Block.String()) && vidx.BaseDataID != expectedBase {
+			vidx.issue("base data id %d, expected %d", vidx.BaseDataID, expectedBase)
+		}
+	}
+	return nil
+}
+
+// sampleDecoder returns the check of the i-th word of the segment, in the format written by its dump
+func sampleDecoder(f snaptype.FileInfo) func(i uint64, word []byte) error {
+	switch f.T {
+	case snaptype.Headers:
+		return func(i uint64, word []byte) error {
+			if len(word) == 0 {
+				return fmt.Errorf("empty header")
+			}
+			h := &types.Header{}
+			if err := rlp.DecodeBytes(word[1:], h); err != nil {
+				return err
+			}
+			if h.Number.Uint64() != f.From+i {
+				return fmt.Errorf("header %d, expected %d", h.Number.Uint64(), f.From+i)
+			}
+			if h.Hash()[0] != word[0] {
+				return fmt.Errorf("header %d hash doesn't start with %x", h.Number.Uint64(), word[0])
+			}
+			return nil
+		}
+	case snaptype.Bodies:
+		return func(i uint64, word []byte) error {
+			return rlp.DecodeBytes(word, &types.BodyForStorage{})
+		}
+	case snaptype.Transactions:
+		return func(i uint64, word []byte) error {
+			if len(word) == 0 { // system transaction
+				return nil
+			}
+			if len(word) < 1+length.Addr {
+				return fmt.Errorf("%d bytes, too short for a transaction", len(word))
+			}
+			txn, err := types.DecodeTransaction(word[1+length.Addr:])
+			if err != nil {
+				return err
+			}
+			if txn.Hash()[0] != word[0] {
+				return fmt.Errorf("transaction %x hash doesn't start with %x", txn.Hash(), word[0])
+			}
+			return nil
+		}
+	case snaptype.BorEvents:
+		return func(i uint64, word []byte) error {
+			blockNum := binary.BigEndian.Uint64(word[length.Hash : length.Hash+length.BlockNum])
+			if blockNum < f.From || blockNum >= f.To {
+				return fmt.Errorf("event of block %d", blockNum)
+			}
+			return nil
+		}
+	case snaptype.BorSpans:
+		return func(i uint64, word []byte) error {
+			if !json.Valid(word) {
+				return fmt.Errorf("invalid span")
+			}
+			return nil
+		}
+	default:
+		return func(uint64, []byte) error { return nil }
+	}
+}
+
+func firstLastBodies(bodiesPath string) (first, last *types.BodyForStorage, err error) {
+	bodies, err := compress.NewDecompressor(bodiesPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer bodies.Close()
+	if bodies.Count() == 0 {
+		return nil, nil, fmt.Errorf("no bodies")
+	}
+	g := bodies.MakeGetter()
+	var buf []byte
+	first, last = &types.BodyForStorage{}, &types.BodyForStorage{}
+	for i := 0; g.HasNext(); i++ {
+		if i != 0 && i != bodies.Count()-1 {
+			g.Skip()
+			continue
+		}
+		buf, _ = g.Next(buf[:0])
+		if i == 0 {
+			if err := rlp.DecodeBytes(buf, first); err != nil {
+				return nil, nil, err
+			}
+		}
+		if i == bodies.Count()-1 {
+			if err := rlp.DecodeBytes(buf, last); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return first, last, nil
+}
+
+// verifyRanges finds the gaps and overlaps between the segments of each type, and the block ranges not covered by
+// all the types: the headers, bodies and transactions, and the bor ones when there are some
+func verifyRanges(segments []snaptype.FileInfo) (issues []string) {
+	ranges := map[snaptype.Type][]snaptype.FileInfo{}
+	for _, f := range segments {
+		ranges[f.T] = append(ranges[f.T], f)
+	}
+	types := []snaptype.Type{snaptype.Headers, snaptype.Bodies, snaptype.Transactions}
+	if len(ranges[snaptype.BorEvents]) > 0 || len(ranges[snaptype.BorSpans]) > 0 {
+		types = append(types, snaptype.BorEvents, snaptype.BorSpans)
+	}
+	type blockRange struct{ from, to uint64 }
+	covered := map[blockRange][]snaptype.Type{}
+	for _, t := range types {
+		var to uint64
+		for _, f := range ranges[t] { // sorted by ParseDir
+			switch {
+			case f.From > to:
+				issues = append(issues, fmt.Sprintf("%s: gap %d-%d", t, to, f.From))
+			case f.From < to:
+				issues = append(issues, fmt.Sprintf("%s: %d-%d overlaps the previous segment up to %d", t, f.From, f.To, to))
+			}
+			to = cmp.Max(to, f.To)
+			r := blockRange{f.From, f.To}
+			covered[r] = append(covered[r], t)
+		}
+	}
+	rangeList := make([]blockRange, 0, len(covered))
+	for r := range covered {
+		rangeList = append(rangeList, r)
+	}
+	slices.SortFunc(rangeList, func(a, b blockRange) int {
+		if a.from != b.from {
+			return cmp.Compare(a.from, b.from)
+		}
+		return cmp.Compare(a.to, b.to)
+	})
+	for _, r := range rangeList {
+		if len(covered[r]) == len(types) {
+			continue
+		}
+		var missing []string
+		for _, t := range types {
+			if !slices.Contains(covered[r], t) {
+				missing = append(missing, t.String())
+			}
+		}
+		issues = append(issues, fmt.Sprintf("%d-%d: no %s segment", r.from, r.to, strings.Join(missing, ", ")))
+	}
+	return issues
+}
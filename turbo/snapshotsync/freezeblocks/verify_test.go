@@ -0,0 +1,75 @@
+package freezeblocks_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+	"github.com/tenderly/erigon/erigon-lib/chain/snapcfg"
+	"github.com/tenderly/erigon/erigon-lib/downloader/snaptype"
+
+	"github.com/tenderly/erigon/params"
+	"github.com/tenderly/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+func TestVerifySnapshots(t *testing.T) {
+	logger := log.New()
+	dir, require := t.TempDir(), require.New(t)
+	createFile := func(from, to uint64, name snaptype.Type) {
+		freezeblocks.CreateTestSegmentFile(t, from, to, name, dir, logger)
+	}
+	createFile(0, 500_000, snaptype.Headers)
+	createFile(0, 500_000, snaptype.Bodies)
+	createFile(0, 500_000, snaptype.Transactions)
+	createFile(500_000, 1_000_000, snaptype.Headers)
+	createFile(1_500_000, 2_000_000, snaptype.Headers)
+
+	headers := snaptype.SegmentFileName(0, 500_000, snaptype.Headers)
+	report, err := freezeblocks.VerifySnapshots(context.Background(), dir, freezeblocks.VerifyCfg{
+		Preverified: snapcfg.Preverified{{Name: headers, Hash: "00"}},
+		Sample:      10,
+		Workers:     2,
+	}, logger)
+	require.NoError(err)
+	require.False(report.Ok)
+	require.Equal([]string{
+		"headers: gap 1000000-1500000",
+		"500000-1000000: no bodies, transactions segment",
+		"1500000-2000000: no bodies, transactions segment",
+	}, report.Issues)
+
+	files := map[string]*freezeblocks.VerifiedFile{}
+	for _, f := range report.Files {
+		files[f.Name] = f
+	}
+	require.Len(files, 11) // 5 segments and their indices, 2 for the transactions
+	f := files[headers]
+	require.True(f.Preverified)
+	require.Len(f.Hash, 40)
+	require.Equal(1, f.Words)
+	require.Equal(1, f.Sampled)
+	issues := strings.Join(f.Issues, "\n")
+	require.Contains(issues, "1 words, expected 500000")
+	require.Contains(issues, "word 0:")
+	require.Contains(issues, "preverified 00")
+
+	idx := files[snaptype.IdxFileName(0, 500_000, snaptype.Headers.String())]
+	require.Equal(uint64(1), idx.Keys)
+	require.Empty(idx.Issues)
+	idx = files[snaptype.IdxFileName(500_000, 1_000_000, snaptype.Headers.String())]
+	require.Equal([]string{"base data id 0, expected 500000"}, idx.Issues)
+}
+
+func TestVerifyDumpedSnapshots(t *testing.T) {
+	logger, require := log.New(), require.New(t)
+	m := createDumpTestKV(t, params.TestChainConfig, 1000)
+	tmpDir, snapDir := t.TempDir(), t.TempDir()
+	require.NoError(freezeblocks.DumpBlocks(m.Ctx, 0, 1000, 1000, tmpDir, snapDir, 0, m.DB, 1, log.LvlInfo, logger, m.BlockReader))
+
+	report, err := freezeblocks.VerifySnapshots(m.Ctx, snapDir, freezeblocks.VerifyCfg{Sample: 100, Workers: 2}, logger)
+	require.NoError(err)
+	require.True(report.Ok, "%+v", report.Issues)
+	require.Len(report.Files, 7) // 3 segments, 4 indices
+}
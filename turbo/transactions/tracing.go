@@ -124,12 +124,14 @@ func ComputeTxEnv(ctx context.Context, engine consensus.EngineReader, block *typ
 
 // TraceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
-// be tracer dependent.
+// be tracer dependent. tracerCtx carries the position of the transaction in its block
+// and may be nil for calls which are not part of a block.
 func TraceTx(
 	ctx context.Context,
 	message core.Message,
 	blockCtx evmtypes.BlockContext,
 	txCtx evmtypes.TxContext,
+	tracerCtx *tracers.Context,
 	ibs evmtypes.IntraBlockState,
 	config *tracers.TraceConfig,
 	chainConfig *chain.Config,
@@ -157,19 +159,25 @@ func TraceTx(
 		if config != nil && config.TracerConfig != nil {
 			cfg = *config.TracerConfig
 		}
-		if tracer, err = tracers.New(*config.Tracer, &tracers.Context{
-			TxHash: txCtx.TxHash,
-		}, cfg); err != nil {
+		if tracerCtx == nil {
+			tracerCtx = &tracers.Context{TxHash: txCtx.TxHash}
+		}
+		if tracer, err = tracers.New(*config.Tracer, tracerCtx, cfg); err != nil {
 			stream.WriteNil()
 			return err
 		}
-		// Handle timeouts and RPC cancellations
+		// Handle timeouts and RPC cancellations, until the trace returns
 		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		done := make(chan struct{})
+		defer close(done)
 		go func() {
-			<-deadlineCtx.Done()
-			tracer.(tracers.Tracer).Stop(errors.New("execution timeout"))
+			select {
+			case <-deadlineCtx.Done():
+				tracer.(tracers.Tracer).Stop(errors.New("execution timeout"))
+			case <-done:
+			}
 		}()
-		defer cancel()
 		streaming = false
 
 	case config == nil: